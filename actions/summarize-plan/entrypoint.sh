@@ -12,6 +12,9 @@ COMPACT="${INPUT_COMPACT:-false}"
 VERBOSE="${INPUT_VERBOSE:-false}"
 SHOW_DETAILS="${INPUT_SHOW_DETAILS:-false}"
 COLOR="${INPUT_COLOR:-auto}"
+PR_COMMENT="${INPUT_PR_COMMENT:-false}"
+COMMENT_MARKER="${INPUT_COMMENT_MARKER:-terraform-ops:summarize-plan}"
+COMMENT_TOKEN="${INPUT_GITHUB_TOKEN:-${GITHUB_TOKEN}}"
 
 # Validate required inputs
 if [ -z "${PLAN_FILE}" ]; then
@@ -25,6 +28,12 @@ if [ ! -f "${PLAN_FILE}" ]; then
 	exit 1
 fi
 
+# Publishing needs a token from the github-token input or the GITHUB_TOKEN env
+if [ "${PR_COMMENT}" = "true" ] && [ -z "${COMMENT_TOKEN}" ]; then
+	echo "Error: pr-comment requires the github-token input or a GITHUB_TOKEN environment variable"
+	exit 1
+fi
+
 # Build command arguments
 ARGS="summarize-plan"
 ARGS="${ARGS} --format ${FORMAT}"
@@ -73,4 +82,16 @@ else
 	echo "${SUMMARY_CONTENT}"
 fi
 
+# Publish the summary as a sticky pull request comment
+if [ "${PR_COMMENT}" = "true" ]; then
+	COMMENT_FILE="${OUTPUT_FILE}"
+	if [ -z "${COMMENT_FILE}" ]; then
+		COMMENT_FILE=$(mktemp)
+		echo "${SUMMARY_CONTENT}" >"${COMMENT_FILE}"
+	fi
+	GITHUB_TOKEN="${COMMENT_TOKEN}" /app/terraform-ops publish github-comment \
+		--body-file "${COMMENT_FILE}" \
+		--marker "${COMMENT_MARKER}"
+fi
+
 echo "Plan summary generation completed successfully"
@@ -296,6 +296,7 @@ func checkOutputContains(t *testing.T, output, testName string) {
 			"Total Changes:",
 			"📊 Statistics",
 			"🔄 Resource Changes",
+			"🏢 random (7: 7 create)",
 			"random_id.test_id",
 			"random_string.test_string",
 			"random_password.test_password",
@@ -309,6 +310,8 @@ func checkOutputContains(t *testing.T, output, testName string) {
 			"Total Changes:",
 			"📊 Statistics",
 			"🔄 Resource Changes",
+			"📦 Root Module (5: 5 create)",
+			"📦 module.myrandom (2: 2 create)",
 			"random_id.test_id",
 			"random_string.test_string",
 			"random_password.test_password",
@@ -316,17 +319,14 @@ func checkOutputContains(t *testing.T, output, testName string) {
 			"module.myrandom.random_string.test_string",
 		},
 		"compact_output": {
-			"Terraform Plan Summary",
-			"Plan Status:",
-			"Format Version:",
-			"Total Changes:",
-			"📊 Statistics",
-			"🔄 Resource Changes",
-			"random_id.test_id",
-			"random_string.test_string",
-			"random_password.test_password",
-			"module.myrandom.random_integer.test_integer",
-			"module.myrandom.random_string.test_string",
+			"Terraform Plan Summary: ",
+			"7 changes (7 create)",
+			"create (7):",
+			"  + random_id.test_id",
+			"  + random_string.test_string",
+			"  + random_password.test_password",
+			"  + module.myrandom.random_integer.test_integer",
+			"  + module.myrandom.random_string.test_string",
 		},
 		"no_sensitive_data": {
 			"Terraform Plan Summary",
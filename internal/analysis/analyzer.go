@@ -29,6 +29,7 @@ type Analyzer interface {
 
 type Registry struct {
 	analyzers []Analyzer
+	settings  RuleSettings
 }
 
 func NewRegistry(analyzers ...Analyzer) *Registry {
@@ -40,12 +41,55 @@ func DefaultRegistry() *Registry {
 		planAnalyzer{},
 		checkAnalyzer{},
 		lifecycleAnalyzer{},
+		NewStatefulDestroyAnalyzer(DefaultResourceCatalog()),
 		driftAnalyzer{},
 		sensitivityAnalyzer{},
 		unknownAnalyzer{},
 	)
 }
 
+// With returns a new registry that runs r's analyzers followed by analyzers.
+// The receiver is left unchanged so a shared default registry can be extended
+// per invocation.
+func (r *Registry) With(analyzers ...Analyzer) *Registry {
+	combined := make([]Analyzer, 0, len(r.analyzers)+len(analyzers))
+	combined = append(combined, r.analyzers...)
+	combined = append(combined, analyzers...)
+	return &Registry{analyzers: combined, settings: r.settings}
+}
+
+// WithAnalyzer returns a new registry in which analyzer takes the place of
+// the analyzer with the same ID, or runs last when there is none. It lets a
+// command reconfigure a built-in analyzer without rebuilding the registry.
+func (r *Registry) WithAnalyzer(analyzer Analyzer) *Registry {
+	combined := append([]Analyzer(nil), r.analyzers...)
+	for i, existing := range combined {
+		if existing.ID() == analyzer.ID() {
+			combined[i] = analyzer
+			return &Registry{analyzers: combined, settings: r.settings}
+		}
+	}
+	return &Registry{analyzers: append(combined, analyzer), settings: r.settings}
+}
+
+// WithResourceCatalogs returns a registry whose stateful-destroy analyzer uses
+// the built-in catalog extended by each catalog file, later files taking
+// precedence. With no paths the receiver is returned unchanged.
+func (r *Registry) WithResourceCatalogs(paths []string) (*Registry, error) {
+	if len(paths) == 0 {
+		return r, nil
+	}
+	catalog := DefaultResourceCatalog()
+	for _, path := range paths {
+		extension, err := LoadResourceCatalog(path)
+		if err != nil {
+			return nil, err
+		}
+		catalog = catalog.Extend(extension)
+	}
+	return r.WithAnalyzer(NewStatefulDestroyAnalyzer(catalog)), nil
+}
+
 func (r *Registry) Analyze(ctx context.Context, changeSet *ir.ChangeSet) ([]report.Finding, error) {
 	if changeSet == nil {
 		return nil, fmt.Errorf("change set is nil")
@@ -55,13 +99,39 @@ func (r *Registry) Analyze(ctx context.Context, changeSet *ir.ChangeSet) ([]repo
 		if err := ctx.Err(); err != nil {
 			return nil, err
 		}
+		if !r.runs(analyzer) {
+			continue
+		}
 		items, err := analyzer.Analyze(ctx, changeSet)
 		if err != nil {
 			return nil, fmt.Errorf("analyzer %s: %w", analyzer.ID(), err)
 		}
-		findings = append(findings, items...)
+		for _, finding := range items {
+			if !r.settings.Enabled(finding.RuleID) {
+				continue
+			}
+			if severity, ok := r.settings.Severities[finding.RuleID]; ok {
+				finding.Severity = severity
+			}
+			findings = append(findings, finding)
+		}
 	}
 	wrapper := report.AnalysisReport{Findings: findings}
 	report.Sort(&wrapper)
 	return wrapper.Findings, nil
 }
+
+// runs reports whether analyzer can produce an enabled finding. Analyzers that
+// do not describe their rules always run.
+func (r *Registry) runs(analyzer Analyzer) bool {
+	provider, ok := analyzer.(RuleProvider)
+	if !ok {
+		return true
+	}
+	for _, rule := range provider.Rules() {
+		if r.settings.Enabled(rule.ID) {
+			return true
+		}
+	}
+	return false
+}
@@ -24,25 +24,26 @@ import (
 
 type planAnalyzer struct{}
 
-func (planAnalyzer) ID() string { return "plan" }
+func (planAnalyzer) ID() string    { return "plan" }
+func (planAnalyzer) Rules() []Rule { return []Rule{rulePlanErrored, rulePlanIncomplete} }
 func (planAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.Finding, error) {
 	var findings []report.Finding
 	if cs.Plan.Errored {
 		findings = append(findings, report.Finding{
-			RuleID:     "TFOPS-PLAN-ERRORED",
-			Title:      "Terraform/OpenTofu planning errored",
-			Category:   report.CategoryPlan,
-			Severity:   report.SeverityHigh,
+			RuleID:     rulePlanErrored.ID,
+			Title:      rulePlanErrored.Title,
+			Category:   rulePlanErrored.Category,
+			Severity:   rulePlanErrored.Severity,
 			Confidence: report.ConfidenceExact,
 			Message:    "Planning reported an error; the available change set may be partial and cannot be safely treated as a complete plan.",
 		})
 	}
 	if !cs.Plan.Complete {
 		findings = append(findings, report.Finding{
-			RuleID:     "TFOPS-PLAN-INCOMPLETE",
-			Title:      "Plan is incomplete",
-			Category:   report.CategoryUncertainty,
-			Severity:   report.SeverityMedium,
+			RuleID:     rulePlanIncomplete.ID,
+			Title:      rulePlanIncomplete.Title,
+			Category:   rulePlanIncomplete.Category,
+			Severity:   rulePlanIncomplete.Severity,
 			Confidence: report.ConfidenceExact,
 			Message:    "The plan is not complete; an additional plan/apply round may be required to converge.",
 		})
@@ -52,7 +53,8 @@ func (planAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.Findi
 
 type checkAnalyzer struct{}
 
-func (checkAnalyzer) ID() string { return "checks" }
+func (checkAnalyzer) ID() string    { return "checks" }
+func (checkAnalyzer) Rules() []Rule { return []Rule{ruleCheckFailed} }
 func (checkAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.Finding, error) {
 	var findings []report.Finding
 	for _, check := range cs.Checks {
@@ -60,10 +62,10 @@ func (checkAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.Find
 			continue
 		}
 		findings = append(findings, report.Finding{
-			RuleID:     "TFOPS-CHECK-FAILED",
-			Title:      "Terraform/OpenTofu check failed",
-			Category:   report.CategoryValidation,
-			Severity:   report.SeverityHigh,
+			RuleID:     ruleCheckFailed.ID,
+			Title:      ruleCheckFailed.Title,
+			Category:   ruleCheckFailed.Category,
+			Severity:   ruleCheckFailed.Severity,
 			Confidence: report.ConfidenceExact,
 			Resource:   &report.ResourceRef{Address: check.Address},
 			Evidence: []report.Evidence{{
@@ -79,7 +81,8 @@ func (checkAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.Find
 
 type lifecycleAnalyzer struct{}
 
-func (lifecycleAnalyzer) ID() string { return "lifecycle" }
+func (lifecycleAnalyzer) ID() string    { return "lifecycle" }
+func (lifecycleAnalyzer) Rules() []Rule { return []Rule{ruleLifecycleDelete, ruleLifecycleReplace} }
 func (lifecycleAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.Finding, error) {
 	var findings []report.Finding
 	for _, resource := range cs.Resources {
@@ -89,10 +92,10 @@ func (lifecycleAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.
 		switch {
 		case resource.Action.Semantic == ir.ActionDelete:
 			findings = append(findings, report.Finding{
-				RuleID:     "TFOPS-LIFECYCLE-DELETE",
-				Title:      "Managed resource deletion",
-				Category:   report.CategoryLifecycle,
-				Severity:   report.SeverityMedium,
+				RuleID:     ruleLifecycleDelete.ID,
+				Title:      ruleLifecycleDelete.Title,
+				Category:   ruleLifecycleDelete.Category,
+				Severity:   ruleLifecycleDelete.Severity,
 				Confidence: report.ConfidenceExact,
 				Resource:   resourceRef(resource),
 				Evidence: []report.Evidence{{
@@ -108,13 +111,7 @@ func (lifecycleAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.
 				Description: string(resource.Action.Semantic),
 				Source:      "resource_changes.change.actions",
 			}}
-			for _, path := range resource.ReplacePaths {
-				evidence = append(evidence, report.Evidence{
-					Kind:   "replace_path",
-					Path:   path.String(),
-					Source: "resource_changes.change.replace_paths",
-				})
-			}
+			evidence = append(evidence, replaceCauseEvidence(resource)...)
 			if resource.ActionReason != "" {
 				evidence = append(evidence, report.Evidence{
 					Kind:        "action_reason",
@@ -123,14 +120,14 @@ func (lifecycleAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.
 				})
 			}
 			findings = append(findings, report.Finding{
-				RuleID:     "TFOPS-LIFECYCLE-REPLACE",
-				Title:      "Managed resource replacement",
-				Category:   report.CategoryLifecycle,
-				Severity:   report.SeverityMedium,
+				RuleID:     ruleLifecycleReplace.ID,
+				Title:      ruleLifecycleReplace.Title,
+				Category:   ruleLifecycleReplace.Category,
+				Severity:   ruleLifecycleReplace.Severity,
 				Confidence: report.ConfidenceExact,
 				Resource:   resourceRef(resource),
 				Evidence:   evidence,
-				Message:    "A managed resource is planned for replacement.",
+				Message:    replaceCauseMessage(evidence),
 			})
 		}
 	}
@@ -139,7 +136,8 @@ func (lifecycleAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.
 
 type driftAnalyzer struct{}
 
-func (driftAnalyzer) ID() string { return "drift" }
+func (driftAnalyzer) ID() string    { return "drift" }
+func (driftAnalyzer) Rules() []Rule { return []Rule{ruleDriftDetected} }
 func (driftAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.Finding, error) {
 	var findings []report.Finding
 	for _, drift := range cs.Drift {
@@ -156,10 +154,10 @@ func (driftAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.Find
 			})
 		}
 		findings = append(findings, report.Finding{
-			RuleID:     "TFOPS-DRIFT-DETECTED",
-			Title:      "External drift detected",
-			Category:   report.CategoryDrift,
-			Severity:   report.SeverityMedium,
+			RuleID:     ruleDriftDetected.ID,
+			Title:      ruleDriftDetected.Title,
+			Category:   ruleDriftDetected.Category,
+			Severity:   ruleDriftDetected.Severity,
 			Confidence: report.ConfidenceExact,
 			Resource:   resourceRef(drift.Resource),
 			Evidence:   evidence,
@@ -171,7 +169,8 @@ func (driftAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.Find
 
 type sensitivityAnalyzer struct{}
 
-func (sensitivityAnalyzer) ID() string { return "sensitivity" }
+func (sensitivityAnalyzer) ID() string    { return "sensitivity" }
+func (sensitivityAnalyzer) Rules() []Rule { return []Rule{ruleSensitiveMutation} }
 func (sensitivityAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.Finding, error) {
 	var findings []report.Finding
 	for _, resource := range cs.Resources {
@@ -187,10 +186,10 @@ func (sensitivityAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]repor
 			})
 		}
 		findings = append(findings, report.Finding{
-			RuleID:     "TFOPS-SENSITIVE-MUTATION",
-			Title:      "Sensitive data participates in a resource change",
-			Category:   report.CategorySensitivity,
-			Severity:   report.SeverityInfo,
+			RuleID:     ruleSensitiveMutation.ID,
+			Title:      ruleSensitiveMutation.Title,
+			Category:   ruleSensitiveMutation.Category,
+			Severity:   ruleSensitiveMutation.Severity,
 			Confidence: report.ConfidenceExact,
 			Resource:   resourceRef(resource),
 			Evidence:   evidence,
@@ -202,7 +201,8 @@ func (sensitivityAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]repor
 
 type unknownAnalyzer struct{}
 
-func (unknownAnalyzer) ID() string { return "unknown" }
+func (unknownAnalyzer) ID() string    { return "unknown" }
+func (unknownAnalyzer) Rules() []Rule { return []Rule{ruleUnknownAfter} }
 func (unknownAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.Finding, error) {
 	var findings []report.Finding
 	for _, resource := range cs.Resources {
@@ -218,10 +218,10 @@ func (unknownAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.Fi
 			})
 		}
 		findings = append(findings, report.Finding{
-			RuleID:     "TFOPS-UNKNOWN-AFTER",
-			Title:      "Post-apply values remain unknown",
-			Category:   report.CategoryUncertainty,
-			Severity:   report.SeverityInfo,
+			RuleID:     ruleUnknownAfter.ID,
+			Title:      ruleUnknownAfter.Title,
+			Category:   ruleUnknownAfter.Category,
+			Severity:   ruleUnknownAfter.Severity,
 			Confidence: report.ConfidenceExact,
 			Resource:   resourceRef(resource),
 			Evidence:   evidence,
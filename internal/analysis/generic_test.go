@@ -57,3 +57,54 @@ func TestDefaultRegistryProducesEvidenceBackedFindings(t *testing.T) {
 		t.Fatal("replacement finding omitted replace_path evidence")
 	}
 }
+
+func TestRegistrySeverityOverridesApplyToAddedAnalyzers(t *testing.T) {
+	cs := &ir.ChangeSet{
+		Plan: ir.PlanMetadata{Applyable: true, Complete: true},
+		Drift: []ir.DriftChange{{Resource: ir.ResourceChange{
+			Address: "test_resource.db",
+			Mode:    ir.ResourceModeManaged,
+			Type:    "test_resource",
+			Action:  ir.NormalizeAction([]string{"update"}),
+		}}},
+	}
+	base := NewRegistry(driftAnalyzer{})
+	registry, err := base.WithRuleSettings(RuleSettings{Severities: map[string]report.Severity{
+		"TFOPS-DRIFT-DETECTED": report.SeverityHigh,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	findings, err := registry.With(unknownAnalyzer{}).Analyze(context.Background(), cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 1 || findings[0].Severity != report.SeverityHigh {
+		t.Fatalf("override was not applied: %#v", findings)
+	}
+	findings, err = base.Analyze(context.Background(), cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if findings[0].Severity != report.SeverityMedium {
+		t.Fatalf("override leaked into the receiver: %#v", findings)
+	}
+}
+
+// testChangeSet returns an applyable, complete plan containing resources.
+func testChangeSet(resources ...ir.ResourceChange) *ir.ChangeSet {
+	return &ir.ChangeSet{
+		Plan:      ir.PlanMetadata{Applyable: true, Complete: true},
+		Resources: resources,
+	}
+}
+
+// testResource returns a managed root module resource change.
+func testResource(address ir.Address, resourceType string, actions ...string) ir.ResourceChange {
+	return ir.ResourceChange{
+		Address: address,
+		Mode:    ir.ResourceModeManaged,
+		Type:    resourceType,
+		Action:  ir.NormalizeAction(actions),
+	}
+}
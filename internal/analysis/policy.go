@@ -0,0 +1,342 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"gopkg.in/yaml.v3"
+
+	"github.com/yu/terraform-ops/internal/glob"
+	"github.com/yu/terraform-ops/internal/ir"
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+// reservedRulePrefix identifies built-in rules. User policies may not claim it
+// so that report consumers can always tell built-in and custom findings apart.
+const reservedRulePrefix = "TFOPS-"
+
+// PolicyFile is the user-facing rules document accepted by analyze --rules.
+// Files ending in .hcl use HCL syntax; every other file is decoded as YAML
+// (which also accepts JSON).
+type PolicyFile struct {
+	Rules []PolicyRule `yaml:"rules" hcl:"rule,block"`
+}
+
+// PolicyRule declares one custom finding. A resource change produces the
+// finding when it satisfies every populated Match criterion.
+type PolicyRule struct {
+	ID          string      `yaml:"id" hcl:"id,label"`
+	Title       string      `yaml:"title" hcl:"title,optional"`
+	Severity    string      `yaml:"severity" hcl:"severity"`
+	Category    string      `yaml:"category" hcl:"category,optional"`
+	Message     string      `yaml:"message" hcl:"message,optional"`
+	Remediation string      `yaml:"remediation" hcl:"remediation,optional"`
+	Match       PolicyMatch `yaml:"match" hcl:"match,block"`
+}
+
+// PolicyMatch lists the selectors for a rule. Within one selector any pattern
+// may match; across selectors all populated selectors must match.
+//
+// Types, Addresses, Modules and Attributes accept glob patterns ('*' and '?').
+// Modules are matched against the resource's module address, with "root" used
+// for root-module resources. Actions name semantic actions (create, update,
+// delete, replace, read, no_op, or a specific replace order); when Actions is
+// empty, no-op and read changes are ignored. Attributes match replacement,
+// unknown-after-apply, sensitive and changed top-level attribute paths.
+type PolicyMatch struct {
+	Types      []string `yaml:"types" hcl:"types,optional"`
+	Addresses  []string `yaml:"addresses" hcl:"addresses,optional"`
+	Modules    []string `yaml:"modules" hcl:"modules,optional"`
+	Actions    []string `yaml:"actions" hcl:"actions,optional"`
+	Attributes []string `yaml:"attributes" hcl:"attributes,optional"`
+}
+
+// LoadPolicyFile reads a rules file and compiles each rule into an Analyzer.
+func LoadPolicyFile(path string) ([]Analyzer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read rules file: %w", err)
+	}
+	var policy PolicyFile
+	if strings.EqualFold(filepath.Ext(path), ".hcl") {
+		err = decodeHCLPolicy(path, data, &policy)
+	} else {
+		err = decodeYAMLPolicy(data, &policy)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decode rules file %s: %w", path, err)
+	}
+	analyzers, err := CompilePolicy(policy, path)
+	if err != nil {
+		return nil, fmt.Errorf("rules file %s: %w", path, err)
+	}
+	return analyzers, nil
+}
+
+func decodeHCLPolicy(path string, data []byte, dest *PolicyFile) error {
+	file, diags := hclparse.NewParser().ParseHCL(data, filepath.Base(path))
+	if diags.HasErrors() {
+		return diags
+	}
+	if diags := gohcl.DecodeBody(file.Body, nil, dest); diags.HasErrors() {
+		return diags
+	}
+	return nil
+}
+
+func decodeYAMLPolicy(data []byte, dest *PolicyFile) error {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(dest); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// CompilePolicy validates a decoded policy and returns one Analyzer per rule.
+// source is recorded as finding evidence so reviewers can trace a custom
+// finding back to the file that declared it.
+func CompilePolicy(policy PolicyFile, source string) ([]Analyzer, error) {
+	seen := make(map[string]struct{}, len(policy.Rules))
+	analyzers := make([]Analyzer, 0, len(policy.Rules))
+	for i, rule := range policy.Rules {
+		compiled, err := compilePolicyRule(rule, source)
+		if err != nil {
+			if rule.ID == "" {
+				return nil, fmt.Errorf("rule #%d: %w", i+1, err)
+			}
+			return nil, fmt.Errorf("rule %s: %w", rule.ID, err)
+		}
+		if _, ok := seen[compiled.rule.ID]; ok {
+			return nil, fmt.Errorf("rule %s: duplicate rule id", compiled.rule.ID)
+		}
+		seen[compiled.rule.ID] = struct{}{}
+		analyzers = append(analyzers, compiled)
+	}
+	return analyzers, nil
+}
+
+func compilePolicyRule(rule PolicyRule, source string) (policyAnalyzer, error) {
+	rule.ID = strings.TrimSpace(rule.ID)
+	if rule.ID == "" {
+		return policyAnalyzer{}, errors.New("id is required")
+	}
+	if strings.HasPrefix(strings.ToUpper(rule.ID), reservedRulePrefix) {
+		return policyAnalyzer{}, fmt.Errorf("id prefix %q is reserved for built-in rules", reservedRulePrefix)
+	}
+	severity, err := report.ParseSeverity(rule.Severity)
+	if err != nil {
+		return policyAnalyzer{}, err
+	}
+	category := report.CategoryPolicy
+	if rule.Category != "" {
+		category, err = parseCategory(rule.Category)
+		if err != nil {
+			return policyAnalyzer{}, err
+		}
+	}
+	match := rule.Match
+	if len(match.Types)+len(match.Addresses)+len(match.Modules)+len(match.Actions)+len(match.Attributes) == 0 {
+		return policyAnalyzer{}, errors.New("match must declare at least one of types, addresses, modules, actions, or attributes")
+	}
+	actions := make(map[ir.ActionKind]struct{})
+	for _, action := range match.Actions {
+		kinds, err := parsePolicyAction(action)
+		if err != nil {
+			return policyAnalyzer{}, err
+		}
+		for _, kind := range kinds {
+			actions[kind] = struct{}{}
+		}
+	}
+	if rule.Title == "" {
+		rule.Title = rule.ID
+	}
+	if rule.Message == "" {
+		rule.Message = fmt.Sprintf("The resource change matches policy rule %s.", rule.ID)
+	}
+	return policyAnalyzer{
+		rule:     rule,
+		severity: severity,
+		category: category,
+		actions:  actions,
+		source:   source,
+	}, nil
+}
+
+func parsePolicyAction(value string) ([]ir.ActionKind, error) {
+	switch normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(value)), "-", "_"); normalized {
+	case "replace":
+		return []ir.ActionKind{ir.ActionReplaceDestroyCreate, ir.ActionReplaceCreateDestroy}, nil
+	case string(ir.ActionCreate), string(ir.ActionUpdate), string(ir.ActionDelete), string(ir.ActionRead),
+		string(ir.ActionNoOp), string(ir.ActionReplaceDestroyCreate), string(ir.ActionReplaceCreateDestroy):
+		return []ir.ActionKind{ir.ActionKind(normalized)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported action %q: use create, update, delete, replace, read, or no_op", value)
+	}
+}
+
+func parseCategory(value string) (report.Category, error) {
+	switch category := report.Category(strings.ToLower(strings.TrimSpace(value))); category {
+	case report.CategoryPlan, report.CategoryLifecycle, report.CategoryValidation, report.CategoryDrift,
+		report.CategorySensitivity, report.CategoryUncertainty, report.CategoryPolicy:
+		return category, nil
+	default:
+		return "", fmt.Errorf("unsupported category %q", value)
+	}
+}
+
+type policyAnalyzer struct {
+	rule     PolicyRule
+	severity report.Severity
+	category report.Category
+	actions  map[ir.ActionKind]struct{}
+	source   string
+}
+
+func (a policyAnalyzer) ID() string { return a.rule.ID }
+func (a policyAnalyzer) Rules() []Rule {
+	return []Rule{{
+		ID:          a.rule.ID,
+		Title:       a.rule.Title,
+		Category:    a.category,
+		Severity:    a.severity,
+		Description: a.rule.Message,
+		Source:      a.source,
+	}}
+}
+func (a policyAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.Finding, error) {
+	var findings []report.Finding
+	for _, resource := range cs.Resources {
+		matchedPaths, ok := a.matches(resource)
+		if !ok {
+			continue
+		}
+		evidence := []report.Evidence{
+			{
+				Kind:        "policy_rule",
+				Description: a.rule.ID,
+				Source:      a.source,
+			},
+			{
+				Kind:        "action",
+				Description: string(resource.Action.Semantic),
+				Source:      "resource_changes.change.actions",
+			},
+		}
+		for _, path := range matchedPaths {
+			evidence = append(evidence, report.Evidence{
+				Kind:   "matched_attribute",
+				Path:   path,
+				Source: "policy.match.attributes",
+			})
+		}
+		findings = append(findings, report.Finding{
+			RuleID:      a.rule.ID,
+			Title:       a.rule.Title,
+			Category:    a.category,
+			Severity:    a.severity,
+			Confidence:  report.ConfidenceExact,
+			Resource:    resourceRef(resource),
+			Evidence:    evidence,
+			Message:     a.rule.Message,
+			Remediation: a.rule.Remediation,
+		})
+	}
+	return findings, nil
+}
+
+// matches reports whether resource satisfies the rule and, when attribute
+// selectors are present, which attribute paths satisfied them.
+func (a policyAnalyzer) matches(resource ir.ResourceChange) ([]string, bool) {
+	match := a.rule.Match
+	if len(a.actions) == 0 {
+		if resource.Action.Semantic == ir.ActionNoOp || resource.Action.Semantic == ir.ActionRead {
+			return nil, false
+		}
+	} else if _, ok := a.actions[resource.Action.Semantic]; !ok {
+		return nil, false
+	}
+	if len(match.Types) > 0 && !glob.MatchAny(match.Types, resource.Type) {
+		return nil, false
+	}
+	if len(match.Addresses) > 0 && !glob.MatchAny(match.Addresses, string(resource.Address)) {
+		return nil, false
+	}
+	if len(match.Modules) > 0 && !glob.MatchAny(match.Modules, moduleAddress(resource)) {
+		return nil, false
+	}
+	if len(match.Attributes) == 0 {
+		return nil, true
+	}
+	var matched []string
+	for _, path := range candidateAttributePaths(resource) {
+		if glob.MatchAny(match.Attributes, path) {
+			matched = append(matched, path)
+		}
+	}
+	return matched, len(matched) > 0
+}
+
+func moduleAddress(resource ir.ResourceChange) string {
+	if resource.ModuleAddress == nil || *resource.ModuleAddress == "" {
+		return "root"
+	}
+	return string(*resource.ModuleAddress)
+}
+
+// candidateAttributePaths lists the attribute paths a policy may select on.
+// Values are compared only after source sanitization, so a redacted attribute
+// contributes through its sensitive path rather than through its value.
+func candidateAttributePaths(resource ir.ResourceChange) []string {
+	set := make(map[string]struct{})
+	for _, group := range [][]ir.AttributePath{resource.ReplacePaths, resource.UnknownPaths, resource.SensitivePaths} {
+		for _, path := range group {
+			set[path.String()] = struct{}{}
+		}
+	}
+	if !resource.Before.Redacted && !resource.After.Redacted {
+		before, _ := resource.Before.Value.(map[string]any)
+		after, _ := resource.After.Value.(map[string]any)
+		for key, value := range after {
+			if previous, ok := before[key]; !ok || !reflect.DeepEqual(previous, value) {
+				set[key] = struct{}{}
+			}
+		}
+		for key := range before {
+			if _, ok := after[key]; !ok {
+				set[key] = struct{}{}
+			}
+		}
+	}
+	out := make([]string, 0, len(set))
+	for path := range set {
+		out = append(out, path)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yu/terraform-ops/internal/ir"
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+func writePolicy(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPolicyFileYAML(t *testing.T) {
+	path := writePolicy(t, "rules.yaml", `rules:
+  - id: ORG-DB-DELETE
+    title: Database deletion
+    severity: critical
+    message: Deleting a database loses data.
+    remediation: Take a final snapshot first.
+    match:
+      types: ["aws_db_*"]
+      actions: [delete]
+  - id: ORG-NETWORK-REPLACE
+    severity: high
+    match:
+      addresses: ["module.network.*"]
+      actions: [replace]
+  - id: ORG-AMI-CHANGE
+    severity: low
+    category: lifecycle
+    match:
+      attributes: [ami]
+`)
+	analyzers, err := LoadPolicyFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	network := ir.Address("module.network")
+	subnet := testResource("module.network.aws_subnet.a", "aws_subnet", "create", "delete")
+	subnet.ModuleAddress = &network
+	subnet.ReplacePaths = []ir.AttributePath{{ir.Attribute("cidr_block")}}
+	web := testResource("aws_instance.web", "aws_instance", "update")
+	web.Before = ir.SafeValue{Value: map[string]any{"ami": "ami-1", "tags": "a"}}
+	web.After = ir.SafeValue{Value: map[string]any{"ami": "ami-2", "tags": "a"}}
+	cs := testChangeSet(
+		testResource("aws_db_instance.main", "aws_db_instance", "delete"),
+		subnet,
+		web,
+		testResource("aws_db_instance.replica", "aws_db_instance", "no-op"),
+	)
+	findings, err := NewRegistry(analyzers...).Analyze(context.Background(), cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 3 {
+		t.Fatalf("got %d findings: %#v", len(findings), findings)
+	}
+
+	db := findings[0]
+	if db.RuleID != "ORG-DB-DELETE" || db.Severity != report.SeverityCritical || db.Category != report.CategoryPolicy {
+		t.Fatalf("unexpected first finding: %#v", db)
+	}
+	if db.Resource.Address != "aws_db_instance.main" || db.Remediation != "Take a final snapshot first." {
+		t.Fatalf("unexpected database finding: %#v", db)
+	}
+	if findings[1].RuleID != "ORG-NETWORK-REPLACE" || findings[1].Resource.Address != "module.network.aws_subnet.a" {
+		t.Fatalf("unexpected second finding: %#v", findings[1])
+	}
+	ami := findings[2]
+	if ami.RuleID != "ORG-AMI-CHANGE" || ami.Category != report.CategoryLifecycle {
+		t.Fatalf("unexpected third finding: %#v", ami)
+	}
+	foundPath := false
+	for _, evidence := range ami.Evidence {
+		if evidence.Kind == "matched_attribute" && evidence.Path == "ami" {
+			foundPath = true
+		}
+		if evidence.Path == "tags" {
+			t.Fatal("unchanged attribute reported as matched")
+		}
+	}
+	if !foundPath {
+		t.Fatalf("expected matched_attribute evidence: %#v", ami.Evidence)
+	}
+}
+
+func TestLoadPolicyFileHCL(t *testing.T) {
+	path := writePolicy(t, "rules.hcl", `
+rule "ORG-NETWORK-ANY" {
+  severity = "medium"
+  match {
+    modules = ["module.network"]
+  }
+}
+`)
+	analyzers, err := LoadPolicyFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	network := ir.Address("module.network")
+	subnet := testResource("module.network.aws_subnet.a", "aws_subnet", "update")
+	subnet.ModuleAddress = &network
+	cs := testChangeSet(subnet, testResource("aws_db_instance.main", "aws_db_instance", "delete"))
+	findings, err := NewRegistry(analyzers...).Analyze(context.Background(), cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 1 || findings[0].RuleID != "ORG-NETWORK-ANY" || findings[0].Title != "ORG-NETWORK-ANY" {
+		t.Fatalf("unexpected findings: %#v", findings)
+	}
+}
+
+func TestCompilePolicyRejectsInvalidRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy PolicyFile
+		want   string
+	}{
+		{
+			name:   "missing id",
+			policy: PolicyFile{Rules: []PolicyRule{{Severity: "high", Match: PolicyMatch{Types: []string{"x"}}}}},
+			want:   "id is required",
+		},
+		{
+			name:   "reserved prefix",
+			policy: PolicyFile{Rules: []PolicyRule{{ID: "TFOPS-MINE", Severity: "high", Match: PolicyMatch{Types: []string{"x"}}}}},
+			want:   "reserved",
+		},
+		{
+			name:   "bad severity",
+			policy: PolicyFile{Rules: []PolicyRule{{ID: "A", Severity: "urgent", Match: PolicyMatch{Types: []string{"x"}}}}},
+			want:   "unsupported severity",
+		},
+		{
+			name:   "empty match",
+			policy: PolicyFile{Rules: []PolicyRule{{ID: "A", Severity: "high"}}},
+			want:   "at least one",
+		},
+		{
+			name:   "bad action",
+			policy: PolicyFile{Rules: []PolicyRule{{ID: "A", Severity: "high", Match: PolicyMatch{Actions: []string{"destroy"}}}}},
+			want:   "unsupported action",
+		},
+		{
+			name: "duplicate id",
+			policy: PolicyFile{Rules: []PolicyRule{
+				{ID: "A", Severity: "high", Match: PolicyMatch{Types: []string{"x"}}},
+				{ID: "A", Severity: "low", Match: PolicyMatch{Types: []string{"y"}}},
+			}},
+			want: "duplicate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CompilePolicy(tt.policy, "test")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %v, want substring %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadPolicyFileRejectsUnknownYAMLFields(t *testing.T) {
+	path := writePolicy(t, "rules.yml", `rules:
+  - id: A
+    severity: high
+    match:
+      type: [aws_instance]
+`)
+	if _, err := LoadPolicyFile(path); err == nil {
+		t.Fatal("expected unknown field error")
+	}
+}
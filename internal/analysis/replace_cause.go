@@ -0,0 +1,141 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/yu/terraform-ops/internal/ir"
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+const (
+	// maxEvidenceValueLength bounds rendered values so a large nested block
+	// cannot dominate the report.
+	maxEvidenceValueLength = 80
+
+	sensitiveValue  = "(sensitive)"
+	redactedValue   = "(redacted)"
+	redactedMarker  = "<redacted>"
+	knownAfterApply = "a value known only after apply"
+)
+
+// replaceCauseEvidence explains each replacement path with the sanitized
+// value it changes from and to. Values under a sensitive path are never
+// rendered, and a path whose new value is unknown until apply is marked as
+// such instead of being reported as null.
+func replaceCauseEvidence(resource ir.ResourceChange) []report.Evidence {
+	evidence := make([]report.Evidence, 0, len(resource.ReplacePaths))
+	for _, path := range resource.ReplacePaths {
+		sensitive := pathsOverlap(resource.SensitivePaths, path)
+		item := report.Evidence{
+			Kind:    "replace_path",
+			Path:    path.String(),
+			Unknown: pathsOverlap(resource.UnknownPaths, path),
+			Before:  describeValue(resource.Before, path, sensitive),
+			Source:  "resource_changes.change.replace_paths",
+		}
+		if !item.Unknown {
+			item.After = describeValue(resource.After, path, sensitive)
+		}
+		item.Description = describeCause(item)
+		evidence = append(evidence, item)
+	}
+	return evidence
+}
+
+// replaceCauseMessage summarizes the first replacement cause for the finding
+// message, so the cause is visible even where evidence is not rendered.
+func replaceCauseMessage(evidence []report.Evidence) string {
+	message := "A managed resource is planned for replacement"
+	var causes []report.Evidence
+	for _, item := range evidence {
+		if item.Kind == "replace_path" && item.Description != "" {
+			causes = append(causes, item)
+		}
+	}
+	if len(causes) == 0 {
+		return message + "."
+	}
+	message += fmt.Sprintf(" because %s %s", causes[0].Path, causes[0].Description)
+	if len(causes) > 1 {
+		message += fmt.Sprintf(" (and %d more replacement paths)", len(causes)-1)
+	}
+	return message + "."
+}
+
+func describeCause(item report.Evidence) string {
+	switch {
+	case item.Before == sensitiveValue || item.Before == redactedValue:
+		if item.Unknown {
+			return "changed from a hidden value to " + knownAfterApply
+		}
+		if item.Before == sensitiveValue {
+			return "changed (sensitive value)"
+		}
+		return "changed (values redacted)"
+	case item.Unknown && item.Before != "":
+		return fmt.Sprintf("changed from %s to %s", item.Before, knownAfterApply)
+	case item.Unknown:
+		return "changed to " + knownAfterApply
+	case item.Before != "" && item.After != "":
+		return fmt.Sprintf("changed from %s to %s", item.Before, item.After)
+	default:
+		return ""
+	}
+}
+
+// describeValue renders the sanitized value at path, or an empty string when
+// the value is not available.
+func describeValue(value ir.SafeValue, path ir.AttributePath, sensitive bool) string {
+	if sensitive {
+		return sensitiveValue
+	}
+	if value.Redacted {
+		return redactedValue
+	}
+	found, ok := value.Lookup(path)
+	if !ok {
+		return ""
+	}
+	if marker, isString := found.(string); isString && marker == redactedMarker {
+		return sensitiveValue
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(found); err != nil {
+		return ""
+	}
+	rendered := strings.TrimSpace(buf.String())
+	if runes := []rune(rendered); len(runes) > maxEvidenceValueLength {
+		rendered = string(runes[:maxEvidenceValueLength-1]) + "…"
+	}
+	return rendered
+}
+
+// pathsOverlap reports whether any of paths addresses path, one of its
+// ancestors, or one of its descendants.
+func pathsOverlap(paths []ir.AttributePath, path ir.AttributePath) bool {
+	for _, candidate := range paths {
+		if path.HasPrefix(candidate) || candidate.HasPrefix(path) {
+			return true
+		}
+	}
+	return false
+}
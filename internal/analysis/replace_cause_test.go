@@ -0,0 +1,106 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/yu/terraform-ops/internal/ir"
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+func TestReplaceCauseEvidence(t *testing.T) {
+	resource := ir.ResourceChange{
+		Address: "aws_instance.web",
+		Mode:    ir.ResourceModeManaged,
+		Type:    "aws_instance",
+		Action:  ir.NormalizeAction([]string{"delete", "create"}),
+		ReplacePaths: []ir.AttributePath{
+			{ir.Attribute("ami")},
+			{ir.Attribute("user_data")},
+			{ir.Attribute("subnet_id")},
+			{ir.Attribute("root_block_device"), ir.Index("0"), ir.Attribute("volume_type")},
+		},
+		Before: ir.SafeValue{Value: map[string]any{
+			"ami":               "ami-old",
+			"user_data":         "<redacted>",
+			"subnet_id":         "subnet-a",
+			"root_block_device": []any{map[string]any{"volume_type": "gp2"}},
+		}},
+		After: ir.SafeValue{Value: map[string]any{
+			"ami":               "ami-new",
+			"user_data":         "<redacted>",
+			"root_block_device": []any{map[string]any{"volume_type": "gp3"}},
+		}},
+		SensitivePaths: []ir.AttributePath{{ir.Attribute("user_data")}},
+		UnknownPaths:   []ir.AttributePath{{ir.Attribute("subnet_id")}},
+	}
+
+	evidence := replaceCauseEvidence(resource)
+	byPath := make(map[string]report.Evidence, len(evidence))
+	for _, item := range evidence {
+		byPath[item.Path] = item
+	}
+
+	if ami := byPath["ami"]; ami.Before != `"ami-old"` || ami.After != `"ami-new"` || ami.Description != `changed from "ami-old" to "ami-new"` {
+		t.Fatalf("unexpected ami evidence: %#v", ami)
+	}
+	if userData := byPath["user_data"]; userData.Before != "(sensitive)" || userData.After != "(sensitive)" || strings.Contains(userData.Description, "redacted>") {
+		t.Fatalf("sensitive path must not render values: %#v", userData)
+	}
+	if subnet := byPath["subnet_id"]; !subnet.Unknown || subnet.After != "" || subnet.Description != `changed from "subnet-a" to a value known only after apply` {
+		t.Fatalf("unexpected unknown evidence: %#v", subnet)
+	}
+	if volume := byPath["root_block_device[0].volume_type"]; volume.Before != `"gp2"` || volume.After != `"gp3"` {
+		t.Fatalf("unexpected nested evidence: %#v", volume)
+	}
+}
+
+func TestReplaceCauseEvidenceInStrictRedaction(t *testing.T) {
+	resource := ir.ResourceChange{
+		ReplacePaths: []ir.AttributePath{{ir.Attribute("ami")}},
+		Before:       ir.SafeValue{Redacted: true},
+		After:        ir.SafeValue{Redacted: true},
+	}
+	evidence := replaceCauseEvidence(resource)
+	if evidence[0].Before != "(redacted)" || evidence[0].After != "(redacted)" || evidence[0].Description != "changed (values redacted)" {
+		t.Fatalf("unexpected strict evidence: %#v", evidence[0])
+	}
+}
+
+func TestLifecycleReplaceMessageNamesCause(t *testing.T) {
+	cs := &ir.ChangeSet{
+		Plan: ir.PlanMetadata{Applyable: true, Complete: true},
+		Resources: []ir.ResourceChange{{
+			Address:      "aws_instance.web",
+			Mode:         ir.ResourceModeManaged,
+			Type:         "aws_instance",
+			Action:       ir.NormalizeAction([]string{"delete", "create"}),
+			ReplacePaths: []ir.AttributePath{{ir.Attribute("ami")}},
+			Before:       ir.SafeValue{Value: map[string]any{"ami": "ami-old"}},
+			After:        ir.SafeValue{Value: map[string]any{"ami": "ami-new"}},
+		}},
+	}
+	findings, err := lifecycleAnalyzer{}.Analyze(context.Background(), cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `A managed resource is planned for replacement because ami changed from "ami-old" to "ami-new".`
+	if len(findings) != 1 || findings[0].Message != want {
+		t.Fatalf("unexpected findings: %#v", findings)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yu/terraform-ops/internal/glob"
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+// builtinRuleSource is the Source of rules that ship with terraform-ops.
+const builtinRuleSource = "built-in"
+
+// Rule describes a rule an analyzer can report, independent of any plan.
+// Severity is the severity findings get unless an override applies.
+type Rule struct {
+	ID          string          `json:"id"`
+	Title       string          `json:"title"`
+	Category    report.Category `json:"category"`
+	Severity    report.Severity `json:"default_severity"`
+	Description string          `json:"description"`
+	Source      string          `json:"source"`
+}
+
+// RuleProvider is implemented by analyzers that describe the rules they
+// report, which lets a registry list and validate rule IDs without a plan.
+type RuleProvider interface {
+	Rules() []Rule
+}
+
+var (
+	rulePlanErrored = Rule{
+		ID:          "TFOPS-PLAN-ERRORED",
+		Title:       "Terraform/OpenTofu planning errored",
+		Category:    report.CategoryPlan,
+		Severity:    report.SeverityHigh,
+		Description: "The plan reports that planning failed, so its change set may be partial.",
+		Source:      builtinRuleSource,
+	}
+	rulePlanIncomplete = Rule{
+		ID:          "TFOPS-PLAN-INCOMPLETE",
+		Title:       "Plan is incomplete",
+		Category:    report.CategoryUncertainty,
+		Severity:    report.SeverityMedium,
+		Description: "The plan is not complete and another plan/apply round may be needed to converge.",
+		Source:      builtinRuleSource,
+	}
+	ruleCheckFailed = Rule{
+		ID:          "TFOPS-CHECK-FAILED",
+		Title:       "Terraform/OpenTofu check failed",
+		Category:    report.CategoryValidation,
+		Severity:    report.SeverityHigh,
+		Description: "A check block, precondition or postcondition has status fail or error.",
+		Source:      builtinRuleSource,
+	}
+	ruleLifecycleDelete = Rule{
+		ID:          "TFOPS-LIFECYCLE-DELETE",
+		Title:       "Managed resource deletion",
+		Category:    report.CategoryLifecycle,
+		Severity:    report.SeverityMedium,
+		Description: "A managed resource is planned for deletion.",
+		Source:      builtinRuleSource,
+	}
+	ruleLifecycleReplace = Rule{
+		ID:          "TFOPS-LIFECYCLE-REPLACE",
+		Title:       "Managed resource replacement",
+		Category:    report.CategoryLifecycle,
+		Severity:    report.SeverityMedium,
+		Description: "A managed resource is planned for replacement; evidence names the attributes that force it.",
+		Source:      builtinRuleSource,
+	}
+	ruleStatefulDestroy = Rule{
+		ID:          "TFOPS-STATEFUL-DESTROY",
+		Title:       "Stateful resource destroyed",
+		Category:    report.CategoryLifecycle,
+		Severity:    report.SeverityHigh,
+		Description: "A resource in the stateful resource catalog is deleted or replaced; the catalog entry sets the severity.",
+		Source:      builtinRuleSource,
+	}
+	ruleDriftDetected = Rule{
+		ID:          "TFOPS-DRIFT-DETECTED",
+		Title:       "External drift detected",
+		Category:    report.CategoryDrift,
+		Severity:    report.SeverityMedium,
+		Description: "The plan reports that a resource changed outside Terraform/OpenTofu.",
+		Source:      builtinRuleSource,
+	}
+	ruleSensitiveMutation = Rule{
+		ID:          "TFOPS-SENSITIVE-MUTATION",
+		Title:       "Sensitive data participates in a resource change",
+		Category:    report.CategorySensitivity,
+		Severity:    report.SeverityInfo,
+		Description: "Sensitive attribute paths participate in a resource change; values are redacted.",
+		Source:      builtinRuleSource,
+	}
+	ruleUnknownAfter = Rule{
+		ID:          "TFOPS-UNKNOWN-AFTER",
+		Title:       "Post-apply values remain unknown",
+		Category:    report.CategoryUncertainty,
+		Severity:    report.SeverityInfo,
+		Description: "Changed attributes stay unknown until apply.",
+		Source:      builtinRuleSource,
+	}
+)
+
+// RuleSettings adjusts which findings a registry reports and at what
+// severity. Disabled and EnableOnly hold rule IDs or glob patterns ('*' and
+// '?'); a rule is reported when it matches no Disabled pattern and, if
+// EnableOnly is set, at least one EnableOnly pattern.
+type RuleSettings struct {
+	Severities map[string]report.Severity
+	Disabled   []string
+	EnableOnly []string
+}
+
+func (s RuleSettings) empty() bool {
+	return len(s.Severities) == 0 && len(s.Disabled) == 0 && len(s.EnableOnly) == 0
+}
+
+// Enabled reports whether findings of ruleID are reported.
+func (s RuleSettings) Enabled(ruleID string) bool {
+	if glob.MatchAny(s.Disabled, ruleID) {
+		return false
+	}
+	return len(s.EnableOnly) == 0 || glob.MatchAny(s.EnableOnly, ruleID)
+}
+
+// merge returns s with other's overrides taking precedence and other's
+// patterns added.
+func (s RuleSettings) merge(other RuleSettings) RuleSettings {
+	severities := make(map[string]report.Severity, len(s.Severities)+len(other.Severities))
+	for rule, severity := range s.Severities {
+		severities[rule] = severity
+	}
+	for rule, severity := range other.Severities {
+		severities[rule] = severity
+	}
+	return RuleSettings{
+		Severities: severities,
+		Disabled:   append(append([]string(nil), s.Disabled...), other.Disabled...),
+		EnableOnly: append(append([]string(nil), s.EnableOnly...), other.EnableOnly...),
+	}
+}
+
+// Rules lists the rules of every analyzer that describes them, sorted by ID.
+// When two analyzers describe the same ID the first one wins.
+func (r *Registry) Rules() []Rule {
+	seen := make(map[string]struct{})
+	var rules []Rule
+	for _, analyzer := range r.analyzers {
+		provider, ok := analyzer.(RuleProvider)
+		if !ok {
+			continue
+		}
+		for _, rule := range provider.Rules() {
+			if _, ok := seen[rule.ID]; ok {
+				continue
+			}
+			seen[rule.ID] = struct{}{}
+			rules = append(rules, rule)
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return rules
+}
+
+// WithRuleSettings returns a registry that applies settings on top of the
+// receiver's. Severity overrides must name a registered rule and every pattern
+// must match one, so a misspelled rule ID fails instead of silently doing
+// nothing; add custom policy rules before calling it.
+func (r *Registry) WithRuleSettings(settings RuleSettings) (*Registry, error) {
+	if settings.empty() {
+		return r, nil
+	}
+	var ids []string
+	known := make(map[string]struct{})
+	for _, rule := range r.Rules() {
+		ids = append(ids, rule.ID)
+		known[rule.ID] = struct{}{}
+	}
+	var unknown []string
+	for _, id := range sortedRuleIDs(settings.Severities) {
+		if _, ok := known[id]; !ok {
+			unknown = append(unknown, id)
+		}
+	}
+	for _, pattern := range append(append([]string(nil), settings.Disabled...), settings.EnableOnly...) {
+		if !matchesAnyRule(pattern, ids) {
+			unknown = append(unknown, pattern)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown rule %s: run terraform-ops rules list to see rule IDs", strings.Join(unknown, ", "))
+	}
+	return &Registry{analyzers: r.analyzers, settings: r.settings.merge(settings)}, nil
+}
+
+func matchesAnyRule(pattern string, ids []string) bool {
+	for _, id := range ids {
+		if glob.Match(pattern, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedRuleIDs(severities map[string]report.Severity) []string {
+	ids := make([]string, 0, len(severities))
+	for id := range severities {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/yu/terraform-ops/internal/ir"
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+func ruleIDs(findings []report.Finding) string {
+	ids := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		ids = append(ids, finding.RuleID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestDefaultRegistryDescribesEveryRule(t *testing.T) {
+	rules := DefaultRegistry().Rules()
+	want := []string{
+		"TFOPS-CHECK-FAILED", "TFOPS-DRIFT-DETECTED", "TFOPS-LIFECYCLE-DELETE", "TFOPS-LIFECYCLE-REPLACE",
+		"TFOPS-PLAN-ERRORED", "TFOPS-PLAN-INCOMPLETE", "TFOPS-SENSITIVE-MUTATION", "TFOPS-STATEFUL-DESTROY",
+		"TFOPS-UNKNOWN-AFTER",
+	}
+	if len(rules) != len(want) {
+		t.Fatalf("got %d rules: %#v", len(rules), rules)
+	}
+	for i, rule := range rules {
+		if rule.ID != want[i] {
+			t.Errorf("rule %d: got %s, want %s", i, rule.ID, want[i])
+		}
+		if rule.Severity == "" || rule.Category == "" || rule.Description == "" || rule.Source != builtinRuleSource {
+			t.Errorf("incomplete metadata: %#v", rule)
+		}
+	}
+}
+
+func TestWithRuleSettingsSelectsRules(t *testing.T) {
+	cases := []struct {
+		name     string
+		settings RuleSettings
+		want     string
+	}{
+		{"disable", RuleSettings{Disabled: []string{"TFOPS-UNKNOWN-AFTER"}}, "TFOPS-LIFECYCLE-DELETE,TFOPS-LIFECYCLE-REPLACE"},
+		{"enable only glob", RuleSettings{EnableOnly: []string{"TFOPS-LIFECYCLE-*"}}, "TFOPS-LIFECYCLE-DELETE,TFOPS-LIFECYCLE-REPLACE"},
+		{"disable beats enable only", RuleSettings{EnableOnly: []string{"TFOPS-LIFECYCLE-*"}, Disabled: []string{"TFOPS-LIFECYCLE-DELETE"}}, "TFOPS-LIFECYCLE-REPLACE"},
+	}
+	db := testResource("test_resource.db", "test_resource", "delete", "create")
+	db.UnknownPaths = []ir.AttributePath{{ir.Attribute("endpoint")}}
+	cs := testChangeSet(db, testResource("test_resource.old", "test_resource", "delete"))
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry, err := DefaultRegistry().WithRuleSettings(tc.settings)
+			if err != nil {
+				t.Fatal(err)
+			}
+			findings, err := registry.Analyze(context.Background(), cs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ruleIDs(findings); got != tc.want {
+				t.Fatalf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWithRuleSettingsRejectsUnknownRules(t *testing.T) {
+	_, err := DefaultRegistry().WithRuleSettings(RuleSettings{
+		Severities: map[string]report.Severity{"TFOPS-DRIFT": report.SeverityHigh},
+		Disabled:   []string{"TFOPS-NOPE-*"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "TFOPS-DRIFT, TFOPS-NOPE-*") {
+		t.Fatalf("expected unknown rule error, got %v", err)
+	}
+
+	analyzers, err := CompilePolicy(PolicyFile{Rules: []PolicyRule{{
+		ID:       "ACME-NO-DELETE",
+		Severity: "high",
+		Message:  "Deletes need approval.",
+		Match:    PolicyMatch{Actions: []string{"delete"}},
+	}}}, "policy.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry, err := DefaultRegistry().With(analyzers...).WithRuleSettings(RuleSettings{EnableOnly: []string{"ACME-*"}})
+	if err != nil {
+		t.Fatalf("custom rules must be selectable: %v", err)
+	}
+	findings, err := registry.Analyze(context.Background(), testChangeSet(testResource("test_resource.old", "test_resource", "delete")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ruleIDs(findings); got != "ACME-NO-DELETE" {
+		t.Fatalf("got %s", got)
+	}
+}
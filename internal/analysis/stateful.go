@@ -0,0 +1,190 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/yu/terraform-ops/internal/glob"
+	"github.com/yu/terraform-ops/internal/ir"
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+const (
+	statefulDestroyAnalyzerID = "stateful"
+	builtinCatalogSource      = "built-in resource catalog"
+)
+
+//go:embed resource_catalog.yaml
+var builtinResourceCatalog []byte
+
+// ResourceCatalog lists resource types whose deletion or replacement destroys
+// state that Terraform/OpenTofu cannot recreate, such as database contents or
+// encryption keys. Earlier entries take precedence when several match a type.
+type ResourceCatalog struct {
+	Entries []CatalogEntry `yaml:"entries"`
+}
+
+// CatalogEntry classifies a group of resource types. Types accept glob
+// patterns ('*' and '?'). Severity is the severity of the finding raised when
+// a matching resource is destroyed.
+type CatalogEntry struct {
+	Provider string   `yaml:"provider"`
+	Kind     string   `yaml:"kind"`
+	Severity string   `yaml:"severity"`
+	Types    []string `yaml:"types"`
+
+	source   string
+	severity report.Severity
+}
+
+// DefaultResourceCatalog returns the built-in catalog.
+func DefaultResourceCatalog() ResourceCatalog {
+	catalog, err := parseResourceCatalog(builtinResourceCatalog, builtinCatalogSource)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in resource catalog: %v", err))
+	}
+	return catalog
+}
+
+// LoadResourceCatalog reads a user catalog in the same YAML (or JSON) format
+// as the built-in one.
+func LoadResourceCatalog(path string) (ResourceCatalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ResourceCatalog{}, fmt.Errorf("read resource catalog: %w", err)
+	}
+	catalog, err := parseResourceCatalog(data, path)
+	if err != nil {
+		return ResourceCatalog{}, fmt.Errorf("resource catalog %s: %w", path, err)
+	}
+	return catalog, nil
+}
+
+func parseResourceCatalog(data []byte, source string) (ResourceCatalog, error) {
+	var catalog ResourceCatalog
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&catalog); err != nil && !errors.Is(err, io.EOF) {
+		return ResourceCatalog{}, err
+	}
+	for i := range catalog.Entries {
+		entry := &catalog.Entries[i]
+		if strings.TrimSpace(entry.Kind) == "" {
+			return ResourceCatalog{}, fmt.Errorf("entry #%d: kind is required", i+1)
+		}
+		if len(entry.Types) == 0 {
+			return ResourceCatalog{}, fmt.Errorf("entry #%d: at least one type is required", i+1)
+		}
+		severity, err := report.ParseSeverity(entry.Severity)
+		if err != nil {
+			return ResourceCatalog{}, fmt.Errorf("entry #%d: %w", i+1, err)
+		}
+		entry.severity = severity
+		entry.source = source
+	}
+	return catalog, nil
+}
+
+// Extend returns a catalog in which other's entries take precedence over c's,
+// so a user file can both add types and re-classify built-in ones.
+func (c ResourceCatalog) Extend(other ResourceCatalog) ResourceCatalog {
+	entries := make([]CatalogEntry, 0, len(other.Entries)+len(c.Entries))
+	entries = append(entries, other.Entries...)
+	entries = append(entries, c.Entries...)
+	return ResourceCatalog{Entries: entries}
+}
+
+// Lookup returns the first entry whose types match resourceType.
+func (c ResourceCatalog) Lookup(resourceType string) (CatalogEntry, bool) {
+	for _, entry := range c.Entries {
+		if glob.MatchAny(entry.Types, resourceType) {
+			return entry, true
+		}
+	}
+	return CatalogEntry{}, false
+}
+
+type statefulDestroyAnalyzer struct {
+	catalog ResourceCatalog
+}
+
+// NewStatefulDestroyAnalyzer raises TFOPS-STATEFUL-DESTROY for managed
+// resources in catalog that are planned for deletion or replacement.
+func NewStatefulDestroyAnalyzer(catalog ResourceCatalog) Analyzer {
+	return statefulDestroyAnalyzer{catalog: catalog}
+}
+
+func (statefulDestroyAnalyzer) ID() string    { return statefulDestroyAnalyzerID }
+func (statefulDestroyAnalyzer) Rules() []Rule { return []Rule{ruleStatefulDestroy} }
+func (a statefulDestroyAnalyzer) Analyze(_ context.Context, cs *ir.ChangeSet) ([]report.Finding, error) {
+	var findings []report.Finding
+	for _, resource := range cs.Resources {
+		if resource.Mode != ir.ResourceModeManaged {
+			continue
+		}
+		if resource.Action.Semantic != ir.ActionDelete && !resource.Action.IsReplace() {
+			continue
+		}
+		entry, ok := a.catalog.Lookup(resource.Type)
+		if !ok {
+			continue
+		}
+		verb := "deleted"
+		if resource.Action.IsReplace() {
+			verb = "replaced"
+		}
+		findings = append(findings, report.Finding{
+			RuleID:     ruleStatefulDestroy.ID,
+			Title:      ruleStatefulDestroy.Title,
+			Category:   ruleStatefulDestroy.Category,
+			Severity:   entry.severity,
+			Confidence: report.ConfidenceStrong,
+			Resource:   resourceRef(resource),
+			Evidence: []report.Evidence{
+				{
+					Kind:        "action",
+					Description: string(resource.Action.Semantic),
+					Source:      "resource_changes.change.actions",
+				},
+				{
+					Kind:        "resource_catalog",
+					Description: catalogDescription(entry),
+					Source:      entry.source,
+				},
+			},
+			Message:     fmt.Sprintf("Stateful resource (%s) is planned to be %s; its existing data or identity is destroyed and is not restored by recreating it.", catalogDescription(entry), verb),
+			Remediation: "Confirm a backup or snapshot exists, or guard the resource with lifecycle { prevent_destroy = true } if the destruction is not intended.",
+		})
+	}
+	return findings, nil
+}
+
+func catalogDescription(entry CatalogEntry) string {
+	kind := strings.ReplaceAll(entry.Kind, "_", " ")
+	if entry.Provider == "" {
+		return kind
+	}
+	return entry.Provider + " " + kind
+}
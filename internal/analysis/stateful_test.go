@@ -0,0 +1,150 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yu/terraform-ops/internal/ir"
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+func TestStatefulDestroyAnalyzerUsesBuiltinCatalog(t *testing.T) {
+	shared := testResource("data.aws_s3_bucket.shared", "aws_s3_bucket", "read")
+	shared.Mode = ir.ResourceModeData
+	cs := testChangeSet(
+		testResource("aws_rds_cluster.main", "aws_rds_cluster", "delete"),
+		testResource("null_resource.trigger", "null_resource", "delete"),
+		testResource("aws_kms_key.data", "aws_kms_key", "create", "delete"),
+		testResource("aws_fsx_lustre_file_system.scratch", "aws_fsx_lustre_file_system", "delete"),
+		testResource("aws_s3_bucket.logs", "aws_s3_bucket", "update"),
+		shared,
+	)
+	findings, err := NewStatefulDestroyAnalyzer(DefaultResourceCatalog()).Analyze(context.Background(), cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]report.Severity, len(findings))
+	for _, finding := range findings {
+		if finding.RuleID != "TFOPS-STATEFUL-DESTROY" {
+			t.Fatalf("unexpected rule %s", finding.RuleID)
+		}
+		got[finding.Resource.Address] = finding.Severity
+		if finding.Resource.Address == "aws_kms_key.data" {
+			want := "Stateful resource (aws encryption key) is planned to be replaced; "
+			if !strings.HasPrefix(finding.Message, want) {
+				t.Fatalf("message = %q, want prefix %q", finding.Message, want)
+			}
+		}
+	}
+	want := map[string]report.Severity{
+		"aws_rds_cluster.main":               report.SeverityHigh,
+		"aws_kms_key.data":                   report.SeverityCritical,
+		"aws_fsx_lustre_file_system.scratch": report.SeverityHigh,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got findings for %v, want %v", got, want)
+	}
+	for address, severity := range want {
+		if got[address] != severity {
+			t.Fatalf("%s: got severity %q, want %q", address, got[address], severity)
+		}
+	}
+}
+
+func TestDefaultRegistryRaisesSeverityForStatefulDelete(t *testing.T) {
+	cs := testChangeSet(
+		testResource("null_resource.trigger", "null_resource", "delete"),
+		testResource("aws_kms_key.data", "aws_kms_key", "create", "delete"),
+	)
+	findings, err := DefaultRegistry().Analyze(context.Background(), cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if highest := report.HighestSeverity(findings); highest != report.SeverityCritical {
+		t.Fatalf("highest severity = %q, want critical", highest)
+	}
+	for _, finding := range findings {
+		if finding.Resource != nil && finding.Resource.Address == "null_resource.trigger" && finding.Severity != report.SeverityMedium {
+			t.Fatalf("null_resource deletion should stay medium: %#v", finding)
+		}
+	}
+}
+
+func TestWithResourceCatalogsExtendsBuiltinCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.yaml")
+	content := `entries:
+  - provider: null
+    kind: trigger
+    severity: low
+    types: [null_resource]
+  - provider: aws
+    kind: database
+    severity: critical
+    types: [aws_rds_cluster]
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	registry, err := NewRegistry(NewStatefulDestroyAnalyzer(DefaultResourceCatalog())).WithResourceCatalogs([]string{path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs := testChangeSet(
+		testResource("aws_rds_cluster.main", "aws_rds_cluster", "delete"),
+		testResource("null_resource.trigger", "null_resource", "delete"),
+		testResource("aws_s3_bucket.logs", "aws_s3_bucket", "update"),
+	)
+	findings, err := registry.Analyze(context.Background(), cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected the catalog analyzer to be replaced, not duplicated; got %#v", findings)
+	}
+	severities := make(map[string]report.Severity)
+	for _, finding := range findings {
+		severities[finding.Resource.Address] = finding.Severity
+		if finding.Resource.Address == "null_resource.trigger" && finding.Evidence[1].Source != path {
+			t.Fatalf("user entries should cite their file: %#v", finding.Evidence)
+		}
+	}
+	if severities["null_resource.trigger"] != report.SeverityLow || severities["aws_rds_cluster.main"] != report.SeverityCritical {
+		t.Fatalf("user catalog did not take precedence: %v", severities)
+	}
+}
+
+func TestLoadResourceCatalogValidatesEntries(t *testing.T) {
+	for name, content := range map[string]string{
+		"missing kind":     "entries:\n  - severity: high\n    types: [x]\n",
+		"missing types":    "entries:\n  - kind: database\n    severity: high\n",
+		"bad severity":     "entries:\n  - kind: database\n    severity: severe\n    types: [x]\n",
+		"unknown property": "entries:\n  - kind: database\n    severity: high\n    types: [x]\n    note: x\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "catalog.yaml")
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadResourceCatalog(path); err == nil {
+				t.Fatal("expected validation error")
+			}
+		})
+	}
+}
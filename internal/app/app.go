@@ -21,6 +21,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/yu/terraform-ops/internal/commands"
+	"github.com/yu/terraform-ops/internal/config"
 	"github.com/yu/terraform-ops/internal/version"
 )
 
@@ -29,6 +30,9 @@ var rootCmd = &cobra.Command{
 	Short:   "Terraform operations CLI tool",
 	Long:    `A CLI tool for managing Terraform operations and workflows`,
 	Version: version.Version,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return loadConfig(cmd)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Printf("Terraform Ops CLI %s\n", version.Version)
 		fmt.Println("Use --help for more information")
@@ -42,6 +46,24 @@ func init() {
 	rootCmd.AddCommand(commands.DefaultPlanGraphCommand().Command())
 	rootCmd.AddCommand(commands.DefaultSummarizePlanCommand().Command())
 	rootCmd.AddCommand(commands.DefaultAnalyzeCommand().Command())
+	rootCmd.AddCommand(commands.DefaultDiffPlansCommand().Command())
+	rootCmd.AddCommand(commands.DefaultPublishCommand().Command())
+	rootCmd.AddCommand(commands.DefaultRulesCommand().Command())
+	rootCmd.AddCommand(commands.DefaultCheckVersionsCommand().Command())
+}
+
+// loadConfig applies the project and user configuration files and TFOPS_*
+// environment variables to the flags of the command about to run.
+func loadConfig(cmd *cobra.Command) error {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	layers, err := config.Load(workDir, os.Getenv)
+	if err != nil {
+		return err
+	}
+	return commands.ApplyConfig(cmd, layers, os.Getenv)
 }
 
 // Run executes the root command
@@ -37,6 +37,9 @@ func TestRootCmd(t *testing.T) {
 	assert.NotNil(t, findCommand(rootCmd, "plan-graph"))
 	assert.NotNil(t, findCommand(rootCmd, "summarize-plan"))
 	assert.NotNil(t, findCommand(rootCmd, "analyze"))
+	assert.NotNil(t, findCommand(rootCmd, "diff-plans"))
+	assert.NotNil(t, findCommand(rootCmd, "rules"))
+	assert.NotNil(t, findCommand(rootCmd, "check-versions"))
 }
 
 // TestShowTerraformCmd tests the 'show-terraform' command execution
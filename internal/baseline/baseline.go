@@ -0,0 +1,204 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package baseline loads, applies, and generates finding suppression files.
+// A suppression accepts one finding, identified by rule ID, resource address
+// and, in batch analysis, workspace, so CI thresholds only consider findings nobody has signed off on.
+package baseline
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+const (
+	// Version is the suppression file format version.
+	Version = "1"
+	// DefaultPath is the conventional baseline file name at the repository root.
+	DefaultPath = ".terraform-ops-baseline.json"
+
+	expiresLayout = "2006-01-02"
+)
+
+type File struct {
+	Version      string        `json:"version"`
+	Suppressions []Suppression `json:"suppressions"`
+}
+
+// Suppression accepts the finding of RuleID on Address. An empty Address
+// matches plan-level findings that have no resource. Workspace names the batch
+// workspace the finding belongs to and is empty for single-plan analysis, so
+// the same address in two root modules is accepted separately. Expires is an
+// optional YYYY-MM-DD date; the suppression stops applying after that day
+// (UTC).
+type Suppression struct {
+	RuleID        string `json:"rule_id"`
+	Address       string `json:"address,omitempty"`
+	Workspace     string `json:"workspace,omitempty"`
+	Justification string `json:"justification,omitempty"`
+	Expires       string `json:"expires,omitempty"`
+}
+
+func (s Suppression) key() string {
+	return s.RuleID + "\x00" + s.Address + "\x00" + s.Workspace
+}
+
+// Expired reports whether the suppression no longer applies at now.
+func (s Suppression) Expired(now time.Time) bool {
+	if s.Expires == "" {
+		return false
+	}
+	expires, err := time.Parse(expiresLayout, s.Expires)
+	if err != nil {
+		return true
+	}
+	return !now.UTC().Before(expires.AddDate(0, 0, 1))
+}
+
+// Load reads and validates a suppression file.
+func Load(path string) (File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return File{}, fmt.Errorf("read baseline %s: %w", path, err)
+	}
+	var file File
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		return File{}, fmt.Errorf("parse baseline %s: %w", path, err)
+	}
+	if err := file.validate(); err != nil {
+		return File{}, fmt.Errorf("invalid baseline %s: %w", path, err)
+	}
+	return file, nil
+}
+
+func (f File) validate() error {
+	if f.Version != Version {
+		return fmt.Errorf("unsupported version %q: want %q", f.Version, Version)
+	}
+	seen := make(map[string]struct{}, len(f.Suppressions))
+	var errs []error
+	for i, suppression := range f.Suppressions {
+		if strings.TrimSpace(suppression.RuleID) == "" {
+			errs = append(errs, fmt.Errorf("suppression %d: rule_id is required", i))
+			continue
+		}
+		if suppression.Expires != "" {
+			if _, err := time.Parse(expiresLayout, suppression.Expires); err != nil {
+				errs = append(errs, fmt.Errorf("suppression %d: expires %q is not a YYYY-MM-DD date", i, suppression.Expires))
+			}
+		}
+		if _, ok := seen[suppression.key()]; ok {
+			errs = append(errs, fmt.Errorf("suppression %d: duplicate entry for %s on %q in workspace %q", i, suppression.RuleID, suppression.Address, suppression.Workspace))
+		}
+		seen[suppression.key()] = struct{}{}
+	}
+	return errors.Join(errs...)
+}
+
+// Apply splits findings into those that remain active and those accepted by
+// an unexpired suppression. Expired suppressions leave their findings active.
+func (f File) Apply(findings []report.Finding, now time.Time) ([]report.Finding, []report.SuppressedFinding) {
+	index := make(map[string]Suppression, len(f.Suppressions))
+	for _, suppression := range f.Suppressions {
+		if !suppression.Expired(now) {
+			index[suppression.key()] = suppression
+		}
+	}
+	var active []report.Finding
+	var suppressed []report.SuppressedFinding
+	for _, finding := range findings {
+		suppression, ok := index[findingKey(finding)]
+		if !ok {
+			active = append(active, finding)
+			continue
+		}
+		suppressed = append(suppressed, report.SuppressedFinding{
+			Finding:       finding,
+			Justification: suppression.Justification,
+			Expires:       suppression.Expires,
+		})
+	}
+	return active, suppressed
+}
+
+// Generate builds a suppression file that accepts every finding. Entries that
+// already exist in previous keep their justification and expiry, so a
+// regenerated baseline does not lose the review trail.
+func Generate(findings []report.Finding, previous File) File {
+	existing := make(map[string]Suppression, len(previous.Suppressions))
+	for _, suppression := range previous.Suppressions {
+		existing[suppression.key()] = suppression
+	}
+	file := File{Version: Version, Suppressions: []Suppression{}}
+	seen := make(map[string]struct{}, len(findings))
+	for _, finding := range findings {
+		key := findingKey(finding)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		suppression, ok := existing[key]
+		if !ok {
+			suppression = Suppression{RuleID: finding.RuleID, Address: findingAddress(finding), Workspace: finding.Workspace}
+		}
+		file.Suppressions = append(file.Suppressions, suppression)
+	}
+	sort.Slice(file.Suppressions, func(i, j int) bool {
+		a, b := file.Suppressions[i], file.Suppressions[j]
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+		if a.Address != b.Address {
+			return a.Address < b.Address
+		}
+		return a.Workspace < b.Workspace
+	})
+	return file
+}
+
+// Write stores file as indented JSON.
+func Write(path string, file File) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(file); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write baseline %s: %w", path, err)
+	}
+	return nil
+}
+
+func findingKey(finding report.Finding) string {
+	return finding.RuleID + "\x00" + findingAddress(finding) + "\x00" + finding.Workspace
+}
+
+func findingAddress(finding report.Finding) string {
+	if finding.Resource == nil {
+		return ""
+	}
+	return finding.Resource.Address
+}
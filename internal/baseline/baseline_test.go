@@ -0,0 +1,143 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+func finding(ruleID, address string, severity report.Severity) report.Finding {
+	out := report.Finding{RuleID: ruleID, Severity: severity, Message: "message"}
+	if address != "" {
+		out.Resource = &report.ResourceRef{Address: address}
+	}
+	return out
+}
+
+func TestApplySuppressesMatchingFindings(t *testing.T) {
+	file := File{Version: Version, Suppressions: []Suppression{
+		{RuleID: "TFOPS-LIFECYCLE-REPLACE", Address: "aws_instance.rotated", Justification: "rotated every release"},
+		{RuleID: "TFOPS-PLAN-ERRORED"},
+		{RuleID: "TFOPS-LIFECYCLE-DELETE", Address: "aws_s3_bucket.logs", Expires: "2026-01-31"},
+	}}
+	findings := []report.Finding{
+		finding("TFOPS-LIFECYCLE-REPLACE", "aws_instance.rotated", report.SeverityMedium),
+		finding("TFOPS-LIFECYCLE-REPLACE", "aws_instance.other", report.SeverityMedium),
+		finding("TFOPS-PLAN-ERRORED", "", report.SeverityHigh),
+		finding("TFOPS-LIFECYCLE-DELETE", "aws_s3_bucket.logs", report.SeverityHigh),
+	}
+
+	active, suppressed := file.Apply(findings, time.Date(2026, 1, 31, 23, 59, 0, 0, time.UTC))
+	if len(active) != 1 || active[0].Resource.Address != "aws_instance.other" {
+		t.Fatalf("unexpected active findings: %#v", active)
+	}
+	if len(suppressed) != 3 {
+		t.Fatalf("expected 3 suppressed findings, got %#v", suppressed)
+	}
+	if suppressed[0].Justification != "rotated every release" {
+		t.Fatalf("justification not carried: %#v", suppressed[0])
+	}
+
+	active, suppressed = file.Apply(findings, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	if len(active) != 2 || len(suppressed) != 2 {
+		t.Fatalf("expired suppression must not apply: active=%#v suppressed=%#v", active, suppressed)
+	}
+	if report.HighestSeverity(active) != report.SeverityHigh {
+		t.Fatalf("expired finding should count toward the threshold")
+	}
+}
+
+func TestLoadValidatesEntries(t *testing.T) {
+	tests := map[string]string{
+		"version":   `{"version":"2","suppressions":[]}`,
+		"rule id":   `{"version":"1","suppressions":[{"address":"a.b"}]}`,
+		"expires":   `{"version":"1","suppressions":[{"rule_id":"R","expires":"31/01/2026"}]}`,
+		"duplicate": `{"version":"1","suppressions":[{"rule_id":"R","address":"a.b"},{"rule_id":"R","address":"a.b"}]}`,
+		"unknown":   `{"version":"1","suppressions":[{"rule_id":"R","reason":"x"}]}`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "baseline.json")
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load(path); err == nil {
+				t.Fatal("expected validation error")
+			}
+		})
+	}
+}
+
+func TestGenerateKeepsReviewTrail(t *testing.T) {
+	previous := File{Version: Version, Suppressions: []Suppression{
+		{RuleID: "TFOPS-LIFECYCLE-REPLACE", Address: "aws_instance.web", Justification: "accepted", Expires: "2027-01-01"},
+		{RuleID: "TFOPS-LIFECYCLE-DELETE", Address: "aws_instance.gone"},
+	}}
+	findings := []report.Finding{
+		finding("TFOPS-LIFECYCLE-REPLACE", "aws_instance.web", report.SeverityMedium),
+		finding("TFOPS-LIFECYCLE-REPLACE", "aws_instance.web", report.SeverityMedium),
+		finding("TFOPS-PLAN-ERRORED", "", report.SeverityHigh),
+	}
+	file := Generate(findings, previous)
+
+	path := filepath.Join(t.TempDir(), DefaultPath)
+	if err := Write(path, file); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Suppressions) != 2 {
+		t.Fatalf("expected one entry per current finding, got %#v", loaded.Suppressions)
+	}
+	if loaded.Suppressions[0].RuleID != "TFOPS-LIFECYCLE-REPLACE" || loaded.Suppressions[0].Justification != "accepted" || loaded.Suppressions[0].Expires != "2027-01-01" {
+		t.Fatalf("existing entry lost its review trail: %#v", loaded.Suppressions[0])
+	}
+	if loaded.Suppressions[1].RuleID != "TFOPS-PLAN-ERRORED" || loaded.Suppressions[1].Address != "" {
+		t.Fatalf("unexpected plan-level entry: %#v", loaded.Suppressions[1])
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "aws_instance.gone") {
+		t.Fatal("stale entries must be dropped")
+	}
+}
+
+func TestSuppressionsAreScopedToWorkspace(t *testing.T) {
+	prod := finding("TFOPS-LIFECYCLE-DELETE", "aws_s3_bucket.logs", report.SeverityHigh)
+	prod.Workspace = "envs/prod"
+	staging := finding("TFOPS-LIFECYCLE-DELETE", "aws_s3_bucket.logs", report.SeverityHigh)
+	staging.Workspace = "envs/staging"
+
+	file := Generate([]report.Finding{staging, prod}, File{})
+	if len(file.Suppressions) != 2 || file.Suppressions[0].Workspace != "envs/prod" || file.Suppressions[1].Workspace != "envs/staging" {
+		t.Fatalf("expected one entry per workspace, got %#v", file.Suppressions)
+	}
+
+	file.Suppressions = file.Suppressions[:1]
+	active, suppressed := file.Apply([]report.Finding{prod, staging}, time.Now())
+	if len(suppressed) != 1 || suppressed[0].Workspace != "envs/prod" {
+		t.Fatalf("expected only the prod finding to be suppressed, got %#v", suppressed)
+	}
+	if len(active) != 1 || active[0].Workspace != "envs/staging" {
+		t.Fatalf("the same address in another workspace must stay active, got %#v", active)
+	}
+}
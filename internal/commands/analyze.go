@@ -16,17 +16,24 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/yu/terraform-ops/internal/analysis"
+	"github.com/yu/terraform-ops/internal/baseline"
+	"github.com/yu/terraform-ops/internal/core"
 	"github.com/yu/terraform-ops/internal/ir"
 	"github.com/yu/terraform-ops/internal/report"
 	terraformsource "github.com/yu/terraform-ops/internal/source/terraform"
+	"github.com/yu/terraform-ops/internal/terraform/config"
 	"github.com/yu/terraform-ops/internal/version"
 )
 
@@ -37,12 +44,21 @@ type AnalyzeCommand struct {
 }
 
 type analyzeOptions struct {
-	format      string
-	engine      string
-	redaction   string
-	failOn      string
-	output      string
-	maxPlanSize int64
+	format        string
+	engine        string
+	redaction     string
+	failOn        string
+	output        string
+	rules         []string
+	catalogs      []string
+	configDir     string
+	baselines     []string
+	writeBaseline bool
+	terraformBin  string
+	maxPlanSize   int64
+	planPatterns  []string
+	parallelism   int
+	selection     ruleOptions
 }
 
 func NewAnalyzeCommand(registry *analysis.Registry, stdin io.Reader, stdout io.Writer) *AnalyzeCommand {
@@ -56,70 +72,142 @@ func DefaultAnalyzeCommand() *AnalyzeCommand {
 func (c *AnalyzeCommand) Command() *cobra.Command {
 	opts := analyzeOptions{}
 	cmd := &cobra.Command{
-		Use:   "analyze <PLAN_JSON>",
+		Use:   "analyze <PLAN_JSON>...",
 		Short: "Analyze Terraform/OpenTofu changes, causes, uncertainty, and blast radius",
 		Long: `Analyze a Terraform/OpenTofu JSON plan without executing Terraform/OpenTofu.
 
 Raw plan values are sanitized before they enter the normalized analysis model. Use "-"
-to read a plan JSON document from stdin.`,
-		Args: cobra.ExactArgs(1),
+to read a plan JSON document from stdin. A saved binary plan (terraform plan -out=tfplan)
+is converted by running "terraform show -json" (or "tofu show -json" with --engine opentofu)
+in the current directory.
+
+Several plans can be analyzed in one run by passing more than one path, a glob, or a
+directory, which is searched recursively for files matching --plan-pattern. Plans are
+loaded and analyzed concurrently. The report keeps each workspace's summary and findings
+separate, keyed by the plan's directory, and rolls the counts up; --fail-on applies to
+the findings of every plan. Binary plans are converted in their own directory.`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return c.run(cmd.Context(), args[0], opts)
+			configured, err := ruleSeverityOverrides(cmd)
+			if err != nil {
+				return err
+			}
+			opts.selection.configured = configured
+			return c.runPlans(cmd.Context(), args, opts)
 		},
 	}
-	cmd.Flags().StringVarP(&opts.format, "format", "f", string(report.FormatText), "Output format (text, json, markdown)")
+	cmd.Flags().StringVarP(&opts.format, "format", "f", string(report.FormatText), "Output format (text, json, markdown, sarif, junit)")
 	cmd.Flags().StringVar(&opts.engine, "engine", "auto", "Source engine (auto, terraform, opentofu)")
 	cmd.Flags().StringVar(&opts.redaction, "redaction", string(ir.RedactionStandard), "Redaction mode (standard, strict)")
 	cmd.Flags().StringVar(&opts.failOn, "fail-on", "none", "Fail when a finding meets the severity threshold (none, info, low, medium, high, critical)")
 	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Write the rendered report to a file instead of stdout")
+	cmd.Flags().StringArrayVar(&opts.rules, "rules", nil, "Load custom policy rules from a YAML or HCL file (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.catalogs, "resource-catalog", nil, "Extend the stateful resource catalog with a YAML file (repeatable)")
+	cmd.Flags().StringVar(&opts.configDir, "config-dir", "", "Resolve finding resources to .tf source positions in this root module directory")
+	cmd.Flags().StringArrayVar(&opts.baselines, "baseline", nil, "Suppress findings accepted in this baseline file (repeatable)")
+	cmd.Flags().BoolVar(&opts.writeBaseline, "write-baseline", false, "Write the current findings to the baseline file (default "+baseline.DefaultPath+") before reporting")
+	cmd.Flags().StringVar(&opts.terraformBin, "terraform-binary", "", "Terraform/OpenTofu executable used to read binary plan files (default terraform, or tofu with --engine opentofu)")
 	cmd.Flags().Int64Var(&opts.maxPlanSize, "max-plan-bytes", terraformsource.DefaultMaxPlanBytes, "Maximum accepted plan JSON size in bytes")
+	cmd.Flags().StringArrayVar(&opts.planPatterns, "plan-pattern", defaultPlanPatterns, "File name pattern of plans to pick up from directory arguments (repeatable)")
+	cmd.Flags().IntVar(&opts.parallelism, "parallelism", runtime.NumCPU(), "Maximum number of plans analyzed concurrently")
+	opts.selection.addFlags(cmd.Flags())
 	return cmd
 }
 
 func (c *AnalyzeCommand) run(ctx context.Context, planPath string, opts analyzeOptions) error {
-	engine, err := parseEngine(opts.engine)
+	setup, err := c.setup(opts)
 	if err != nil {
 		return err
 	}
-	redaction, err := parseRedaction(opts.redaction)
+	changeSet, err := loadChangeSet(ctx, planPath, setup.input)
 	if err != nil {
 		return err
 	}
-	format, err := parseAnalysisFormat(opts.format)
+	findings, err := setup.registry.Analyze(ctx, changeSet)
 	if err != nil {
 		return err
 	}
-	threshold, err := parseFailOn(opts.failOn)
-	if err != nil {
-		return err
+	var suppressed []report.SuppressedFinding
+	if len(opts.baselines) > 0 || opts.writeBaseline {
+		findings, suppressed, err = applyBaseline(findings, opts.baselines, opts.writeBaseline, time.Now())
+		if err != nil {
+			return err
+		}
 	}
+	analysisReport := report.Build(changeSet, findings, version.Version)
+	analysisReport.Suppressed = suppressed
+	report.Sort(&analysisReport)
+	if opts.configDir != "" {
+		if err := attachSourceLocations(&analysisReport, opts.configDir); err != nil {
+			return err
+		}
+	}
+	analysisReport.FailOn = setup.threshold
+	return c.emit(analysisReport, setup.format, opts.output)
+}
 
-	var plan *terraformsource.Plan
-	if planPath == "-" {
-		plan, err = terraformsource.ParseReader(c.stdin, opts.maxPlanSize)
-	} else {
-		plan, err = terraformsource.ParseFile(planPath, opts.maxPlanSize)
+// analyzeSetup holds the parsed options shared by single-plan and batch runs.
+type analyzeSetup struct {
+	input     planInput
+	format    report.Format
+	threshold report.Severity
+	registry  *analysis.Registry
+}
+
+func (c *AnalyzeCommand) setup(opts analyzeOptions) (analyzeSetup, error) {
+	engine, err := parseEngine(opts.engine)
+	if err != nil {
+		return analyzeSetup{}, err
 	}
+	redaction, err := parseRedaction(opts.redaction)
 	if err != nil {
-		return err
+		return analyzeSetup{}, err
 	}
-
-	changeSet, err := terraformsource.Normalize(plan, engine, redaction)
+	format, err := parseAnalysisFormat(opts.format)
 	if err != nil {
-		return err
+		return analyzeSetup{}, err
 	}
-	findings, err := c.registry.Analyze(ctx, changeSet)
+	threshold, err := parseFailOn(opts.failOn)
 	if err != nil {
-		return err
+		return analyzeSetup{}, err
 	}
-	analysisReport := report.Build(changeSet, findings, version.Version)
+	registry, err := c.registry.WithResourceCatalogs(opts.catalogs)
+	if err != nil {
+		return analyzeSetup{}, err
+	}
+	registry, err = withPolicyRules(registry, opts.rules)
+	if err != nil {
+		return analyzeSetup{}, err
+	}
+	registry, err = withRuleOptions(registry, opts.selection)
+	if err != nil {
+		return analyzeSetup{}, err
+	}
+	return analyzeSetup{
+		input: planInput{
+			stdin:           c.stdin,
+			maxBytes:        opts.maxPlanSize,
+			engine:          engine,
+			redaction:       redaction,
+			terraformBinary: opts.terraformBin,
+		},
+		format:    format,
+		threshold: threshold,
+		registry:  registry,
+	}, nil
+}
+
+// emit renders the report, writes it, and only then applies the fail-on
+// threshold across every finding in the report, so a gating run still
+// publishes its output.
+func (c *AnalyzeCommand) emit(analysisReport report.AnalysisReport, format report.Format, output string) error {
 	rendered, err := report.Render(analysisReport, format)
 	if err != nil {
 		return err
 	}
 
-	if opts.output != "" {
-		if err := os.WriteFile(opts.output, rendered, 0o600); err != nil {
+	if output != "" {
+		if err := os.WriteFile(output, rendered, 0o600); err != nil {
 			return fmt.Errorf("write analysis report: %w", err)
 		}
 	} else {
@@ -128,12 +216,107 @@ func (c *AnalyzeCommand) run(ctx context.Context, planPath string, opts analyzeO
 		}
 	}
 
-	if threshold != "" && report.MeetsThreshold(report.HighestSeverity(findings), threshold) {
-		return &FindingThresholdError{Threshold: threshold, Highest: report.HighestSeverity(findings)}
+	threshold := analysisReport.FailOn
+	highest := report.HighestSeverity(report.AllFindings(analysisReport))
+	if threshold != "" && report.MeetsThreshold(highest, threshold) {
+		return &FindingThresholdError{Threshold: threshold, Highest: highest}
 	}
 	return nil
 }
 
+// withPolicyRules extends registry with the custom rules declared in each
+// rules file, leaving registry itself unchanged.
+func withPolicyRules(registry *analysis.Registry, paths []string) (*analysis.Registry, error) {
+	for _, path := range paths {
+		analyzers, err := analysis.LoadPolicyFile(path)
+		if err != nil {
+			return nil, err
+		}
+		registry = registry.With(analyzers...)
+	}
+	return registry, nil
+}
+
+// applyBaseline removes accepted findings before thresholds are evaluated. In
+// write mode it first regenerates the baseline from the current findings,
+// keeping the justification and expiry of entries that still match.
+func applyBaseline(findings []report.Finding, paths []string, write bool, now time.Time) ([]report.Finding, []report.SuppressedFinding, error) {
+	file, err := resolveBaseline(findings, paths, write)
+	if err != nil {
+		return nil, nil, err
+	}
+	active, suppressed := file.Apply(findings, now)
+	return active, suppressed, nil
+}
+
+// resolveBaseline loads the baselines at paths as one file, or in write mode
+// regenerates the single baseline from findings first.
+func resolveBaseline(findings []report.Finding, paths []string, write bool) (baseline.File, error) {
+	if !write {
+		merged := baseline.File{Version: baseline.Version}
+		for _, path := range paths {
+			file, err := baseline.Load(path)
+			if err != nil {
+				return baseline.File{}, err
+			}
+			merged.Suppressions = append(merged.Suppressions, file.Suppressions...)
+		}
+		return merged, nil
+	}
+	if len(paths) > 1 {
+		return baseline.File{}, errors.New("--write-baseline needs a single --baseline path")
+	}
+	path := baseline.DefaultPath
+	if len(paths) == 1 {
+		path = paths[0]
+	}
+	previous, err := baseline.Load(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return baseline.File{}, err
+	}
+	file := baseline.Generate(findings, previous)
+	if err := baseline.Write(path, file); err != nil {
+		return baseline.File{}, err
+	}
+	return file, nil
+}
+
+// attachSourceLocations points each finding's resource at the configuration
+// block that declares it. Plan JSON has no source positions, so these come from
+// parsing the root module in configDir and its local module calls.
+func attachSourceLocations(analysisReport *report.AnalysisReport, configDir string) error {
+	locations, err := config.NewParser().ResourceLocations(configDir)
+	if err != nil {
+		return err
+	}
+	for i := range analysisReport.Findings {
+		analysisReport.Findings[i].Resource = withSourceLocation(analysisReport.Findings[i].Resource, locations)
+	}
+	for i := range analysisReport.Suppressed {
+		analysisReport.Suppressed[i].Resource = withSourceLocation(analysisReport.Suppressed[i].Resource, locations)
+	}
+	return nil
+}
+
+func withSourceLocation(resource *report.ResourceRef, locations map[ir.Address]core.SourceRange) *report.ResourceRef {
+	if resource == nil {
+		return nil
+	}
+	location, ok := locations[ir.Address(resource.Address).WithoutInstanceKeys()]
+	if !ok {
+		return resource
+	}
+	withLocation := *resource
+	withLocation.Location = &report.SourceLocation{
+		File:        location.File,
+		StartLine:   location.StartLine,
+		StartColumn: location.StartColumn,
+		EndLine:     location.EndLine,
+		EndColumn:   location.EndColumn,
+	}
+	return &withLocation
+}
+
 type FindingThresholdError struct {
 	Threshold report.Severity
 	Highest   report.Severity
@@ -145,7 +328,7 @@ func (e *FindingThresholdError) Error() string {
 
 func parseEngine(value string) (ir.Engine, error) {
 	switch strings.ToLower(value) {
-	case "auto":
+	case "auto", "":
 		return ir.EngineUnknown, nil
 	case "terraform":
 		return ir.EngineTerraform, nil
@@ -175,26 +358,22 @@ func parseAnalysisFormat(value string) (report.Format, error) {
 		return report.FormatJSON, nil
 	case report.FormatMarkdown:
 		return report.FormatMarkdown, nil
+	case report.FormatSARIF:
+		return report.FormatSARIF, nil
+	case report.FormatJUnit:
+		return report.FormatJUnit, nil
 	default:
-		return "", fmt.Errorf("unsupported analysis format %q: use text, json, or markdown", value)
+		return "", fmt.Errorf("unsupported analysis format %q: use text, json, markdown, sarif, or junit", value)
 	}
 }
 
 func parseFailOn(value string) (report.Severity, error) {
-	switch report.Severity(strings.ToLower(value)) {
-	case "none":
+	if strings.EqualFold(value, "none") {
 		return "", nil
-	case report.SeverityInfo:
-		return report.SeverityInfo, nil
-	case report.SeverityLow:
-		return report.SeverityLow, nil
-	case report.SeverityMedium:
-		return report.SeverityMedium, nil
-	case report.SeverityHigh:
-		return report.SeverityHigh, nil
-	case report.SeverityCritical:
-		return report.SeverityCritical, nil
-	default:
+	}
+	severity, err := report.ParseSeverity(value)
+	if err != nil {
 		return "", fmt.Errorf("unsupported fail-on threshold %q", value)
 	}
+	return severity, nil
 }
@@ -0,0 +1,316 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yu/terraform-ops/internal/baseline"
+	"github.com/yu/terraform-ops/internal/ir"
+	"github.com/yu/terraform-ops/internal/report"
+	"github.com/yu/terraform-ops/internal/version"
+)
+
+// defaultPlanPatterns selects plan files when analyze is given a directory.
+var defaultPlanPatterns = []string{"*plan.json"}
+
+// runPlans analyzes one plan, or several when the arguments name more than
+// one path, a glob, or a directory.
+func (c *AnalyzeCommand) runPlans(ctx context.Context, args []string, opts analyzeOptions) error {
+	paths, batch, err := expandPlanArgs(args, opts.planPatterns)
+	if err != nil {
+		return err
+	}
+	if !batch {
+		return c.run(ctx, paths[0], opts)
+	}
+	return c.runBatch(ctx, paths, opts)
+}
+
+// runBatch analyzes every plan on a bounded worker pool and reports them as
+// one batch report with a workspace entry per plan. One baseline file and the
+// fail-on threshold cover all plans; baseline entries name their workspace.
+func (c *AnalyzeCommand) runBatch(ctx context.Context, paths []string, opts analyzeOptions) error {
+	if opts.configDir != "" {
+		return errors.New("--config-dir can only be used when analyzing a single plan")
+	}
+	if opts.parallelism < 1 {
+		return fmt.Errorf("invalid parallelism %d: must be at least 1", opts.parallelism)
+	}
+	setup, err := c.setup(opts)
+	if err != nil {
+		return err
+	}
+	plans, err := analyzePlans(ctx, setup, paths, opts.parallelism)
+	if err != nil {
+		return err
+	}
+
+	// Baseline entries are scoped to a workspace so accepting a finding in
+	// one root module does not hide the same address in another.
+	names := workspaceNames(paths)
+	var accepted *baseline.File
+	if len(opts.baselines) > 0 || opts.writeBaseline {
+		var all []report.Finding
+		for _, plan := range plans {
+			all = append(all, withWorkspace(plan.findings, names[plan.path])...)
+		}
+		file, err := resolveBaseline(all, opts.baselines, opts.writeBaseline)
+		if err != nil {
+			return err
+		}
+		accepted = &file
+	}
+
+	now := time.Now()
+	workspaces := make(map[string]report.WorkspaceReport, len(plans))
+	for _, plan := range plans {
+		findings := plan.findings
+		var suppressed []report.SuppressedFinding
+		if accepted != nil {
+			findings, suppressed = accepted.Apply(withWorkspace(findings, names[plan.path]), now)
+			// Workspace entries carry their name; their findings do not.
+			findings = withWorkspace(findings, "")
+			for i := range suppressed {
+				suppressed[i].Workspace = ""
+			}
+		}
+		built := report.Build(plan.changeSet, findings, version.Version)
+		built.Suppressed = suppressed
+		report.Sort(&built)
+		workspaces[names[plan.path]] = built.Workspace(plan.path)
+	}
+	aggregated := report.Aggregate(workspaces, version.Version)
+	aggregated.FailOn = setup.threshold
+	return c.emit(aggregated, setup.format, opts.output)
+}
+
+// withWorkspace returns copies of findings tagged with workspace.
+func withWorkspace(findings []report.Finding, workspace string) []report.Finding {
+	out := make([]report.Finding, len(findings))
+	for i, finding := range findings {
+		finding.Workspace = workspace
+		out[i] = finding
+	}
+	return out
+}
+
+type analyzedPlan struct {
+	path      string
+	changeSet *ir.ChangeSet
+	findings  []report.Finding
+}
+
+// analyzePlans loads and analyzes plans on at most parallelism workers.
+// Binary plans are converted in their own directory, which is expected to be
+// the initialized root module. The first failure cancels the remaining work.
+func analyzePlans(parent context.Context, setup analyzeSetup, paths []string, parallelism int) ([]analyzedPlan, error) {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	results := make([]analyzedPlan, len(paths))
+	errs := make([]error, len(paths))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(parallelism, len(paths)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				input := setup.input
+				input.workingDir = filepath.Dir(paths[i])
+				changeSet, err := loadChangeSet(ctx, paths[i], input)
+				var findings []report.Finding
+				if err == nil {
+					findings, err = setup.registry.Analyze(ctx, changeSet)
+				}
+				if err != nil {
+					errs[i] = fmt.Errorf("analyze plan %s: %w", paths[i], err)
+					cancel()
+					continue
+				}
+				results[i] = analyzedPlan{path: paths[i], changeSet: changeSet, findings: findings}
+			}
+		}()
+	}
+feed:
+	for i := range paths {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := parent.Err(); err != nil {
+		return nil, err
+	}
+	// Report the failure that triggered the cancellation rather than the
+	// cancellations it caused in other workers.
+	var canceled error
+	for _, err := range errs {
+		switch {
+		case err == nil:
+		case !errors.Is(err, context.Canceled):
+			return nil, err
+		case canceled == nil:
+			canceled = err
+		}
+	}
+	if canceled != nil {
+		return nil, canceled
+	}
+	return results, nil
+}
+
+// expandPlanArgs resolves analyze arguments into plan paths. Globs are
+// expanded and directories are searched recursively for files matching
+// patterns. batch is false only for a single plain path (or "-"), which keeps
+// the single-plan report.
+func expandPlanArgs(args, patterns []string) ([]string, bool, error) {
+	if len(patterns) == 0 {
+		patterns = defaultPlanPatterns
+	}
+	batch := len(args) > 1
+	seen := make(map[string]struct{})
+	var paths []string
+	add := func(path string) {
+		if path != "-" {
+			path = filepath.Clean(path)
+		}
+		if _, ok := seen[path]; !ok {
+			seen[path] = struct{}{}
+			paths = append(paths, path)
+		}
+	}
+	addTree := func(path string) error {
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			add(path)
+			return nil
+		}
+		found, err := findPlans(path, patterns)
+		if err != nil {
+			return err
+		}
+		if len(found) == 0 {
+			return fmt.Errorf("no plans matching %s found in %s", strings.Join(patterns, ", "), path)
+		}
+		for _, plan := range found {
+			add(plan)
+		}
+		return nil
+	}
+
+	for _, arg := range args {
+		switch {
+		case arg == "-":
+			if len(args) > 1 {
+				return nil, false, errors.New("stdin (\"-\") cannot be combined with other plans")
+			}
+			add(arg)
+		case strings.ContainsAny(arg, "*?["):
+			batch = true
+			matches, err := filepath.Glob(arg)
+			if err != nil {
+				return nil, false, fmt.Errorf("invalid plan glob %q: %w", arg, err)
+			}
+			if len(matches) == 0 {
+				return nil, false, fmt.Errorf("no plans match %q", arg)
+			}
+			for _, match := range matches {
+				if err := addTree(match); err != nil {
+					return nil, false, err
+				}
+			}
+		default:
+			if info, err := os.Stat(arg); err == nil && info.IsDir() {
+				batch = true
+			}
+			if err := addTree(arg); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+	return paths, batch, nil
+}
+
+// findPlans walks root for files whose name matches one of patterns. Hidden
+// directories such as .terraform and .git are skipped.
+func findPlans(root string, patterns []string) ([]string, error) {
+	var found []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != root && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		for _, pattern := range patterns {
+			if matched, _ := filepath.Match(pattern, entry.Name()); matched {
+				found = append(found, path)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search plans in %s: %w", root, err)
+	}
+	sort.Strings(found)
+	return found, nil
+}
+
+// workspaceNames names each plan after the directory that holds it, which in
+// a monorepo is the root module. Plans that share a directory, or sit in the
+// current one, are named by their path without the extension instead. When
+// two plans still end up with the same name, such as a/b.json and
+// a/b/plan.json, both are named by their full path.
+func workspaceNames(paths []string) map[string]string {
+	dirs := make(map[string]int, len(paths))
+	for _, path := range paths {
+		dirs[filepath.Dir(path)]++
+	}
+	names := make(map[string]string, len(paths))
+	taken := make(map[string]int, len(paths))
+	for _, path := range paths {
+		name := filepath.Dir(path)
+		if name == "." || dirs[name] > 1 {
+			name = strings.TrimSuffix(path, filepath.Ext(path))
+		}
+		names[path] = filepath.ToSlash(name)
+		taken[names[path]]++
+	}
+	for path, name := range names {
+		if taken[name] > 1 {
+			names[path] = filepath.ToSlash(path)
+		}
+	}
+	return names
+}
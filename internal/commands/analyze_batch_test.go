@@ -0,0 +1,263 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yu/terraform-ops/internal/analysis"
+	"github.com/yu/terraform-ops/internal/baseline"
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+const batchDeletePlan = `{
+  "format_version":"1.0",
+  "applyable":true,
+  "complete":true,
+  "errored":false,
+  "resource_changes":[{
+    "address":"test_resource.example",
+    "mode":"managed",
+    "type":"test_resource",
+    "name":"example",
+    "change":{"actions":["delete"]}
+  }],
+  "output_changes":{},
+  "configuration":{"root_module":{"resources":[],"module_calls":{},"outputs":{}}}
+}`
+
+const batchEmptyPlan = `{
+  "format_version":"1.0",
+  "applyable":true,
+  "complete":true,
+  "errored":false,
+  "resource_changes":[],
+  "output_changes":{},
+  "configuration":{"root_module":{"resources":[],"module_calls":{},"outputs":{}}}
+}`
+
+func writeBatchPlans(t *testing.T, plans map[string]string) {
+	t.Helper()
+	for path, content := range plans {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAnalyzeCommandBatchKeepsWorkspacesSeparate(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeBatchPlans(t, map[string]string{
+		"envs/prod/plan.json":         batchDeletePlan,
+		"envs/staging/plan.json":      batchEmptyPlan,
+		"envs/staging/notes.json":     `not a plan`,
+		"envs/.terraform/x/plan.json": `not a plan either`,
+	})
+
+	var stdout bytes.Buffer
+	cmd := NewAnalyzeCommand(analysis.DefaultRegistry(), strings.NewReader(""), &stdout)
+	err := cmd.runPlans(context.Background(), []string{"envs"}, analyzeOptions{
+		format:      "json",
+		engine:      "terraform",
+		redaction:   "standard",
+		failOn:      "medium",
+		maxPlanSize: 1 << 20,
+		parallelism: 2,
+	})
+	var thresholdErr *FindingThresholdError
+	if !errors.As(err, &thresholdErr) {
+		t.Fatalf("err = %v, want threshold error across plans", err)
+	}
+
+	var got report.AnalysisReport
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON report: %v\n%s", err, stdout.String())
+	}
+	if names := report.WorkspaceNames(got); !reflect.DeepEqual(names, []string{"envs/prod", "envs/staging"}) {
+		t.Fatalf("workspaces = %v", names)
+	}
+	if len(got.Findings) != 0 {
+		t.Fatal("batch findings must stay inside their workspace")
+	}
+	prod := got.Workspaces["envs/prod"]
+	if prod.Path != filepath.Join("envs", "prod", "plan.json") || prod.Summary.Delete != 1 || len(prod.Findings) == 0 {
+		t.Fatalf("unexpected prod workspace: %+v", prod)
+	}
+	if staging := got.Workspaces["envs/staging"]; len(staging.Findings) != 0 {
+		t.Fatalf("staging should have no findings: %+v", staging.Findings)
+	}
+	if got.Summary.Delete != 1 || got.Summary.Findings != prod.Summary.Findings {
+		t.Fatalf("rolled-up summary = %+v", got.Summary)
+	}
+}
+
+func TestAnalyzeCommandBatchScopesBaselineToWorkspace(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeBatchPlans(t, map[string]string{
+		"envs/prod/plan.json":    batchDeletePlan,
+		"envs/staging/plan.json": batchDeletePlan,
+	})
+	opts := analyzeOptions{
+		format:        "json",
+		engine:        "terraform",
+		redaction:     "standard",
+		failOn:        "none",
+		maxPlanSize:   1 << 20,
+		parallelism:   2,
+		baselines:     []string{"baseline.json"},
+		writeBaseline: true,
+	}
+	cmd := NewAnalyzeCommand(analysis.DefaultRegistry(), strings.NewReader(""), &bytes.Buffer{})
+	if err := cmd.runPlans(context.Background(), []string{"envs"}, opts); err != nil {
+		t.Fatal(err)
+	}
+	written, err := baseline.Load("baseline.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var prodOnly []baseline.Suppression
+	for _, suppression := range written.Suppressions {
+		if suppression.Workspace == "envs/prod" {
+			prodOnly = append(prodOnly, suppression)
+		}
+	}
+	if len(prodOnly) == 0 || len(prodOnly)*2 != len(written.Suppressions) {
+		t.Fatalf("expected matching entries per workspace, got %#v", written.Suppressions)
+	}
+	written.Suppressions = prodOnly
+	if err := baseline.Write("baseline.json", written); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	opts.writeBaseline = false
+	cmd = NewAnalyzeCommand(analysis.DefaultRegistry(), strings.NewReader(""), &stdout)
+	if err := cmd.runPlans(context.Background(), []string{"envs"}, opts); err != nil {
+		t.Fatal(err)
+	}
+	var got report.AnalysisReport
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON report: %v\n%s", err, stdout.String())
+	}
+	if prod := got.Workspaces["envs/prod"]; len(prod.Findings) != 0 || len(prod.Suppressed) == 0 {
+		t.Fatalf("prod findings should be suppressed: %+v", prod)
+	}
+	if staging := got.Workspaces["envs/staging"]; len(staging.Findings) == 0 || len(staging.Suppressed) != 0 {
+		t.Fatalf("staging findings must stay active: %+v", staging)
+	}
+}
+
+func TestAnalyzeCommandBatchReportsFailingPlan(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeBatchPlans(t, map[string]string{
+		"a/plan.json": batchEmptyPlan,
+		"b/plan.json": `{"format_version":`,
+	})
+	cmd := NewAnalyzeCommand(analysis.DefaultRegistry(), strings.NewReader(""), &bytes.Buffer{})
+	err := cmd.runPlans(context.Background(), []string{"*/plan.json"}, analyzeOptions{
+		format:      "text",
+		engine:      "terraform",
+		redaction:   "standard",
+		failOn:      "none",
+		maxPlanSize: 1 << 20,
+		parallelism: 4,
+	})
+	if err == nil || !strings.Contains(err.Error(), filepath.Join("b", "plan.json")) {
+		t.Fatalf("err = %v, want error naming the broken plan", err)
+	}
+}
+
+func TestExpandPlanArgs(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeBatchPlans(t, map[string]string{
+		"stacks/net/plan.json":         batchEmptyPlan,
+		"stacks/app/prod.tfplan.json":  batchEmptyPlan,
+		"stacks/app/terraform.tfstate": "{}",
+		"stacks/app/.cache/plan.json":  batchEmptyPlan,
+		"standalone.json":              batchEmptyPlan,
+	})
+
+	paths, batch, err := expandPlanArgs([]string{"standalone.json"}, nil)
+	if err != nil || batch || !reflect.DeepEqual(paths, []string{"standalone.json"}) {
+		t.Fatalf("single path = %v, %v, %v", paths, batch, err)
+	}
+	paths, batch, err = expandPlanArgs([]string{"stacks", "standalone.json"}, nil)
+	if err != nil || !batch {
+		t.Fatalf("directory = %v, %v, %v", paths, batch, err)
+	}
+	want := []string{
+		filepath.Join("stacks", "app", "prod.tfplan.json"),
+		filepath.Join("stacks", "net", "plan.json"),
+		"standalone.json",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+	if _, _, err := expandPlanArgs([]string{"missing/*.json"}, nil); err == nil {
+		t.Fatal("expected an error for a glob without matches")
+	}
+	if _, _, err := expandPlanArgs([]string{"-", "standalone.json"}, nil); err == nil {
+		t.Fatal("expected stdin to be rejected in batch mode")
+	}
+}
+
+func TestWorkspaceNames(t *testing.T) {
+	got := workspaceNames([]string{
+		filepath.Join("envs", "prod", "plan.json"),
+		filepath.Join("envs", "dev", "a.json"),
+		filepath.Join("envs", "dev", "b.json"),
+		"root.json",
+	})
+	want := map[string]string{
+		filepath.Join("envs", "prod", "plan.json"): "envs/prod",
+		filepath.Join("envs", "dev", "a.json"):     "envs/dev/a",
+		filepath.Join("envs", "dev", "b.json"):     "envs/dev/b",
+		"root.json":                                "root",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("workspaceNames() = %v, want %v", got, want)
+	}
+}
+
+func TestWorkspaceNamesKeepsCollidingPlansApart(t *testing.T) {
+	got := workspaceNames([]string{
+		filepath.Join("a", "b.json"),
+		filepath.Join("a", "c.json"),
+		filepath.Join("a", "b", "plan.json"),
+		filepath.Join("stack", "plan.json"),
+		filepath.Join("stack", "plan.tfplan"),
+	})
+	want := map[string]string{
+		filepath.Join("a", "b.json"):          "a/b.json",
+		filepath.Join("a", "c.json"):          "a/c",
+		filepath.Join("a", "b", "plan.json"):  "a/b/plan.json",
+		filepath.Join("stack", "plan.json"):   "stack/plan.json",
+		filepath.Join("stack", "plan.tfplan"): "stack/plan.tfplan",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("workspaceNames() = %v, want %v", got, want)
+	}
+}
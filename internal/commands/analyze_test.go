@@ -17,6 +17,9 @@ package commands
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -132,3 +135,120 @@ func TestAnalyzeCommandFailOnReturnsErrorAfterRendering(t *testing.T) {
 		t.Fatal("expected report to be rendered before threshold error")
 	}
 }
+
+func TestAnalyzeCommandSARIFIncludesSourceLocations(t *testing.T) {
+	configDir := t.TempDir()
+	mainTf := "resource \"test_resource\" \"example\" {\n  name = \"example\"\n}\n"
+	if err := os.WriteFile(filepath.Join(configDir, "main.tf"), []byte(mainTf), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	input := `{
+  "format_version":"1.0",
+  "applyable":true,
+  "complete":true,
+  "errored":false,
+  "resource_changes":[{
+    "address":"test_resource.example[0]",
+    "mode":"managed",
+    "type":"test_resource",
+    "name":"example",
+    "index":0,
+    "change":{"actions":["delete"]}
+  }],
+  "output_changes":{},
+  "configuration":{"root_module":{"resources":[],"module_calls":{},"outputs":{}}}
+}`
+	var stdout bytes.Buffer
+	cmd := NewAnalyzeCommand(analysis.DefaultRegistry(), strings.NewReader(input), &stdout)
+	err := cmd.run(context.Background(), "-", analyzeOptions{
+		format:      "sarif",
+		engine:      "terraform",
+		redaction:   "standard",
+		failOn:      "none",
+		configDir:   configDir,
+		maxPlanSize: 1 << 20,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || len(log.Runs[0].Results) == 0 {
+		t.Fatalf("unexpected SARIF output: %s", stdout.String())
+	}
+	location := log.Runs[0].Results[0].Locations[0].PhysicalLocation
+	if !strings.HasSuffix(location.ArtifactLocation.URI, "main.tf") || location.Region.StartLine != 1 {
+		t.Fatalf("unexpected physical location: %+v", location)
+	}
+}
+
+func TestAnalyzeCommandBaselineSuppressesBeforeThreshold(t *testing.T) {
+	input := `{
+  "format_version":"1.0",
+  "applyable":true,
+  "complete":true,
+  "errored":false,
+  "resource_changes":[{
+    "address":"test_resource.example",
+    "mode":"managed",
+    "type":"test_resource",
+    "name":"example",
+    "change":{"actions":["delete"]}
+  }],
+  "output_changes":{},
+  "configuration":{"root_module":{"resources":[],"module_calls":{},"outputs":{}}}
+}`
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+	opts := analyzeOptions{
+		format:        "json",
+		engine:        "terraform",
+		redaction:     "standard",
+		failOn:        "low",
+		baselines:     []string{baselinePath},
+		writeBaseline: true,
+		maxPlanSize:   1 << 20,
+	}
+	var stdout bytes.Buffer
+	cmd := NewAnalyzeCommand(analysis.DefaultRegistry(), strings.NewReader(input), &stdout)
+	if err := cmd.run(context.Background(), "-", opts); err != nil {
+		t.Fatalf("write-baseline should accept every current finding: %v", err)
+	}
+	if _, err := os.Stat(baselinePath); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout.Reset()
+	opts.writeBaseline = false
+	cmd = NewAnalyzeCommand(analysis.DefaultRegistry(), strings.NewReader(input), &stdout)
+	if err := cmd.run(context.Background(), "-", opts); err != nil {
+		t.Fatalf("baselined findings must not trip --fail-on: %v", err)
+	}
+	var got struct {
+		Findings   []map[string]any `json:"findings"`
+		Suppressed []map[string]any `json:"suppressed"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Findings) != 0 || len(got.Suppressed) == 0 {
+		t.Fatalf("expected only suppressed findings, got %s", stdout.String())
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yu/terraform-ops/internal/core"
+	"github.com/yu/terraform-ops/internal/terraform/config"
+	"github.com/yu/terraform-ops/internal/version"
+	"github.com/yu/terraform-ops/internal/versionpolicy"
+)
+
+// CheckVersionsCommand evaluates the version constraints of workspaces
+// against a version policy.
+type CheckVersionsCommand struct {
+	configParser    core.ConfigParser
+	workspaceFinder core.WorkspaceFinder
+	stdout          io.Writer
+}
+
+type checkVersionsOptions struct {
+	policy   string
+	format   string
+	output   string
+	exitCode bool
+	search   workspaceSearchOptions
+}
+
+func NewCheckVersionsCommand(configParser core.ConfigParser, workspaceFinder core.WorkspaceFinder, stdout io.Writer) *CheckVersionsCommand {
+	return &CheckVersionsCommand{configParser: configParser, workspaceFinder: workspaceFinder, stdout: stdout}
+}
+
+func DefaultCheckVersionsCommand() *CheckVersionsCommand {
+	parser := config.NewParser()
+	return NewCheckVersionsCommand(parser, parser, os.Stdout)
+}
+
+func (c *CheckVersionsCommand) Command() *cobra.Command {
+	opts := checkVersionsOptions{}
+	cmd := &cobra.Command{
+		Use:   "check-versions --policy <FILE> <path...>",
+		Short: "Check workspace version constraints against a version policy",
+		Long: `Check the required_version and required_providers constraints of Terraform workspaces
+against a version policy: allowed Terraform versions, minimum and maximum provider versions,
+and banned providers.
+
+Constraints are evaluated as version ranges, not compared as text. A workspace violates a
+rule when its constraint accepts any version the policy does not, so "~> 5.10" satisfies a
+policy of ">= 5.0, < 6.0" while ">= 4.0" does not, and a provider without a constraint
+never satisfies a bounded rule. Providers the policy does not mention are not checked.
+
+With --recursive each path is searched for root modules as in show-terraform.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(args, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.policy, "policy", "", "Version policy YAML file (required)")
+	cmd.Flags().StringVarP(&opts.format, "format", "f", string(versionpolicy.FormatText), "Output format (text, json)")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Write the report to a file instead of stdout")
+	cmd.Flags().BoolVar(&opts.exitCode, "exit-code", true, "Return an error after rendering when any workspace violates the policy")
+	opts.search.addFlags(cmd.Flags())
+	return cmd
+}
+
+func (c *CheckVersionsCommand) run(args []string, opts checkVersionsOptions) error {
+	if opts.policy == "" {
+		return errors.New("--policy is required")
+	}
+	format, err := versionpolicy.ParseFormat(opts.format)
+	if err != nil {
+		return err
+	}
+	policy, err := versionpolicy.LoadPolicy(opts.policy)
+	if err != nil {
+		return err
+	}
+	paths, discovered, err := opts.search.resolvePaths(c.workspaceFinder, args)
+	if err != nil {
+		return err
+	}
+	configs, err := c.configParser.ParseConfigFiles(paths)
+	if err != nil {
+		return fmt.Errorf("failed to parse config files: %w", err)
+	}
+	writeDiagnosticList(os.Stderr, discovered)
+	writeDiagnostics(os.Stderr, configs)
+	checked, err := policy.Check(configs, opts.policy, version.Version)
+	if err != nil {
+		return err
+	}
+	rendered, err := versionpolicy.Render(checked, format)
+	if err != nil {
+		return err
+	}
+	if opts.output != "" {
+		if err := os.WriteFile(opts.output, rendered, 0o600); err != nil {
+			return fmt.Errorf("write version check: %w", err)
+		}
+	} else if _, err := c.stdout.Write(rendered); err != nil {
+		return fmt.Errorf("write version check: %w", err)
+	}
+
+	if opts.exitCode && checked.Summary.NonCompliant > 0 {
+		return &VersionPolicyError{Summary: checked.Summary}
+	}
+	return nil
+}
+
+// VersionPolicyError is returned after the report has been rendered when at
+// least one workspace violates the version policy.
+type VersionPolicyError struct {
+	Summary versionpolicy.Summary
+}
+
+func (e *VersionPolicyError) Error() string {
+	return fmt.Sprintf("version policy violated: %d of %d workspaces non-compliant, %d violations",
+		e.Summary.NonCompliant, e.Summary.Workspaces, e.Summary.Violations)
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yu/terraform-ops/internal/terraform/config"
+)
+
+func writeCheckVersionsFixture(t *testing.T, name, terraformBlock string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(terraformBlock), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestCheckVersionsCommandReportsViolationsAfterRendering(t *testing.T) {
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+	policy := "terraform:\n  allowed: \">= 1.5\"\nproviders:\n  aws:\n    allowed: \"~> 5.0\"\n"
+	if err := os.WriteFile(policyPath, []byte(policy), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	compliant := writeCheckVersionsFixture(t, "compliant", `
+terraform {
+  required_version = ">= 1.6"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.40"
+    }
+  }
+}
+`)
+	legacy := writeCheckVersionsFixture(t, "legacy", `
+terraform {
+  required_version = ">= 1.5"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 4.0"
+    }
+  }
+}
+`)
+
+	parser := config.NewParser()
+	var stdout bytes.Buffer
+	cmd := NewCheckVersionsCommand(parser, parser, &stdout)
+	err := cmd.run([]string{compliant, legacy}, checkVersionsOptions{policy: policyPath, format: "text", exitCode: true})
+	var policyErr *VersionPolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("expected version policy error, got %v", err)
+	}
+	if policyErr.Summary.NonCompliant != 1 || policyErr.Summary.Violations != 1 {
+		t.Fatalf("unexpected summary: %#v", policyErr.Summary)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "PASS  "+compliant) || !strings.Contains(out, "FAIL  "+legacy) {
+		t.Fatalf("report was not rendered before returning: %s", out)
+	}
+	if !strings.Contains(out, ">= 4.0.0, < 5.0.0") {
+		t.Fatalf("violation does not name the offending range: %s", out)
+	}
+
+	stdout.Reset()
+	err = cmd.run([]string{compliant, legacy}, checkVersionsOptions{policy: policyPath, format: "json", exitCode: false})
+	if err != nil {
+		t.Fatalf("--exit-code=false should not fail: %v", err)
+	}
+	if !strings.Contains(stdout.String(), `"non_compliant": 1`) {
+		t.Fatalf("unexpected JSON report: %s", stdout.String())
+	}
+}
+
+func TestCheckVersionsCommandRequiresPolicy(t *testing.T) {
+	parser := config.NewParser()
+	cmd := NewCheckVersionsCommand(parser, parser, &bytes.Buffer{})
+	if err := cmd.run([]string{"."}, checkVersionsOptions{format: "text"}); err == nil || !strings.Contains(err.Error(), "--policy") {
+		t.Fatalf("expected missing policy error, got %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	opsconfig "github.com/yu/terraform-ops/internal/config"
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+// ApplyConfig fills every flag of cmd that was not passed on the command line,
+// first from TFOPS_* environment variables and then from the configuration
+// layers, and stores the layers in the command context for settings that are
+// not flags. Configured commands and flags that do not exist are rejected.
+func ApplyConfig(cmd *cobra.Command, layers opsconfig.Layers, getenv func(string) string) error {
+	if err := layers.Check(func(command string) (func(string) bool, bool) {
+		return resolveConfigCommand(cmd.Root(), command)
+	}); err != nil {
+		return err
+	}
+
+	command := configCommandName(cmd)
+	var errs []error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Changed || flag.Name == "help" || flag.Name == "version" {
+			return
+		}
+		if value, name, ok := opsconfig.EnvValue(getenv, command, flag.Name); ok {
+			values := []string{value}
+			if _, slice := flag.Value.(pflag.SliceValue); slice {
+				values = strings.Split(value, ",")
+			}
+			if err := setFlagDefault(flag, values); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			}
+			return
+		}
+		values, _, ok, err := layers.FlagValues(command, flag.Name)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		if ok {
+			if err := setFlagDefault(flag, values); err != nil {
+				errs = append(errs, fmt.Errorf("config for %s --%s: %w", command, flag.Name, err))
+			}
+		}
+	})
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cmd.SetContext(opsconfig.NewContext(ctx, layers))
+	return nil
+}
+
+// setFlagDefault replaces the flag's default without marking it as changed,
+// so later layers of the precedence chain never see it as user input.
+func setFlagDefault(flag *pflag.Flag, values []string) error {
+	if slice, ok := flag.Value.(pflag.SliceValue); ok {
+		return slice.Replace(values)
+	}
+	if len(values) != 1 {
+		return fmt.Errorf("flag is not repeatable but got %d values", len(values))
+	}
+	return flag.Value.Set(values[0])
+}
+
+// configCommandName is the command path below the root command, the key used
+// for the command in configuration files.
+func configCommandName(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
+
+func resolveConfigCommand(root *cobra.Command, command string) (func(string) bool, bool) {
+	current := root
+	for _, name := range strings.Fields(command) {
+		var next *cobra.Command
+		for _, child := range current.Commands() {
+			if child.Name() == name {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			return nil, false
+		}
+		current = next
+	}
+	if current == root {
+		return nil, false
+	}
+	return func(flag string) bool {
+		return current.Flags().Lookup(flag) != nil || current.InheritedFlags().Lookup(flag) != nil
+	}, true
+}
+
+// ruleSeverityOverrides parses the rule_severity settings of the configuration
+// in cmd's context.
+func ruleSeverityOverrides(cmd *cobra.Command) (map[string]report.Severity, error) {
+	configured := opsconfig.FromContext(cmd.Context()).RuleSeverity()
+	overrides := make(map[string]report.Severity, len(configured))
+	for rule, value := range configured {
+		severity, err := report.ParseSeverity(value)
+		if err != nil {
+			return nil, fmt.Errorf("rule_severity for %s: %w", rule, err)
+		}
+		overrides[rule] = severity
+	}
+	return overrides, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	opsconfig "github.com/yu/terraform-ops/internal/config"
+)
+
+func configTestCommand(t *testing.T) (*cobra.Command, *cobra.Command, *analyzeOptions) {
+	t.Helper()
+	opts := &analyzeOptions{}
+	analyze := &cobra.Command{Use: "analyze", RunE: func(*cobra.Command, []string) error { return nil }}
+	analyze.Flags().StringVar(&opts.format, "format", "text", "")
+	analyze.Flags().StringVar(&opts.failOn, "fail-on", "none", "")
+	analyze.Flags().StringVar(&opts.terraformBin, "terraform-binary", "", "")
+	analyze.Flags().StringArrayVar(&opts.rules, "rules", nil, "")
+	root := &cobra.Command{Use: "terraform-ops"}
+	root.AddCommand(analyze)
+	return root, analyze, opts
+}
+
+func loadTestLayers(t *testing.T, content string) opsconfig.Layers {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), opsconfig.ProjectFileName)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := opsconfig.LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return opsconfig.Layers{cfg}
+}
+
+func TestApplyConfigPrecedence(t *testing.T) {
+	_, analyze, opts := configTestCommand(t)
+	layers := loadTestLayers(t, `
+terraform_bin: tofu
+rule_severity:
+  TFOPS-DRIFT-DETECTED: high
+commands:
+  analyze:
+    format: markdown
+    fail-on: low
+    rules: [a.yaml, b.yaml]
+`)
+	if err := analyze.ParseFlags([]string{"--format", "json"}); err != nil {
+		t.Fatal(err)
+	}
+	getenv := func(name string) string {
+		return map[string]string{"TFOPS_ANALYZE_FAIL_ON": "high"}[name]
+	}
+	if err := ApplyConfig(analyze, layers, getenv); err != nil {
+		t.Fatal(err)
+	}
+
+	if opts.format != "json" {
+		t.Errorf("flag must beat config: format %q", opts.format)
+	}
+	if opts.failOn != "high" {
+		t.Errorf("env must beat config: fail-on %q", opts.failOn)
+	}
+	if opts.terraformBin != "tofu" {
+		t.Errorf("top-level terraform_bin not applied: %q", opts.terraformBin)
+	}
+	if !reflect.DeepEqual(opts.rules, []string{"a.yaml", "b.yaml"}) {
+		t.Errorf("list values not applied: %v", opts.rules)
+	}
+	if analyze.Flags().Changed("fail-on") {
+		t.Error("configured defaults must not count as command line input")
+	}
+	overrides, err := ruleSeverityOverrides(analyze)
+	if err != nil || overrides["TFOPS-DRIFT-DETECTED"] != "high" {
+		t.Errorf("rule severity not carried in context: %v, %v", overrides, err)
+	}
+}
+
+func TestApplyConfigRejectsUnknownCommandsAndFlags(t *testing.T) {
+	_, analyze, _ := configTestCommand(t)
+	layers := loadTestLayers(t, `
+commands:
+  analyse:
+    format: json
+  analyze:
+    colour: never
+`)
+	err := ApplyConfig(analyze, layers, func(string) string { return "" })
+	if err == nil || !strings.Contains(err.Error(), `unknown command "analyse"`) || !strings.Contains(err.Error(), "no flag --colour") {
+		t.Fatalf("expected unknown command and flag errors, got %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yu/terraform-ops/internal/analysis"
+	"github.com/yu/terraform-ops/internal/ir"
+	"github.com/yu/terraform-ops/internal/plandiff"
+	"github.com/yu/terraform-ops/internal/report"
+	terraformsource "github.com/yu/terraform-ops/internal/source/terraform"
+	"github.com/yu/terraform-ops/internal/version"
+)
+
+// DiffPlansCommand compares two plans after both have crossed the source
+// sanitization boundary and been analyzed with the same registry.
+type DiffPlansCommand struct {
+	registry *analysis.Registry
+	stdin    io.Reader
+	stdout   io.Writer
+}
+
+type diffPlansOptions struct {
+	format       string
+	engine       string
+	redaction    string
+	output       string
+	rules        []string
+	catalogs     []string
+	exitCode     bool
+	terraformBin string
+	maxPlanSize  int64
+	selection    ruleOptions
+}
+
+func NewDiffPlansCommand(registry *analysis.Registry, stdin io.Reader, stdout io.Writer) *DiffPlansCommand {
+	return &DiffPlansCommand{registry: registry, stdin: stdin, stdout: stdout}
+}
+
+func DefaultDiffPlansCommand() *DiffPlansCommand {
+	return NewDiffPlansCommand(analysis.DefaultRegistry(), os.Stdin, os.Stdout)
+}
+
+func (c *DiffPlansCommand) Command() *cobra.Command {
+	opts := diffPlansOptions{}
+	cmd := &cobra.Command{
+		Use:   "diff-plans <OLD_PLAN_JSON> <NEW_PLAN_JSON>",
+		Short: "Compare two Terraform/OpenTofu plans of the same workspace",
+		Long: `Compare two Terraform/OpenTofu JSON plans, for example the plan a reviewer approved and
+the plan that is about to be applied.
+
+Both plans are sanitized and analyzed the same way as terraform-ops analyze. The report
+lists resources that appeared or disappeared, resources whose action, replacement paths,
+unknown-after-apply paths or sanitized values changed, and findings that are new or
+resolved. Attribute names are reported; attribute values are not. Use "-" for at most one
+plan to read it from stdin. Saved binary plans are converted with "terraform show -json".`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configured, err := ruleSeverityOverrides(cmd)
+			if err != nil {
+				return err
+			}
+			opts.selection.configured = configured
+			return c.run(cmd.Context(), args[0], args[1], opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.format, "format", "f", string(report.FormatText), "Output format (text, json, markdown)")
+	cmd.Flags().StringVar(&opts.engine, "engine", "auto", "Source engine (auto, terraform, opentofu)")
+	cmd.Flags().StringVar(&opts.redaction, "redaction", string(ir.RedactionStandard), "Redaction mode (standard, strict)")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Write the rendered diff to a file instead of stdout")
+	cmd.Flags().StringArrayVar(&opts.rules, "rules", nil, "Load custom policy rules from a YAML or HCL file (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.catalogs, "resource-catalog", nil, "Extend the stateful resource catalog with a YAML file (repeatable)")
+	cmd.Flags().BoolVar(&opts.exitCode, "exit-code", true, "Return an error after rendering when the plans diverge")
+	cmd.Flags().StringVar(&opts.terraformBin, "terraform-binary", "", "Terraform/OpenTofu executable used to read binary plan files (default terraform, or tofu with --engine opentofu)")
+	cmd.Flags().Int64Var(&opts.maxPlanSize, "max-plan-bytes", terraformsource.DefaultMaxPlanBytes, "Maximum accepted plan JSON size in bytes")
+	opts.selection.addFlags(cmd.Flags())
+	return cmd
+}
+
+func (c *DiffPlansCommand) run(ctx context.Context, oldPath, newPath string, opts diffPlansOptions) error {
+	if oldPath == "-" && newPath == "-" {
+		return errors.New("at most one plan can be read from stdin")
+	}
+	engine, err := parseEngine(opts.engine)
+	if err != nil {
+		return err
+	}
+	redaction, err := parseRedaction(opts.redaction)
+	if err != nil {
+		return err
+	}
+	format, err := parseAnalysisFormat(opts.format)
+	if err != nil {
+		return err
+	}
+	if format == report.FormatSARIF || format == report.FormatJUnit {
+		return fmt.Errorf("unsupported plan diff format %q: use text, json, or markdown", opts.format)
+	}
+	registry, err := c.registry.WithResourceCatalogs(opts.catalogs)
+	if err != nil {
+		return err
+	}
+	registry, err = withPolicyRules(registry, opts.rules)
+	if err != nil {
+		return err
+	}
+	registry, err = withRuleOptions(registry, opts.selection)
+	if err != nil {
+		return err
+	}
+
+	input := planInput{
+		stdin:           c.stdin,
+		maxBytes:        opts.maxPlanSize,
+		engine:          engine,
+		redaction:       redaction,
+		terraformBinary: opts.terraformBin,
+	}
+	inputs := make([]plandiff.Input, 0, 2)
+	for _, path := range []string{oldPath, newPath} {
+		changeSet, err := loadChangeSet(ctx, path, input)
+		if err != nil {
+			return fmt.Errorf("load plan %s: %w", path, err)
+		}
+		findings, err := registry.Analyze(ctx, changeSet)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, plandiff.Input{Path: path, ChangeSet: changeSet, Findings: findings})
+	}
+
+	diff, err := plandiff.Compare(inputs[0], inputs[1], version.Version)
+	if err != nil {
+		return err
+	}
+	rendered, err := plandiff.Render(diff, format)
+	if err != nil {
+		return err
+	}
+	if opts.output != "" {
+		if err := os.WriteFile(opts.output, rendered, 0o600); err != nil {
+			return fmt.Errorf("write plan diff: %w", err)
+		}
+	} else if _, err := c.stdout.Write(rendered); err != nil {
+		return fmt.Errorf("write plan diff: %w", err)
+	}
+
+	if opts.exitCode && diff.Diverged {
+		return &PlanDivergenceError{Summary: diff.Summary}
+	}
+	return nil
+}
+
+// PlanDivergenceError is returned after the diff has been rendered when the
+// compared plans differ, so CI can gate on an unchanged plan.
+type PlanDivergenceError struct {
+	Summary plandiff.Summary
+}
+
+func (e *PlanDivergenceError) Error() string {
+	return fmt.Sprintf(
+		"plans diverge: %d appeared, %d disappeared, %d changed resources; %d new, %d resolved findings",
+		e.Summary.Appeared, e.Summary.Disappeared, e.Summary.Changed, e.Summary.NewFindings, e.Summary.ResolvedFindings,
+	)
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yu/terraform-ops/internal/analysis"
+)
+
+func diffPlanJSON(action string) string {
+	return `{
+  "format_version":"1.0",
+  "applyable":true,
+  "complete":true,
+  "errored":false,
+  "resource_changes":[{
+    "address":"test_resource.example",
+    "mode":"managed",
+    "type":"test_resource",
+    "name":"example",
+    "change":{"actions":["` + action + `"]}
+  }],
+  "output_changes":{},
+  "configuration":{"root_module":{"resources":[],"module_calls":{},"outputs":{}}}
+}`
+}
+
+func TestDiffPlansCommandReturnsDivergenceAfterRendering(t *testing.T) {
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "old.json")
+	if err := os.WriteFile(oldPath, []byte(diffPlanJSON("update")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var stdout bytes.Buffer
+	cmd := NewDiffPlansCommand(analysis.DefaultRegistry(), strings.NewReader(diffPlanJSON("delete")), &stdout)
+	err := cmd.run(context.Background(), oldPath, "-", diffPlansOptions{
+		format:      "json",
+		engine:      "terraform",
+		redaction:   "standard",
+		exitCode:    true,
+		maxPlanSize: 1 << 20,
+	})
+	var divergence *PlanDivergenceError
+	if !errors.As(err, &divergence) {
+		t.Fatalf("expected divergence error, got %v", err)
+	}
+	if divergence.Summary.Changed != 1 || divergence.Summary.NewFindings != 1 {
+		t.Fatalf("unexpected summary: %#v", divergence.Summary)
+	}
+	if !strings.Contains(stdout.String(), `"new_action": "delete"`) {
+		t.Fatalf("diff was not rendered before returning: %s", stdout.String())
+	}
+}
+
+func TestDiffPlansCommandEquivalentPlans(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plan.json")
+	if err := os.WriteFile(path, []byte(diffPlanJSON("update")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var stdout bytes.Buffer
+	cmd := NewDiffPlansCommand(analysis.DefaultRegistry(), strings.NewReader(""), &stdout)
+	err := cmd.run(context.Background(), path, path, diffPlansOptions{
+		format:      "text",
+		engine:      "auto",
+		redaction:   "standard",
+		exitCode:    true,
+		maxPlanSize: 1 << 20,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), "Plans are equivalent.") {
+		t.Fatalf("unexpected output: %s", stdout.String())
+	}
+}
@@ -15,6 +15,8 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 
@@ -63,44 +65,72 @@ Examples:
   terraform-ops plan-graph --no-variables plan.json
   terraform-ops plan-graph --no-data-sources --no-outputs --no-variables plan.json
   terraform-ops plan-graph --no-modules plan.json
+  terraform-ops plan-graph --edge-labels plan.json
+  terraform-ops plan-graph --focus module.db.aws_db_instance.main --downstream plan.json
+  terraform-ops plan-graph --focus 'module.network.*' --depth 1 plan.json
+  terraform-ops plan-graph --collapse-modules=1 plan.json
   terraform-ops plan-graph --output graph.dot plan.json`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return c.runPlanGraph(args[0], opts)
+			return c.runPlanGraph(cmd.Context(), args[0], opts)
 		},
 	}
 
 	cmd.Flags().StringVarP((*string)(&opts.Format), "format", "f", string(core.FormatGraphviz), "Output format (graphviz, mermaid, plantuml)")
 	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Output file path (default: stdout)")
-	cmd.Flags().StringVarP((*string)(&opts.GroupBy), "group-by", "g", string(core.GroupByModule), "Grouping strategy (module, action, resource_type)")
+	cmd.Flags().StringVarP((*string)(&opts.GroupBy), "group-by", "g", string(core.GroupByModule), "Grouping strategy (module, action, resource_type, provider)")
 	cmd.Flags().BoolVar(&opts.NoDataSources, "no-data-sources", false, "Exclude data source resources from the graph")
 	cmd.Flags().BoolVar(&opts.NoOutputs, "no-outputs", false, "Exclude root output values from the graph")
 	cmd.Flags().BoolVar(&opts.NoVariables, "no-variables", false, "Exclude provided root variables from the graph")
 	cmd.Flags().BoolVar(&opts.NoLocals, "no-locals", false, "Compatibility flag; local declarations are not exposed by plan JSON")
 	cmd.Flags().BoolVar(&opts.NoModules, "no-modules", false, "Exclude resources from modules from the graph")
 	cmd.Flags().BoolVarP(&opts.Compact, "compact", "c", false, "Generate a more compact graph layout")
+	cmd.Flags().BoolVar(&opts.EdgeLabels, "edge-labels", false, "Label edges with their dependency evidence kinds")
+	cmd.Flags().StringArrayVar(&opts.Focus, "focus", nil, "Render only nodes matching this address glob and their neighborhood (repeatable)")
+	cmd.Flags().BoolVar(&opts.Upstream, "upstream", false, "With --focus, follow dependencies of the focus nodes")
+	cmd.Flags().BoolVar(&opts.Downstream, "downstream", false, "With --focus, follow dependents of the focus nodes")
+	cmd.Flags().IntVar(&opts.Depth, "depth", 0, "With --focus, maximum number of edges from a focus node (0 means unlimited)")
+	cmd.Flags().IntVar(&opts.CollapseModules, "collapse-modules", 0, "Collapse modules at this depth or deeper into one summary node per module (0 disables)")
 	cmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Enable verbose output for debugging")
+	cmd.Flags().Int64Var(&opts.MaxPlanBytes, "max-plan-bytes", terraformsource.DefaultMaxPlanBytes, "Maximum accepted plan JSON size in bytes")
+	cmd.Flags().StringVar(&opts.Engine, "engine", "auto", "Source engine (auto, terraform, opentofu)")
+	cmd.Flags().StringVar(&opts.TerraformBinary, "terraform-binary", "", "Terraform/OpenTofu executable used to read binary plan files (default terraform, or tofu with --engine opentofu)")
 
 	return cmd
 }
 
-func (c *PlanGraphCommand) runPlanGraph(planFile string, opts core.GraphOptions) error {
+func (c *PlanGraphCommand) runPlanGraph(ctx context.Context, planFile string, opts core.GraphOptions) error {
 	if !isValidFormat(opts.Format) {
 		return &core.UnsupportedFormatError{Format: string(opts.Format)}
 	}
 	if !isValidGrouping(opts.GroupBy) {
-		return fmt.Errorf("unsupported grouping: %s. Supported groupings: module, action, resource_type", opts.GroupBy)
+		return fmt.Errorf("unsupported grouping: %s. Supported groupings: module, action, resource_type, provider", opts.GroupBy)
+	}
+	if opts.Depth < 0 {
+		return fmt.Errorf("--depth must not be negative: %d", opts.Depth)
+	}
+	if opts.CollapseModules < 0 {
+		return fmt.Errorf("--collapse-modules must not be negative: %d", opts.CollapseModules)
+	}
+	if len(opts.Focus) == 0 && (opts.Upstream || opts.Downstream || opts.Depth > 0) {
+		return errors.New("--upstream, --downstream and --depth require --focus")
+	}
+
+	engine, err := parseEngine(opts.Engine)
+	if err != nil {
+		return err
 	}
 
 	if opts.Verbose {
 		fmt.Fprintf(os.Stderr, "Loading normalized plan: %s\n", planFile)
 	}
-	changeSet, err := terraformsource.LoadFile(
-		planFile,
-		terraformsource.DefaultMaxPlanBytes,
-		ir.EngineUnknown,
-		ir.RedactionStandard,
-	)
+	changeSet, err := loadChangeSet(ctx, planFile, planInput{
+		stdin:           os.Stdin,
+		maxBytes:        opts.MaxPlanBytes,
+		engine:          engine,
+		redaction:       ir.RedactionStandard,
+		terraformBinary: opts.TerraformBinary,
+	})
 	if err != nil {
 		// Keep the established error prefix used by scripts/integration tests.
 		return fmt.Errorf("failed to open plan file: %w", err)
@@ -150,7 +180,7 @@ func isValidFormat(format core.GraphFormat) bool {
 
 func isValidGrouping(grouping core.GroupingStrategy) bool {
 	switch grouping {
-	case core.GroupByModule, core.GroupByAction, core.GroupByResourceType:
+	case core.GroupByModule, core.GroupByAction, core.GroupByResourceType, core.GroupByProvider:
 		return true
 	default:
 		return false
@@ -0,0 +1,62 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yu/terraform-ops/internal/core"
+)
+
+func TestPlanGraphCommandFocusFlags(t *testing.T) {
+	command := DefaultPlanGraphCommand().Command()
+	for _, name := range []string{"focus", "upstream", "downstream", "depth", "edge-labels", "collapse-modules"} {
+		assert.NotNil(t, command.Flags().Lookup(name), name)
+	}
+}
+
+func TestPlanGraphRejectsTraversalWithoutFocus(t *testing.T) {
+	cmd := DefaultPlanGraphCommand()
+	base := core.GraphOptions{Format: core.FormatGraphviz, GroupBy: core.GroupByModule}
+
+	for name, opts := range map[string]core.GraphOptions{
+		"upstream":   withGraphOptions(base, func(o *core.GraphOptions) { o.Upstream = true }),
+		"downstream": withGraphOptions(base, func(o *core.GraphOptions) { o.Downstream = true }),
+		"depth":      withGraphOptions(base, func(o *core.GraphOptions) { o.Depth = 2 }),
+	} {
+		err := cmd.runPlanGraph(context.Background(), "plan.json", opts)
+		assert.EqualError(t, err, "--upstream, --downstream and --depth require --focus", name)
+	}
+
+	negative := withGraphOptions(base, func(o *core.GraphOptions) {
+		o.Focus = []string{"aws_instance.*"}
+		o.Depth = -1
+	})
+	assert.EqualError(t, cmd.runPlanGraph(context.Background(), "plan.json", negative), "--depth must not be negative: -1")
+}
+
+func TestPlanGraphRejectsNegativeCollapseModules(t *testing.T) {
+	opts := core.GraphOptions{Format: core.FormatGraphviz, GroupBy: core.GroupByModule, CollapseModules: -1}
+	err := DefaultPlanGraphCommand().runPlanGraph(context.Background(), "plan.json", opts)
+	assert.EqualError(t, err, "--collapse-modules must not be negative: -1")
+}
+
+func withGraphOptions(opts core.GraphOptions, apply func(*core.GraphOptions)) core.GraphOptions {
+	apply(&opts)
+	return opts
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/yu/terraform-ops/internal/ir"
+	terraformsource "github.com/yu/terraform-ops/internal/source/terraform"
+	"github.com/yu/terraform-ops/pkg/terraform"
+)
+
+// binaryPlanMagic prefixes saved binary plan files, which are zip archives.
+var binaryPlanMagic = []byte("PK\x03\x04")
+
+// planInput describes where a command reads its plan from and how the plan
+// crosses the sanitization boundary.
+type planInput struct {
+	stdin           io.Reader
+	maxBytes        int64
+	engine          ir.Engine
+	redaction       ir.RedactionMode
+	terraformBinary string
+	// workingDir is where binary plans are converted; empty means the
+	// current directory.
+	workingDir string
+}
+
+// loadChangeSet parses and normalizes the plan at planPath. "-" reads the plan
+// JSON document from stdin. A saved binary plan (for example tfplan from
+// terraform plan -out) is converted with "<binary> show -json" run from the
+// current directory, where the workspace is expected to be initialized.
+func loadChangeSet(ctx context.Context, planPath string, input planInput) (*ir.ChangeSet, error) {
+	var (
+		plan *terraformsource.Plan
+		err  error
+	)
+	if planPath == "-" {
+		plan, err = terraformsource.ParseReader(input.stdin, input.maxBytes)
+	} else {
+		plan, err = parsePlanFile(ctx, planPath, input)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return terraformsource.Normalize(plan, input.engine, input.redaction)
+}
+
+func parsePlanFile(ctx context.Context, planPath string, input planInput) (*terraformsource.Plan, error) {
+	binaryPlan, err := isBinaryPlan(planPath)
+	if err != nil {
+		return nil, err
+	}
+	if !binaryPlan {
+		return terraformsource.ParseFile(planPath, input.maxBytes)
+	}
+	if input.workingDir != "" {
+		// The show command runs in workingDir, so a relative path would no
+		// longer point at the plan.
+		if planPath, err = filepath.Abs(planPath); err != nil {
+			return nil, err
+		}
+	}
+	client := terraform.NewClient(terraformBinary(input.terraformBinary, input.engine), input.workingDir)
+	output, err := client.ShowJSON(ctx, planPath)
+	if err != nil {
+		return nil, err
+	}
+	plan, parseErr := terraformsource.ParseReader(output, input.maxBytes)
+	// A failed show run also surfaces as a JSON parse error; report the
+	// underlying failure instead.
+	if closeErr := output.Close(); closeErr != nil {
+		return nil, closeErr
+	}
+	return plan, parseErr
+}
+
+func isBinaryPlan(planPath string) (bool, error) {
+	f, err := os.Open(planPath)
+	if err != nil {
+		return false, fmt.Errorf("open plan JSON: %w", err)
+	}
+	defer f.Close()
+	header, err := bufio.NewReader(f).Peek(len(binaryPlanMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("read plan: %w", err)
+	}
+	return bytes.Equal(header, binaryPlanMagic), nil
+}
+
+// terraformBinary picks the executable used to read binary plans. An explicit
+// binary wins; otherwise OpenTofu plans use tofu and everything else terraform.
+func terraformBinary(configured string, engine ir.Engine) string {
+	if configured != "" {
+		return configured
+	}
+	if engine == ir.EngineOpenTofu {
+		return "tofu"
+	}
+	return "terraform"
+}
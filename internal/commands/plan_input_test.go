@@ -0,0 +1,194 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yu/terraform-ops/internal/analysis"
+	"github.com/yu/terraform-ops/internal/core"
+	"github.com/yu/terraform-ops/internal/ir"
+	terraformsource "github.com/yu/terraform-ops/internal/source/terraform"
+)
+
+const binaryPlanFixture = `{
+  "format_version":"1.2",
+  "terraform_version":"1.9.0",
+  "applyable":true,
+  "complete":true,
+  "errored":false,
+  "resource_changes":[{
+    "address":"test_resource.example",
+    "mode":"managed",
+    "type":"test_resource",
+    "name":"example",
+    "change":{"actions":["delete"]}
+  }],
+  "output_changes":{},
+  "configuration":{"root_module":{"resources":[],"module_calls":{},"outputs":{}}}
+}`
+
+// writeShowStub creates a binary plan file and a stub terraform executable
+// whose "show -json <plan>" prints the fixture plan JSON.
+func writeShowStub(t *testing.T) (planPath, stubPath string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("stub binaries are shell scripts")
+	}
+	dir := t.TempDir()
+	planPath = filepath.Join(dir, "tfplan")
+	if err := os.WriteFile(planPath, append([]byte("PK\x03\x04"), make([]byte, 32)...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fixturePath := filepath.Join(dir, "plan.json")
+	if err := os.WriteFile(fixturePath, []byte(binaryPlanFixture), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stubPath = filepath.Join(dir, "terraform")
+	script := "#!/bin/sh\n[ \"$1\" = show ] && [ \"$2\" = -json ] && [ \"$3\" = \"" + planPath + "\" ] || exit 2\ncat \"" + fixturePath + "\"\n"
+	if err := os.WriteFile(stubPath, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return planPath, stubPath
+}
+
+func TestLoadChangeSetConvertsBinaryPlan(t *testing.T) {
+	planPath, stubPath := writeShowStub(t)
+	changeSet, err := loadChangeSet(context.Background(), planPath, planInput{
+		maxBytes:        1 << 20,
+		engine:          ir.EngineTerraform,
+		redaction:       ir.RedactionStandard,
+		terraformBinary: stubPath,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changeSet.Resources) != 1 || changeSet.Resources[0].Address != "test_resource.example" {
+		t.Fatalf("unexpected resources: %#v", changeSet.Resources)
+	}
+}
+
+func TestLoadChangeSetBinaryPlanHonorsMaxBytes(t *testing.T) {
+	planPath, stubPath := writeShowStub(t)
+	_, err := loadChangeSet(context.Background(), planPath, planInput{
+		maxBytes:        64,
+		engine:          ir.EngineTerraform,
+		redaction:       ir.RedactionStandard,
+		terraformBinary: stubPath,
+	})
+	if !errors.Is(err, terraformsource.ErrPlanTooLarge) {
+		t.Fatalf("expected plan too large error, got %v", err)
+	}
+}
+
+func TestLoadChangeSetBinaryPlanReportsShowFailure(t *testing.T) {
+	planPath, _ := writeShowStub(t)
+	failing := filepath.Join(t.TempDir(), "terraform")
+	if err := os.WriteFile(failing, []byte("#!/bin/sh\necho 'Error: plan file was created by a different version' >&2\nexit 1\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	_, err := loadChangeSet(context.Background(), planPath, planInput{
+		engine:          ir.EngineTerraform,
+		redaction:       ir.RedactionStandard,
+		terraformBinary: failing,
+	})
+	if err == nil || !strings.Contains(err.Error(), "different version") {
+		t.Fatalf("expected show failure, got %v", err)
+	}
+}
+
+func TestAnalyzeCommandReadsBinaryPlan(t *testing.T) {
+	planPath, stubPath := writeShowStub(t)
+	var stdout bytes.Buffer
+	cmd := NewAnalyzeCommand(analysis.DefaultRegistry(), strings.NewReader(""), &stdout)
+	err := cmd.run(context.Background(), planPath, analyzeOptions{
+		format:       "text",
+		engine:       "terraform",
+		redaction:    "standard",
+		failOn:       "none",
+		terraformBin: stubPath,
+		maxPlanSize:  1 << 20,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), "test_resource.example") {
+		t.Fatalf("expected the converted plan to be analyzed:\n%s", stdout.String())
+	}
+}
+
+func TestTerraformBinaryDefaultsByEngine(t *testing.T) {
+	if got := terraformBinary("", ir.EngineOpenTofu); got != "tofu" {
+		t.Fatalf("got %q", got)
+	}
+	if got := terraformBinary("", ir.EngineUnknown); got != "terraform" {
+		t.Fatalf("got %q", got)
+	}
+	if got := terraformBinary("/opt/terraform", ir.EngineOpenTofu); got != "/opt/terraform" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestPlanCommandsShareBinaryPlanFlags(t *testing.T) {
+	for _, cmd := range []*cobra.Command{
+		DefaultAnalyzeCommand().Command(),
+		DefaultDiffPlansCommand().Command(),
+		DefaultPlanGraphCommand().Command(),
+		DefaultSummarizePlanCommand().Command(),
+	} {
+		if flag := cmd.Flags().Lookup("terraform-binary"); flag == nil || flag.DefValue != "" {
+			t.Errorf("%s: --terraform-binary must default to the engine's executable", cmd.Name())
+		}
+		if flag := cmd.Flags().Lookup("engine"); flag == nil || flag.DefValue != "auto" {
+			t.Errorf("%s: missing --engine", cmd.Name())
+		}
+	}
+}
+
+func TestSummarizePlanReadsBinaryPlanWithTofu(t *testing.T) {
+	planPath, stubPath := writeShowStub(t)
+	if err := os.Rename(stubPath, filepath.Join(filepath.Dir(stubPath), "tofu")); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", filepath.Dir(stubPath)+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	output := filepath.Join(t.TempDir(), "summary.txt")
+	err := DefaultSummarizePlanCommand().runSummarizePlan(context.Background(), planPath, core.SummaryOptions{
+		Format:       core.FormatText,
+		Output:       output,
+		GroupBy:      core.GroupByAction,
+		MaxPlanBytes: 1 << 20,
+		Engine:       "opentofu",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(summary), "test_resource.example") {
+		t.Fatalf("expected the plan converted by tofu to be summarized:\n%s", summary)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yu/terraform-ops/internal/publish"
+)
+
+// PublishCommand groups subcommands that deliver rendered reports to code
+// review systems.
+type PublishCommand struct {
+	httpClient *http.Client
+	getenv     func(string) string
+	stdin      io.Reader
+	stdout     io.Writer
+}
+
+type githubCommentOptions struct {
+	bodyFile string
+	repo     string
+	pr       int
+	marker   string
+	author   string
+	apiURL   string
+	tokenEnv string
+}
+
+func NewPublishCommand(httpClient *http.Client, getenv func(string) string, stdin io.Reader, stdout io.Writer) *PublishCommand {
+	return &PublishCommand{httpClient: httpClient, getenv: getenv, stdin: stdin, stdout: stdout}
+}
+
+func DefaultPublishCommand() *PublishCommand {
+	return NewPublishCommand(http.DefaultClient, os.Getenv, os.Stdin, os.Stdout)
+}
+
+func (c *PublishCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "publish",
+		Short: "Publish rendered reports to code review systems",
+	}
+	cmd.AddCommand(c.githubCommentCommand())
+	return cmd
+}
+
+func (c *PublishCommand) githubCommentCommand() *cobra.Command {
+	opts := githubCommentOptions{}
+	cmd := &cobra.Command{
+		Use:   "github-comment",
+		Short: "Create or update a sticky pull request comment on GitHub",
+		Long: `Publish a rendered markdown report, such as the output of analyze --format markdown or
+summarize-plan --format markdown, as a pull request comment.
+
+The comment is tagged with a hidden marker. When a comment with the same marker already
+exists it is edited in place, so every push updates one comment instead of adding another.
+Only comments written by --author are edited; by default that is the token's user, or
+github-actions[bot] for the GITHUB_TOKEN of a workflow run.
+Reports longer than GitHub's 65536 character limit are truncated at a line boundary and
+folded into a collapsible section.
+
+The token is read from the environment variable named by --token-env (GITHUB_TOKEN by
+default). Inside GitHub Actions the repository and pull request number default to
+GITHUB_REPOSITORY and the triggering pull_request event.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.runGitHubComment(cmd.Context(), opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.bodyFile, "body-file", "-", `Markdown file to publish, or "-" for stdin`)
+	cmd.Flags().StringVar(&opts.repo, "repo", "", "Repository as owner/name (default $GITHUB_REPOSITORY)")
+	cmd.Flags().IntVar(&opts.pr, "pr", 0, "Pull request number (default from $GITHUB_EVENT_PATH or $GITHUB_REF)")
+	cmd.Flags().StringVar(&opts.marker, "marker", publish.DefaultMarker, "Hidden marker that identifies the comment to update")
+	cmd.Flags().StringVar(&opts.author, "author", "", "Login of the comment author to update (default the token's user, or github-actions[bot] in GitHub Actions)")
+	cmd.Flags().StringVar(&opts.apiURL, "api-url", "", "GitHub REST API URL (default $GITHUB_API_URL or https://api.github.com)")
+	cmd.Flags().StringVar(&opts.tokenEnv, "token-env", "GITHUB_TOKEN", "Environment variable that holds the GitHub token")
+	return cmd
+}
+
+func (c *PublishCommand) runGitHubComment(ctx context.Context, opts githubCommentOptions) error {
+	token := c.getenv(opts.tokenEnv)
+	if token == "" {
+		return fmt.Errorf("no GitHub token: set %s", opts.tokenEnv)
+	}
+	repo := opts.repo
+	if repo == "" {
+		repo = c.getenv("GITHUB_REPOSITORY")
+	}
+	owner, name, err := publish.ParseRepository(repo)
+	if err != nil {
+		return err
+	}
+	number := opts.pr
+	if number == 0 {
+		number, err = c.pullRequestFromEnvironment()
+		if err != nil {
+			return err
+		}
+	}
+	apiURL := opts.apiURL
+	if apiURL == "" {
+		apiURL = c.getenv("GITHUB_API_URL")
+	}
+	body, err := c.readBody(opts.bodyFile)
+	if err != nil {
+		return err
+	}
+
+	client := publish.NewGitHubClient(apiURL, token, c.httpClient)
+	result, err := client.UpsertComment(ctx, publish.PullRequest{Owner: owner, Repo: name, Number: number}, opts.marker, opts.author, body)
+	if err != nil {
+		return err
+	}
+	verb := "Updated"
+	if result.Created {
+		verb = "Created"
+	}
+	_, err = fmt.Fprintf(c.stdout, "%s comment %s\n", verb, result.URL)
+	return err
+}
+
+func (c *PublishCommand) readBody(path string) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(c.stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("read comment body: %w", err)
+	}
+	return string(data), nil
+}
+
+// pullRequestFromEnvironment finds the pull request number of a GitHub
+// Actions run, first from the event payload and then from the merge ref.
+func (c *PublishCommand) pullRequestFromEnvironment() (int, error) {
+	if path := c.getenv("GITHUB_EVENT_PATH"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return 0, fmt.Errorf("read GitHub event: %w", err)
+		}
+		var event struct {
+			PullRequest *struct {
+				Number int `json:"number"`
+			} `json:"pull_request"`
+		}
+		if err := json.Unmarshal(data, &event); err != nil {
+			return 0, fmt.Errorf("parse GitHub event: %w", err)
+		}
+		if event.PullRequest != nil && event.PullRequest.Number > 0 {
+			return event.PullRequest.Number, nil
+		}
+	}
+	if number, ok := publish.PullRequestNumberFromRef(c.getenv("GITHUB_REF")); ok {
+		return number, nil
+	}
+	return 0, errors.New("no pull request number: pass --pr or run on a pull_request event")
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPublishGitHubCommentUsesActionsEnvironment(t *testing.T) {
+	var posted string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.URL.Path == "/user":
+			// The Actions GITHUB_TOKEN cannot read the authenticated user.
+			w.WriteHeader(http.StatusForbidden)
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`[{"id":9,"body":"<!-- terraform-ops -->\nold","user":{"login":"octocat"}}]`))
+		case r.Method == http.MethodPost:
+			if r.URL.Path != "/repos/acme/infra/issues/12/comments" {
+				t.Errorf("unexpected path %s", r.URL.Path)
+			}
+			var payload map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			posted = payload["body"]
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":1,"html_url":"https://github.test/acme/infra/pull/12#issuecomment-1"}`))
+		}
+	}))
+	defer server.Close()
+
+	eventPath := filepath.Join(t.TempDir(), "event.json")
+	if err := os.WriteFile(eventPath, []byte(`{"pull_request":{"number":12}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	env := map[string]string{
+		"GITHUB_TOKEN":      "secret-token",
+		"GITHUB_REPOSITORY": "acme/infra",
+		"GITHUB_EVENT_PATH": eventPath,
+		"GITHUB_API_URL":    server.URL,
+	}
+	var stdout bytes.Buffer
+	cmd := NewPublishCommand(server.Client(), func(key string) string { return env[key] }, strings.NewReader("## Analysis\n"), &stdout)
+	err := cmd.runGitHubComment(context.Background(), githubCommentOptions{bodyFile: "-", marker: "terraform-ops", tokenEnv: "GITHUB_TOKEN"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if posted != "<!-- terraform-ops -->\n## Analysis\n" {
+		t.Fatalf("posted body = %q", posted)
+	}
+	if !strings.Contains(stdout.String(), "Created comment https://github.test/acme/infra/pull/12#issuecomment-1") {
+		t.Fatalf("stdout = %q", stdout.String())
+	}
+}
+
+func TestPublishGitHubCommentRequiresToken(t *testing.T) {
+	cmd := NewPublishCommand(nil, func(string) string { return "" }, strings.NewReader(""), &bytes.Buffer{})
+	err := cmd.runGitHubComment(context.Background(), githubCommentOptions{bodyFile: "-", repo: "acme/infra", pr: 1, tokenEnv: "GITHUB_TOKEN"})
+	if err == nil || !strings.Contains(err.Error(), "GITHUB_TOKEN") {
+		t.Fatalf("err = %v, want missing token error", err)
+	}
+}
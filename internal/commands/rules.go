@@ -0,0 +1,156 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/yu/terraform-ops/internal/analysis"
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+// RulesCommand groups subcommands that describe the analysis rules.
+type RulesCommand struct {
+	registry *analysis.Registry
+	stdout   io.Writer
+}
+
+type rulesListOptions struct {
+	format string
+	rules  []string
+}
+
+func NewRulesCommand(registry *analysis.Registry, stdout io.Writer) *RulesCommand {
+	return &RulesCommand{registry: registry, stdout: stdout}
+}
+
+func DefaultRulesCommand() *RulesCommand {
+	return NewRulesCommand(analysis.DefaultRegistry(), os.Stdout)
+}
+
+func (c *RulesCommand) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rules",
+		Short: "Describe the rules used by analyze and diff-plans",
+	}
+	cmd.AddCommand(c.listCommand())
+	return cmd
+}
+
+func (c *RulesCommand) listCommand() *cobra.Command {
+	opts := rulesListOptions{}
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List every rule ID with its default severity, category and description",
+		Long: `List the built-in analysis rules, and the custom rules of any --rules files, with the
+severity their findings get unless --rule-severity overrides it. The IDs are the values
+accepted by --rule-severity, --disable-rule and --enable-only.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.runList(opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.format, "format", "f", string(report.FormatText), "Output format (text, json)")
+	cmd.Flags().StringArrayVar(&opts.rules, "rules", nil, "Include custom policy rules from a YAML or HCL file (repeatable)")
+	return cmd
+}
+
+func (c *RulesCommand) runList(opts rulesListOptions) error {
+	registry, err := withPolicyRules(c.registry, opts.rules)
+	if err != nil {
+		return err
+	}
+	rules := registry.Rules()
+	switch report.Format(strings.ToLower(opts.format)) {
+	case report.FormatJSON:
+		enc := json.NewEncoder(c.stdout)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			Rules []analysis.Rule `json:"rules"`
+		}{Rules: rules})
+	case report.FormatText:
+		w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tSEVERITY\tCATEGORY\tDESCRIPTION")
+		for _, rule := range rules {
+			description := rule.Description
+			if description == "" {
+				description = rule.Title
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", rule.ID, rule.Severity, rule.Category, description)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unsupported rules format %q: use text or json", opts.format)
+	}
+}
+
+// ruleOptions are the rule selection flags shared by analyze and diff-plans.
+// configured holds the rule_severity settings of the configuration files,
+// which --rule-severity overrides rule by rule.
+type ruleOptions struct {
+	configured map[string]report.Severity
+	severities []string
+	disabled   []string
+	enableOnly []string
+}
+
+func (o *ruleOptions) addFlags(flags *pflag.FlagSet) {
+	flags.StringArrayVar(&o.severities, "rule-severity", nil, "Report a rule's findings at another severity, as RULE_ID=SEVERITY (repeatable)")
+	flags.StringArrayVar(&o.disabled, "disable-rule", nil, "Do not report findings of this rule ID or glob (repeatable)")
+	flags.StringArrayVar(&o.enableOnly, "enable-only", nil, "Report only findings of these rule IDs or globs (repeatable)")
+}
+
+func (o ruleOptions) settings() (analysis.RuleSettings, error) {
+	severities := make(map[string]report.Severity, len(o.configured)+len(o.severities))
+	for rule, severity := range o.configured {
+		severities[rule] = severity
+	}
+	for _, value := range o.severities {
+		rule, level, ok := strings.Cut(value, "=")
+		rule = strings.TrimSpace(rule)
+		if !ok || rule == "" {
+			return analysis.RuleSettings{}, fmt.Errorf("invalid --rule-severity %q: use RULE_ID=SEVERITY", value)
+		}
+		severity, err := report.ParseSeverity(level)
+		if err != nil {
+			return analysis.RuleSettings{}, fmt.Errorf("invalid --rule-severity %q: %w", value, err)
+		}
+		severities[rule] = severity
+	}
+	return analysis.RuleSettings{
+		Severities: severities,
+		Disabled:   o.disabled,
+		EnableOnly: o.enableOnly,
+	}, nil
+}
+
+// withRuleOptions applies the rule selection to registry, which must already
+// include any custom policy rules so their IDs are accepted.
+func withRuleOptions(registry *analysis.Registry, opts ruleOptions) (*analysis.Registry, error) {
+	settings, err := opts.settings()
+	if err != nil {
+		return nil, err
+	}
+	return registry.WithRuleSettings(settings)
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yu/terraform-ops/internal/analysis"
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+func TestRulesListText(t *testing.T) {
+	var stdout bytes.Buffer
+	if err := NewRulesCommand(analysis.DefaultRegistry(), &stdout).runList(rulesListOptions{format: "text"}); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if !strings.HasPrefix(lines[0], "ID") || len(lines) != 10 {
+		t.Fatalf("unexpected listing:\n%s", stdout.String())
+	}
+	if !strings.Contains(stdout.String(), "TFOPS-DRIFT-DETECTED") || !strings.Contains(stdout.String(), "medium") {
+		t.Fatalf("listing misses drift rule:\n%s", stdout.String())
+	}
+}
+
+func TestRulesListJSONIncludesCustomRules(t *testing.T) {
+	rulesPath := filepath.Join(t.TempDir(), "policy.yaml")
+	policy := `rules:
+  - id: ACME-NO-DELETE
+    title: No deletes
+    severity: high
+    message: Deletes need approval.
+    match:
+      actions: [delete]
+`
+	if err := os.WriteFile(rulesPath, []byte(policy), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var stdout bytes.Buffer
+	err := NewRulesCommand(analysis.DefaultRegistry(), &stdout).runList(rulesListOptions{format: "json", rules: []string{rulesPath}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var listing struct {
+		Rules []analysis.Rule `json:"rules"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &listing); err != nil {
+		t.Fatal(err)
+	}
+	if listing.Rules[0].ID != "ACME-NO-DELETE" || listing.Rules[0].Source != rulesPath {
+		t.Fatalf("custom rule missing: %#v", listing.Rules[0])
+	}
+}
+
+func TestRuleOptionsLetFlagsOverrideConfiguredSeverities(t *testing.T) {
+	opts := ruleOptions{
+		configured: map[string]report.Severity{"TFOPS-DRIFT-DETECTED": report.SeverityLow, "TFOPS-UNKNOWN-AFTER": report.SeverityLow},
+		severities: []string{"TFOPS-DRIFT-DETECTED=high"},
+	}
+	settings, err := opts.settings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.Severities["TFOPS-DRIFT-DETECTED"] != report.SeverityHigh || settings.Severities["TFOPS-UNKNOWN-AFTER"] != report.SeverityLow {
+		t.Fatalf("unexpected severities: %v", settings.Severities)
+	}
+	for _, value := range []string{"TFOPS-DRIFT-DETECTED", "=high", "TFOPS-DRIFT-DETECTED=urgent"} {
+		if _, err := (ruleOptions{severities: []string{value}}).settings(); err == nil {
+			t.Errorf("%q: expected an error", value)
+		}
+	}
+}
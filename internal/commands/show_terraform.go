@@ -18,8 +18,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/yu/terraform-ops/internal/core"
 	"github.com/yu/terraform-ops/internal/terraform/config"
@@ -27,13 +33,31 @@ import (
 
 // ShowTerraformCommand represents the show-terraform command with dependency injection
 type ShowTerraformCommand struct {
-	configParser core.ConfigParser
+	configParser    core.ConfigParser
+	workspaceFinder core.WorkspaceFinder
+}
+
+// showTerraformOptions holds the flags of show-terraform
+type showTerraformOptions struct {
+	format        string
+	strict        bool
+	backendConfig []string
+	search        workspaceSearchOptions
+}
+
+// workspaceSearchOptions holds the workspace discovery flags shared by the
+// commands that read Terraform configuration
+type workspaceSearchOptions struct {
+	recursive bool
+	include   []string
+	exclude   []string
 }
 
 // NewShowTerraformCommand creates a new show-terraform command with injected dependencies
-func NewShowTerraformCommand(configParser core.ConfigParser) *ShowTerraformCommand {
+func NewShowTerraformCommand(configParser core.ConfigParser, workspaceFinder core.WorkspaceFinder) *ShowTerraformCommand {
 	return &ShowTerraformCommand{
-		configParser: configParser,
+		configParser:    configParser,
+		workspaceFinder: workspaceFinder,
 	}
 }
 
@@ -41,56 +65,148 @@ func NewShowTerraformCommand(configParser core.ConfigParser) *ShowTerraformComma
 type LegacyOutput struct {
 	Path      string `json:"path"`
 	Terraform struct {
-		RequiredVersion   string            `json:"required_version"`
-		Backend           *LegacyBackend    `json:"backend,omitempty"`
-		RequiredProviders map[string]string `json:"required_providers"`
+		RequiredVersion   string                              `json:"required_version"`
+		Backend           *LegacyBackend                      `json:"backend,omitempty"`
+		RequiredProviders map[string]string                   `json:"required_providers"`
+		Providers         map[string]core.ProviderRequirement `json:"providers"`
 	} `json:"terraform"`
+	LockFile       *core.LockFile      `json:"lock_file,omitempty"`
+	LockMismatches []core.LockMismatch `json:"lock_mismatches,omitempty"`
+	Modules        []core.ModuleCall   `json:"modules"`
+	Diagnostics    []core.Diagnostic   `json:"diagnostics"`
 }
 
 // LegacyBackend represents the backend structure for backward compatibility
 type LegacyBackend struct {
-	Type   string                 `json:"type"`
-	Config map[string]interface{} `json:"config"`
+	Type          string                 `json:"type"`
+	Config        map[string]interface{} `json:"config"`
+	SensitiveKeys []string               `json:"sensitive_keys,omitempty"`
 }
 
 // Command returns the cobra command for show-terraform
 func (c *ShowTerraformCommand) Command() *cobra.Command {
+	opts := showTerraformOptions{}
 	cmd := &cobra.Command{
 		Use:   "show-terraform <path...>",
 		Short: "Displays information from the terraform block in workspaces",
-		Long:  `The show-terraform command inspects Terraform configuration files (*.tf) in the specified paths and outputs information contained in the terraform block (required_version, backend, and required_providers) in JSON format. It does not recurse into subdirectories.`,
-		Args:  cobra.MinimumNArgs(1),
+		Long: `The show-terraform command inspects Terraform configuration files (*.tf) in the specified paths and outputs information contained in the terraform block (required_version, backend, and required_providers) in JSON format.
+
+Provider entries include their source address and configuration_aliases. When a workspace has a .terraform.lock.hcl file, the locked provider versions and hashes are reported together with lock_mismatches: required providers missing from the lock file, or locked to a version outside the declared constraint.
+
+Module calls are listed with their source, source type (local, registry, git, mercurial, s3, gcs, http), registry version or git ref, and whether they use count or for_each. --format table prints this module inventory as one row per module call.
+
+Backend settings include lists, maps and nested blocks such as assume_role. --backend-config accepts a file of backend settings or a key=value pair, as terraform init -backend-config does, and overrides the backend block key by key. Settings whose names look like credentials (access_key, secret_key, token, password, ...) are listed in sensitive_keys and their values are printed as <redacted>.
+
+Files or blocks that cannot be parsed are skipped and reported under diagnostics with their file and line range (on stderr with --format table). With --strict any diagnostic makes the command exit non-zero after the output is written.
+
+By default only the .tf files directly in each path are read. With --recursive each path is searched for root modules instead: directories that declare a backend, a cloud block, or a provider. Hidden directories such as .terraform/ and directories used as a local module source are skipped. --include and --exclude filter the workspaces by their path relative to the searched directory. A file with a syntax error is classified from the blocks that could be read. Parse errors found during the search go under the diagnostics of the workspace that contains the file, or to stderr when no workspace contains it, and count toward --strict.`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return c.runShowTerraform(args)
+			paths, discovered, err := opts.search.resolvePaths(c.workspaceFinder, args)
+			if err != nil {
+				return err
+			}
+			return c.runShowTerraform(paths, discovered, opts)
 		},
 	}
+	cmd.Flags().StringVarP(&opts.format, "format", "f", "json", "Output format (json, table)")
+	cmd.Flags().BoolVar(&opts.strict, "strict", false, "Exit non-zero when any file or block could not be parsed")
+	cmd.Flags().StringArrayVar(&opts.backendConfig, "backend-config", nil, "Override backend settings with an HCL file or key=value, like terraform init -backend-config (repeatable)")
+	opts.search.addFlags(cmd.Flags())
 
 	return cmd
 }
 
-// runShowTerraform executes the show-terraform command
-func (c *ShowTerraformCommand) runShowTerraform(paths []string) error {
+// addFlags registers --recursive, --include and --exclude
+func (o *workspaceSearchOptions) addFlags(flags *pflag.FlagSet) {
+	flags.BoolVarP(&o.recursive, "recursive", "r", false, "Discover root modules below each path instead of reading the paths themselves")
+	flags.StringArrayVar(&o.include, "include", nil, "With --recursive, only use workspaces whose relative path matches this glob (repeatable)")
+	flags.StringArrayVar(&o.exclude, "exclude", nil, "With --recursive, skip workspaces whose relative path matches this glob (repeatable)")
+}
+
+// resolvePaths returns the workspace directories to inspect: the arguments
+// themselves, or with --recursive the root modules discovered below them
+// together with the diagnostics of files that failed to parse on the way.
+func (o workspaceSearchOptions) resolvePaths(finder core.WorkspaceFinder, args []string) ([]string, []core.Diagnostic, error) {
+	if !o.recursive {
+		if len(o.include) > 0 || len(o.exclude) > 0 {
+			return nil, nil, fmt.Errorf("--include and --exclude require --recursive")
+		}
+		return args, nil, nil
+	}
+	search := core.WorkspaceSearchOptions{Include: o.include, Exclude: o.exclude}
+	seen := make(map[string]struct{})
+	paths := []string{}
+	var diagnostics []core.Diagnostic
+	for _, root := range args {
+		workspaces, diags, err := finder.FindWorkspaces(root, search)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to discover workspaces: %w", err)
+		}
+		diagnostics = append(diagnostics, diags...)
+		for _, workspace := range workspaces {
+			if _, ok := seen[workspace]; ok {
+				continue
+			}
+			seen[workspace] = struct{}{}
+			paths = append(paths, workspace)
+		}
+	}
+	return paths, diagnostics, nil
+}
+
+// runShowTerraform executes the show-terraform command. discovered holds the
+// diagnostics met while searching for workspaces.
+func (c *ShowTerraformCommand) runShowTerraform(paths []string, discovered []core.Diagnostic, opts showTerraformOptions) error {
+	if opts.format != "json" && opts.format != "table" {
+		return fmt.Errorf("unsupported show-terraform format %q: use json or table", opts.format)
+	}
+	overrides, err := config.ParseBackendConfig(opts.backendConfig)
+	if err != nil {
+		return err
+	}
 	allInfo, err := c.configParser.ParseConfigFiles(paths)
 	if err != nil {
 		return fmt.Errorf("failed to parse config files: %w", err)
 	}
+	for i := range allInfo {
+		config.MergeBackendConfig(allInfo[i].Backend, overrides)
+	}
+	unattached := attachDiagnostics(allInfo, discovered)
+	writeDiagnosticList(os.Stderr, unattached)
+	if opts.format == "table" {
+		writeDiagnostics(os.Stderr, allInfo)
+		if err := writeModuleTable(os.Stdout, allInfo); err != nil {
+			return err
+		}
+		return strictDiagnostics(opts.strict, allInfo, unattached)
+	}
 
 	// Transform to legacy format for backward compatibility
-	var legacyOutputs []LegacyOutput
+	legacyOutputs := make([]LegacyOutput, 0, len(allInfo))
 	for _, info := range allInfo {
 		legacy := LegacyOutput{
 			Path: info.Path,
 		}
 		legacy.Terraform.RequiredVersion = info.RequiredVersion
 		legacy.Terraform.RequiredProviders = info.RequiredProviders
+		legacy.Terraform.Providers = info.Providers
+		legacy.LockFile = info.LockFile
+		legacy.LockMismatches = info.LockMismatches
+		legacy.Modules = info.Modules
+		if legacy.Modules == nil {
+			legacy.Modules = []core.ModuleCall{}
+		}
+		legacy.Diagnostics = info.Diagnostics
+		if legacy.Diagnostics == nil {
+			legacy.Diagnostics = []core.Diagnostic{}
+		}
 
 		if info.Backend != nil {
 			legacy.Terraform.Backend = &LegacyBackend{
-				Type:   info.Backend.Type,
-				Config: make(map[string]interface{}),
-			}
-			for k, v := range info.Backend.Config {
-				legacy.Terraform.Backend.Config[k] = v
+				Type:          info.Backend.Type,
+				Config:        config.RedactBackendConfig(info.Backend),
+				SensitiveKeys: info.Backend.SensitiveKeys,
 			}
 		}
 
@@ -106,10 +222,117 @@ func (c *ShowTerraformCommand) runShowTerraform(paths []string) error {
 	}
 
 	fmt.Println(out.String())
-	return nil
+	return strictDiagnostics(opts.strict, allInfo, unattached)
+}
+
+// attachDiagnostics adds each discovery diagnostic to the innermost workspace
+// containing its file, such as a broken file in a nested module directory. It
+// returns the diagnostics of files outside every workspace.
+func attachDiagnostics(configs []core.TerraformConfig, diagnostics []core.Diagnostic) []core.Diagnostic {
+	var unattached []core.Diagnostic
+	for _, diag := range diagnostics {
+		owner := -1
+		for i, info := range configs {
+			rel, err := filepath.Rel(info.Path, diag.File)
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				continue
+			}
+			if owner < 0 || len(info.Path) > len(configs[owner].Path) {
+				owner = i
+			}
+		}
+		if owner < 0 {
+			unattached = append(unattached, diag)
+			continue
+		}
+		configs[owner].Diagnostics = append(configs[owner].Diagnostics, diag)
+	}
+	return unattached
+}
+
+// writeDiagnostics prints configuration diagnostics one per line for output
+// formats that have no place for them.
+func writeDiagnostics(w io.Writer, configs []core.TerraformConfig) {
+	for _, info := range configs {
+		writeDiagnosticList(w, info.Diagnostics)
+	}
+}
+
+// writeDiagnosticList prints diagnostics one per line.
+func writeDiagnosticList(w io.Writer, diagnostics []core.Diagnostic) {
+	for _, diag := range diagnostics {
+		fmt.Fprintln(w, diag.String())
+	}
+}
+
+// strictDiagnostics returns a ConfigDiagnosticsError when strict is set and
+// any workspace, or the workspace search, has diagnostics.
+func strictDiagnostics(strict bool, configs []core.TerraformConfig, discovered []core.Diagnostic) error {
+	if !strict {
+		return nil
+	}
+	diagErr := &ConfigDiagnosticsError{}
+	diagnostics := append([]core.Diagnostic(nil), discovered...)
+	for _, info := range configs {
+		diagnostics = append(diagnostics, info.Diagnostics...)
+	}
+	for _, diag := range diagnostics {
+		diagErr.Diagnostics++
+		if diag.Severity == core.DiagnosticError {
+			diagErr.Errors++
+		}
+	}
+	if diagErr.Diagnostics == 0 {
+		return nil
+	}
+	return diagErr
+}
+
+// ConfigDiagnosticsError is returned by --strict after the output has been
+// written when some configuration could not be parsed.
+type ConfigDiagnosticsError struct {
+	Diagnostics int
+	Errors      int
+}
+
+func (e *ConfigDiagnosticsError) Error() string {
+	return fmt.Sprintf("configuration has %d diagnostics (%d errors)", e.Diagnostics, e.Errors)
+}
+
+// writeModuleTable prints the module inventory, one row per module call, so
+// workspaces pinned to a given module version can be found with grep.
+func writeModuleTable(out io.Writer, configs []core.TerraformConfig) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "WORKSPACE\tMODULE\tTYPE\tSOURCE\tVERSION\tREPEAT")
+	for _, info := range configs {
+		for _, module := range info.Modules {
+			version := module.Version
+			if version == "" {
+				version = module.Ref
+			}
+			repeat := ""
+			switch {
+			case module.Count:
+				repeat = "count"
+			case module.ForEach:
+				repeat = "for_each"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", info.Path, module.Name, module.SourceType,
+				orDash(module.Source), orDash(version), orDash(repeat))
+		}
+	}
+	return w.Flush()
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
 }
 
 // DefaultShowTerraformCommand creates a show-terraform command with default dependencies
 func DefaultShowTerraformCommand() *ShowTerraformCommand {
-	return NewShowTerraformCommand(config.NewParser())
+	parser := config.NewParser()
+	return NewShowTerraformCommand(parser, parser)
 }
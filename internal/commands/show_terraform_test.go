@@ -0,0 +1,131 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yu/terraform-ops/internal/core"
+	"github.com/yu/terraform-ops/internal/terraform/config"
+)
+
+func TestWriteModuleTable(t *testing.T) {
+	configs := []core.TerraformConfig{
+		{Path: "/repo/prod", Modules: []core.ModuleCall{
+			{Name: "network", Source: "git::https://example.com/network.git?ref=v1.0.0", SourceType: core.ModuleSourceGit, Ref: "v1.0.0", ForEach: true},
+			{Name: "vpc", Source: "terraform-aws-modules/vpc/aws", SourceType: core.ModuleSourceRegistry, Version: "~> 5.1"},
+		}},
+		{Path: "/repo/empty"},
+	}
+	var out bytes.Buffer
+	if err := writeModuleTable(&out, configs); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and two rows, got:\n%s", out.String())
+	}
+	if fields := strings.Fields(lines[1]); strings.Join(fields, " ") != "/repo/prod network git git::https://example.com/network.git?ref=v1.0.0 v1.0.0 for_each" {
+		t.Fatalf("unexpected git row: %q", lines[1])
+	}
+	if fields := strings.Fields(lines[2]); strings.Join(fields, " ") != "/repo/prod vpc registry terraform-aws-modules/vpc/aws ~> 5.1 -" {
+		t.Fatalf("unexpected registry row: %q", lines[2])
+	}
+}
+
+func TestShowTerraformRejectsUnknownFormat(t *testing.T) {
+	parser := config.NewParser()
+	err := NewShowTerraformCommand(parser, parser).runShowTerraform([]string{t.TempDir()}, nil, showTerraformOptions{format: "yaml"})
+	if err == nil || !strings.Contains(err.Error(), "unsupported show-terraform format") {
+		t.Fatalf("expected format error, got %v", err)
+	}
+}
+
+func TestShowTerraformStrictFailsOnDiagnostics(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte("terraform {\n  this is not valid\n}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	parser := config.NewParser()
+	cmd := NewShowTerraformCommand(parser, parser)
+
+	if err := cmd.runShowTerraform([]string{dir}, nil, showTerraformOptions{format: "json"}); err != nil {
+		t.Fatalf("diagnostics should not fail without --strict: %v", err)
+	}
+	err := cmd.runShowTerraform([]string{dir}, nil, showTerraformOptions{format: "json", strict: true})
+	var diagErr *ConfigDiagnosticsError
+	if !errors.As(err, &diagErr) {
+		t.Fatalf("expected diagnostics error, got %v", err)
+	}
+	if diagErr.Errors == 0 || diagErr.Diagnostics < diagErr.Errors {
+		t.Fatalf("unexpected counts: %#v", diagErr)
+	}
+}
+
+func TestShowTerraformRecursiveReportsDiscoveryDiagnostics(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"envs/prod/providers.tf":        "provider \"aws\" {}\n\nlocals {\n  name = (\n}\n",
+		"envs/prod/modules/net/main.tf": "variable \"cidr\" {\n",
+		"scripts/broken.tf":             "locals {\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	parser := config.NewParser()
+	paths, discovered, err := workspaceSearchOptions{recursive: true}.resolvePaths(parser, []string{root})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prod := filepath.Join(root, "envs", "prod")
+	if len(paths) != 1 || paths[0] != prod {
+		t.Fatalf("the workspace with a broken provider file was dropped: %v", paths)
+	}
+
+	configs, err := parser.ParseConfigFiles(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unattached := attachDiagnostics(configs, discovered)
+	files = map[string]string{}
+	for _, diag := range configs[0].Diagnostics {
+		files[diag.File] = string(diag.Severity)
+	}
+	for _, want := range []string{filepath.Join(prod, "providers.tf"), filepath.Join(prod, "modules", "net", "main.tf")} {
+		if files[want] != string(core.DiagnosticError) {
+			t.Fatalf("missing error for %s in %v", want, configs[0].Diagnostics)
+		}
+	}
+	if len(unattached) == 0 || unattached[0].File != filepath.Join(root, "scripts", "broken.tf") {
+		t.Fatalf("diagnostics outside every workspace: %v", unattached)
+	}
+
+	err = NewShowTerraformCommand(parser, parser).runShowTerraform(paths, discovered, showTerraformOptions{format: "table", strict: true})
+	var diagErr *ConfigDiagnosticsError
+	if !errors.As(err, &diagErr) || diagErr.Errors < 3 {
+		t.Fatalf("expected discovery diagnostics to fail --strict, got %v", err)
+	}
+}
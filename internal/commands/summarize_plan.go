@@ -15,6 +15,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -71,7 +72,7 @@ Examples:
   terraform-ops summarize-plan --group-by provider plan.json`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return c.runSummarizePlan(args[0], opts)
+			return c.runSummarizePlan(cmd.Context(), args[0], opts)
 		},
 	}
 
@@ -83,11 +84,14 @@ Examples:
 	cmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Enable verbose output for debugging")
 	cmd.Flags().BoolVar(&opts.ShowDetails, "show-details", false, "Show detailed change information")
 	cmd.Flags().StringVarP((*string)(&opts.Color), "color", "", string(core.ColorAuto), "Color output mode (auto, always, never)")
+	cmd.Flags().Int64Var(&opts.MaxPlanBytes, "max-plan-bytes", terraformsource.DefaultMaxPlanBytes, "Maximum accepted plan JSON size in bytes")
+	cmd.Flags().StringVar(&opts.Engine, "engine", "auto", "Source engine (auto, terraform, opentofu)")
+	cmd.Flags().StringVar(&opts.TerraformBinary, "terraform-binary", "", "Terraform/OpenTofu executable used to read binary plan files (default terraform, or tofu with --engine opentofu)")
 
 	return cmd
 }
 
-func (c *SummarizePlanCommand) runSummarizePlan(planFile string, opts core.SummaryOptions) error {
+func (c *SummarizePlanCommand) runSummarizePlan(ctx context.Context, planFile string, opts core.SummaryOptions) error {
 	if !isValidSummaryFormat(opts.Format) {
 		return fmt.Errorf("unsupported format: %s. Supported formats: text, json, markdown, table, plan", opts.Format)
 	}
@@ -95,15 +99,21 @@ func (c *SummarizePlanCommand) runSummarizePlan(planFile string, opts core.Summa
 		return fmt.Errorf("unsupported grouping: %s. Supported groupings: action, module, provider, resource_type", opts.GroupBy)
 	}
 
+	engine, err := parseEngine(opts.Engine)
+	if err != nil {
+		return err
+	}
+
 	if opts.Verbose {
 		fmt.Fprintf(os.Stderr, "Loading normalized plan: %s\n", planFile)
 	}
-	changeSet, err := terraformsource.LoadFile(
-		planFile,
-		terraformsource.DefaultMaxPlanBytes,
-		ir.EngineUnknown,
-		ir.RedactionStandard,
-	)
+	changeSet, err := loadChangeSet(ctx, planFile, planInput{
+		stdin:           os.Stdin,
+		maxBytes:        opts.MaxPlanBytes,
+		engine:          engine,
+		redaction:       ir.RedactionStandard,
+		terraformBinary: opts.TerraformBinary,
+	})
 	if err != nil {
 		// Preserve the established CLI error prefix while the implementation now
 		// parses and normalizes through the shared source adapter.
@@ -15,8 +15,28 @@
 package config
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ProjectFileName is the configuration file looked up from the working
+	// directory up to the repository root.
+	ProjectFileName = ".terraform-ops.yaml"
+	// EnvPrefix prefixes environment variables that set flag defaults.
+	EnvPrefix = "TFOPS_"
+	// EnvConfigPath names a project configuration file to use instead of the
+	// one found by walking up from the working directory.
+	EnvConfigPath = EnvPrefix + "CONFIG"
 )
 
 // Config holds the application configuration
@@ -25,6 +45,18 @@ type Config struct {
 	ConfigDir    string `yaml:"config_dir"`
 	LogLevel     string `yaml:"log_level"`
 	TerraformBin string `yaml:"terraform_bin"`
+	// RuleSeverity overrides the severity of findings by rule ID.
+	RuleSeverity map[string]string `yaml:"rule_severity"`
+	// Suppressions lists baseline files applied by analyze. Relative paths
+	// are resolved against the directory of the file that declares them.
+	Suppressions []string `yaml:"suppressions"`
+	// Commands holds flag defaults keyed by command path below the root
+	// command (for example "analyze" or "publish github-comment") and then by
+	// flag name. A value is a scalar or, for repeatable flags, a list.
+	Commands map[string]map[string]any `yaml:"commands"`
+
+	// Path is the file the configuration was read from.
+	Path string `yaml:"-"`
 }
 
 // DefaultConfig returns a default configuration
@@ -38,8 +70,249 @@ func DefaultConfig() *Config {
 	}
 }
 
-// LoadConfig loads configuration from file
+// LoadConfig loads configuration from file. Unknown keys are rejected so a
+// misspelled setting fails loudly instead of being ignored.
 func LoadConfig(configPath string) (*Config, error) {
-	// TODO: Implement config file loading (YAML/JSON)
-	return DefaultConfig(), nil
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("read config %s: %w", configPath, err)
+	}
+	cfg := &Config{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parse config %s: %w", configPath, err)
+	}
+	cfg.Path = configPath
+	for i, path := range cfg.Suppressions {
+		if path != "" && !filepath.IsAbs(path) {
+			cfg.Suppressions[i] = filepath.Join(filepath.Dir(configPath), path)
+		}
+	}
+	return cfg, nil
+}
+
+// topLevelFlags maps top-level settings onto the flag they provide a default
+// for in every command that has it.
+var topLevelFlags = map[string]func(*Config) ([]string, bool){
+	"terraform-binary": func(c *Config) ([]string, bool) {
+		return []string{c.TerraformBin}, c.TerraformBin != ""
+	},
+	"baseline": func(c *Config) ([]string, bool) {
+		return c.Suppressions, len(c.Suppressions) > 0
+	},
+	"verbose": func(c *Config) ([]string, bool) {
+		return []string{"true"}, c.Verbose
+	},
+}
+
+// Layers are the configuration files that apply to a run, highest precedence
+// first: the project file, then the user file.
+type Layers []*Config
+
+// Load reads the project configuration found from workDir, or named by
+// TFOPS_CONFIG, and the user configuration under $XDG_CONFIG_HOME. Missing
+// files are skipped.
+func Load(workDir string, getenv func(string) string) (Layers, error) {
+	var layers Layers
+	projectPath := getenv(EnvConfigPath)
+	if projectPath == "" {
+		projectPath = FindProjectConfig(workDir)
+	}
+	if projectPath != "" {
+		cfg, err := LoadConfig(projectPath)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, cfg)
+	}
+	if userPath := UserConfigPath(getenv); userPath != "" {
+		cfg, err := LoadConfig(userPath)
+		switch {
+		case err == nil:
+			layers = append(layers, cfg)
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, err
+		}
+	}
+	return layers, nil
+}
+
+// FindProjectConfig returns the nearest .terraform-ops.yaml in dir or one of
+// its parents, stopping at the repository root (the directory that holds
+// .git). It returns "" when there is none.
+func FindProjectConfig(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		candidate := filepath.Join(dir, ProjectFileName)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// UserConfigPath returns $XDG_CONFIG_HOME/terraform-ops/config.yaml, falling
+// back to ~/.config when XDG_CONFIG_HOME is unset.
+func UserConfigPath(getenv func(string) string) string {
+	base := getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home := getenv("HOME")
+		if home == "" {
+			return ""
+		}
+		base = filepath.Join(home, ".config")
+	}
+	return filepath.Join(base, "terraform-ops", "config.yaml")
+}
+
+// FlagValues returns the configured default of flag for command and the file
+// it came from. Within a file a command-specific value beats a top-level
+// setting; across files the higher layer wins.
+func (l Layers) FlagValues(command, flag string) ([]string, string, bool, error) {
+	for _, cfg := range l {
+		if raw, ok := cfg.Commands[command][flag]; ok {
+			values, err := flagValues(raw)
+			if err != nil {
+				return nil, cfg.Path, false, fmt.Errorf("%s: commands.%s.%s: %w", cfg.Path, command, flag, err)
+			}
+			return values, cfg.Path, true, nil
+		}
+		if lookup, ok := topLevelFlags[flag]; ok {
+			if values, ok := lookup(cfg); ok {
+				return values, cfg.Path, true, nil
+			}
+		}
+	}
+	return nil, "", false, nil
+}
+
+// Check reports configured commands that resolve returns false for and
+// configured flags that a command does not define, naming the file that
+// declares them.
+func (l Layers) Check(resolve func(command string) (hasFlag func(string) bool, ok bool)) error {
+	var errs []error
+	for _, cfg := range l {
+		for _, command := range sortedKeys(cfg.Commands) {
+			hasFlag, ok := resolve(command)
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s: unknown command %q", cfg.Path, command))
+				continue
+			}
+			for _, flag := range sortedKeys(cfg.Commands[command]) {
+				if !hasFlag(flag) {
+					errs = append(errs, fmt.Errorf("%s: command %q has no flag --%s", cfg.Path, command, flag))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RuleSeverity merges rule severity overrides; a higher layer wins per rule.
+func (l Layers) RuleSeverity() map[string]string {
+	out := make(map[string]string)
+	for i := len(l) - 1; i >= 0; i-- {
+		for rule, severity := range l[i].RuleSeverity {
+			out[rule] = severity
+		}
+	}
+	return out
+}
+
+func flagValues(raw any) ([]string, error) {
+	switch value := raw.(type) {
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if !isScalar(item) {
+				return nil, errors.New("list items must be scalars")
+			}
+			values = append(values, fmt.Sprint(item))
+		}
+		return values, nil
+	default:
+		if !isScalar(value) {
+			return nil, errors.New("value must be a scalar or a list of scalars")
+		}
+		return []string{fmt.Sprint(value)}, nil
+	}
+}
+
+func isScalar(value any) bool {
+	switch value.(type) {
+	case nil, []any, map[string]any:
+		return false
+	default:
+		return true
+	}
+}
+
+// sharedEnvFlags are the flags that mean the same thing in every command, so
+// the unscoped TFOPS_<FLAG> form is safe for them. Flags such as format or
+// output accept different values per command, or change where a command
+// writes, and are only read from TFOPS_<COMMAND>_<FLAG>.
+var sharedEnvFlags = map[string]struct{}{
+	"terraform-binary": {},
+	"rules":            {},
+	"baseline":         {},
+	"verbose":          {},
+}
+
+// EnvValue returns the TFOPS_* environment override of flag for command and
+// the variable it came from. The command-specific TFOPS_<COMMAND>_<FLAG>
+// beats the global TFOPS_<FLAG>, which is only read for shared flags.
+func EnvValue(getenv func(string) string, command, flag string) (string, string, bool) {
+	names := []string{EnvName(command + " " + flag)}
+	if _, ok := sharedEnvFlags[flag]; ok {
+		names = append(names, EnvName(flag))
+	}
+	for _, name := range names {
+		if value := getenv(name); value != "" {
+			return value, name, true
+		}
+	}
+	return "", "", false
+}
+
+// EnvName converts a command path or flag name to its environment variable,
+// for example "publish github-comment" to TFOPS_PUBLISH_GITHUB_COMMENT.
+func EnvName(name string) string {
+	replacer := strings.NewReplacer("-", "_", " ", "_")
+	return EnvPrefix + strings.ToUpper(replacer.Replace(name))
+}
+
+type contextKey struct{}
+
+// NewContext returns a context that carries the loaded layers.
+func NewContext(ctx context.Context, layers Layers) context.Context {
+	return context.WithValue(ctx, contextKey{}, layers)
+}
+
+// FromContext returns the layers stored by NewContext, or none.
+func FromContext(ctx context.Context) Layers {
+	if ctx == nil {
+		return nil
+	}
+	layers, _ := ctx.Value(contextKey{}).(Layers)
+	return layers
 }
@@ -0,0 +1,160 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func envFrom(values map[string]string) func(string) string {
+	return func(name string) string { return values[name] }
+}
+
+func TestLoadFindsProjectAndUserFiles(t *testing.T) {
+	repo := t.TempDir()
+	writeFile(t, filepath.Join(repo, ".git", "HEAD"), "ref: refs/heads/main\n")
+	writeFile(t, filepath.Join(repo, ProjectFileName), `
+suppressions: [baseline.json]
+rule_severity:
+  TFOPS-DRIFT-DETECTED: high
+commands:
+  analyze:
+    format: sarif
+    rules: [a.yaml, b.yaml]
+`)
+	xdg := t.TempDir()
+	writeFile(t, filepath.Join(xdg, "terraform-ops", "config.yaml"), `
+terraform_bin: tofu
+rule_severity:
+  TFOPS-DRIFT-DETECTED: low
+  TFOPS-UNKNOWN-AFTER: info
+commands:
+  analyze:
+    format: json
+    fail-on: high
+`)
+	workDir := filepath.Join(repo, "envs", "prod")
+	if err := os.MkdirAll(workDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	layers, err := Load(workDir, envFrom(map[string]string{"XDG_CONFIG_HOME": xdg}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(layers) != 2 {
+		t.Fatalf("got %d layers", len(layers))
+	}
+
+	cases := []struct {
+		flag string
+		want []string
+	}{
+		{"format", []string{"sarif"}},
+		{"fail-on", []string{"high"}},
+		{"rules", []string{"a.yaml", "b.yaml"}},
+		{"terraform-binary", []string{"tofu"}},
+		{"baseline", []string{filepath.Join(repo, "baseline.json")}},
+	}
+	for _, tc := range cases {
+		got, _, ok, err := layers.FlagValues("analyze", tc.flag)
+		if err != nil || !ok || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("--%s: got %v, %v, %v; want %v", tc.flag, got, ok, err, tc.want)
+		}
+	}
+	if _, _, ok, _ := layers.FlagValues("plan-graph", "format"); ok {
+		t.Error("analyze settings leaked into plan-graph")
+	}
+
+	want := map[string]string{"TFOPS-DRIFT-DETECTED": "high", "TFOPS-UNKNOWN-AFTER": "info"}
+	if got := layers.RuleSeverity(); !reflect.DeepEqual(got, want) {
+		t.Errorf("rule severity: got %v, want %v", got, want)
+	}
+}
+
+func TestFindProjectConfigStopsAtRepositoryRoot(t *testing.T) {
+	outer := t.TempDir()
+	writeFile(t, filepath.Join(outer, ProjectFileName), "verbose: true\n")
+	repo := filepath.Join(outer, "repo")
+	writeFile(t, filepath.Join(repo, ".git", "HEAD"), "ref: refs/heads/main\n")
+	if got := FindProjectConfig(repo); got != "" {
+		t.Fatalf("found %s outside the repository", got)
+	}
+}
+
+func TestLoadConfigRejectsUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ProjectFileName)
+	writeFile(t, path, "terraform_binary: tofu\n")
+	if _, err := LoadConfig(path); err == nil || !strings.Contains(err.Error(), "terraform_binary") {
+		t.Fatalf("expected unknown key error, got %v", err)
+	}
+}
+
+func TestEnvValueIgnoresGlobalCommandSpecificFlags(t *testing.T) {
+	getenv := envFrom(map[string]string{
+		"TFOPS_FORMAT":   "sarif",
+		"TFOPS_OUTPUT":   "graph.dot",
+		"TFOPS_FAIL_ON":  "high",
+		"TFOPS_GROUP_BY": "action",
+		"TFOPS_RULES":    "rules.yaml",
+		"TFOPS_BASELINE": "baseline.json",
+		"TFOPS_VERBOSE":  "true",
+	})
+	for _, flag := range []string{"format", "output", "fail-on", "group-by"} {
+		if value, name, ok := EnvValue(getenv, "summarize-plan", flag); ok {
+			t.Errorf("--%s: global variable %s = %q must not apply", flag, name, value)
+		}
+	}
+	for _, flag := range []string{"rules", "baseline", "verbose"} {
+		if _, _, ok := EnvValue(getenv, "analyze", flag); !ok {
+			t.Errorf("--%s: shared global variable was ignored", flag)
+		}
+	}
+}
+
+func TestEnvValuePrefersCommandSpecificVariable(t *testing.T) {
+	getenv := envFrom(map[string]string{
+		"TFOPS_TERRAFORM_BINARY":              "tofu",
+		"TFOPS_PLAN_GRAPH_TERRAFORM_BINARY":   "terraform",
+		"TFOPS_PUBLISH_GITHUB_COMMENT_MARKER": "plan",
+		"TFOPS_ANALYZE_FAIL_ON":               "high",
+	})
+	if value, name, ok := EnvValue(getenv, "analyze", "terraform-binary"); !ok || value != "tofu" || name != "TFOPS_TERRAFORM_BINARY" {
+		t.Errorf("global: got %q from %s", value, name)
+	}
+	if value, name, ok := EnvValue(getenv, "plan-graph", "terraform-binary"); !ok || value != "terraform" || name != "TFOPS_PLAN_GRAPH_TERRAFORM_BINARY" {
+		t.Errorf("command-specific over global: got %q from %s", value, name)
+	}
+	if value, _, ok := EnvValue(getenv, "analyze", "fail-on"); !ok || value != "high" {
+		t.Errorf("command-specific: got %q", value)
+	}
+	if value, _, ok := EnvValue(getenv, "publish github-comment", "marker"); !ok || value != "plan" {
+		t.Errorf("subcommand: got %q", value)
+	}
+}
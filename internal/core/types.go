@@ -14,7 +14,12 @@
 
 package core
 
-import "github.com/yu/terraform-ops/internal/ir"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yu/terraform-ops/internal/ir"
+)
 
 // ConfigParser defines the interface for parsing Terraform configuration files.
 // Configuration inspection is separate from plan/change intelligence and keeps
@@ -23,6 +28,13 @@ type ConfigParser interface {
 	ParseConfigFiles(paths []string) ([]TerraformConfig, error)
 }
 
+// WorkspaceFinder discovers the root modules below a directory tree so
+// show-terraform can inspect every workspace of a monorepo. The diagnostics
+// describe files that could not be parsed while searching.
+type WorkspaceFinder interface {
+	FindWorkspaces(root string, opts WorkspaceSearchOptions) ([]string, []Diagnostic, error)
+}
+
 // GraphBuilder projects the normalized ChangeSet graph into the renderer-facing
 // graph model. Dependency discovery itself belongs to the source normalizer.
 type GraphBuilder interface {
@@ -50,6 +62,13 @@ type SummaryOptions struct {
 	Verbose     bool
 	ShowDetails bool
 	Color       ColorMode
+	// MaxPlanBytes bounds the plan JSON read from disk or from show -json.
+	MaxPlanBytes int64
+	// Engine is the source engine (auto, terraform, opentofu).
+	Engine string
+	// TerraformBinary reads saved binary plans; empty means tofu for the
+	// opentofu engine and terraform otherwise.
+	TerraformBinary string
 }
 
 // PlanSummary is a renderer-facing projection of an ir.ChangeSet. It is not a
@@ -89,14 +108,15 @@ type Changes struct {
 
 // ResourceSummary is a renderer-facing resource projection.
 type ResourceSummary struct {
-	Address       string                 `json:"address"`
-	ModuleAddress string                 `json:"module_address"`
-	Type          string                 `json:"type"`
-	Name          string                 `json:"name"`
-	Provider      string                 `json:"provider"`
-	Actions       []string               `json:"actions"`
-	Sensitive     bool                   `json:"sensitive"`
-	KeyChanges    map[string]interface{} `json:"key_changes,omitempty"`
+	Address        string                 `json:"address"`
+	ModuleAddress  string                 `json:"module_address"`
+	Type           string                 `json:"type"`
+	Name           string                 `json:"name"`
+	Provider       string                 `json:"provider"`
+	ProviderConfig string                 `json:"provider_config,omitempty"`
+	Actions        []string               `json:"actions"`
+	Sensitive      bool                   `json:"sensitive"`
+	KeyChanges     map[string]interface{} `json:"key_changes,omitempty"`
 }
 
 // OutputSummary is a renderer-facing output projection.
@@ -137,12 +157,153 @@ type TerraformConfig struct {
 	RequiredVersion   string            `json:"required_version,omitempty"`
 	Backend           *Backend          `json:"backend,omitempty"`
 	RequiredProviders map[string]string `json:"required_providers"`
+	// Providers holds the full required_providers entries keyed by local name.
+	// RequiredProviders keeps only their version constraints.
+	Providers      map[string]ProviderRequirement `json:"providers,omitempty"`
+	LockFile       *LockFile                      `json:"lock_file,omitempty"`
+	LockMismatches []LockMismatch                 `json:"lock_mismatches,omitempty"`
+	// Modules lists the module calls of the root module, sorted by name.
+	Modules []ModuleCall `json:"modules,omitempty"`
+	// Diagnostics records the problems met while reading the workspace. The
+	// affected files or blocks are skipped and the rest is still reported.
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+}
+
+// DiagnosticSeverity is the severity of a configuration diagnostic.
+type DiagnosticSeverity string
+
+const (
+	DiagnosticError   DiagnosticSeverity = "error"
+	DiagnosticWarning DiagnosticSeverity = "warning"
+)
+
+// Diagnostic is a problem found while parsing configuration. File is an
+// absolute path. Range is nil when the problem has no source position, such
+// as an unreadable file.
+type Diagnostic struct {
+	Severity DiagnosticSeverity `json:"severity"`
+	Summary  string             `json:"summary"`
+	Detail   string             `json:"detail,omitempty"`
+	File     string             `json:"file,omitempty"`
+	Range    *SourceRange       `json:"range,omitempty"`
+}
+
+// String formats the diagnostic as "severity: file:line:column: summary; detail".
+func (d Diagnostic) String() string {
+	var b strings.Builder
+	b.WriteString(string(d.Severity))
+	b.WriteString(": ")
+	if d.Range != nil {
+		fmt.Fprintf(&b, "%s:%d:%d: ", d.Range.File, d.Range.StartLine, d.Range.StartColumn)
+	} else if d.File != "" {
+		b.WriteString(d.File + ": ")
+	}
+	b.WriteString(d.Summary)
+	if d.Detail != "" {
+		b.WriteString("; " + d.Detail)
+	}
+	return b.String()
+}
+
+// ModuleSourceType classifies a module source address.
+type ModuleSourceType string
+
+const (
+	ModuleSourceLocal     ModuleSourceType = "local"
+	ModuleSourceRegistry  ModuleSourceType = "registry"
+	ModuleSourceGit       ModuleSourceType = "git"
+	ModuleSourceMercurial ModuleSourceType = "mercurial"
+	ModuleSourceS3        ModuleSourceType = "s3"
+	ModuleSourceGCS       ModuleSourceType = "gcs"
+	ModuleSourceHTTP      ModuleSourceType = "http"
+	ModuleSourceUnknown   ModuleSourceType = "unknown"
+)
+
+// ModuleCall is a module block of a workspace. Version is the registry
+// version constraint and Ref the git or mercurial ref from the source's
+// ?ref= argument. Source is empty when it is not a literal string.
+type ModuleCall struct {
+	Name       string           `json:"name"`
+	Source     string           `json:"source"`
+	SourceType ModuleSourceType `json:"source_type"`
+	Version    string           `json:"version,omitempty"`
+	Ref        string           `json:"ref,omitempty"`
+	Count      bool             `json:"count"`
+	ForEach    bool             `json:"for_each"`
+	Location   SourceRange      `json:"location"`
+}
+
+// ProviderRequirement is one entry of a required_providers block. Source is
+// the source address as written; Address is its fully qualified form, for
+// example registry.terraform.io/hashicorp/aws.
+type ProviderRequirement struct {
+	Source               string   `json:"source,omitempty"`
+	Address              string   `json:"address"`
+	Version              string   `json:"version"`
+	ConfigurationAliases []string `json:"configuration_aliases,omitempty"`
+}
+
+// LockFile is the dependency lock file (.terraform.lock.hcl) of a workspace.
+// Providers are keyed by fully qualified provider address.
+type LockFile struct {
+	Path      string                    `json:"path"`
+	Providers map[string]LockedProvider `json:"providers"`
+}
+
+// LockedProvider is a provider selection recorded in the lock file.
+type LockedProvider struct {
+	Version     string   `json:"version"`
+	Constraints string   `json:"constraints,omitempty"`
+	Hashes      []string `json:"hashes"`
+}
+
+// LockMismatchKind classifies a disagreement between required_providers and
+// the lock file.
+type LockMismatchKind string
+
+const (
+	LockMismatchVersion LockMismatchKind = "version_outside_constraint"
+	LockMismatchMissing LockMismatchKind = "missing_from_lock_file"
+)
+
+// LockMismatch reports a required provider whose lock file entry is missing
+// or pins a version the declared constraint does not allow.
+type LockMismatch struct {
+	Provider      string           `json:"provider"`
+	Address       string           `json:"address"`
+	Kind          LockMismatchKind `json:"kind"`
+	Constraint    string           `json:"constraint,omitempty"`
+	LockedVersion string           `json:"locked_version,omitempty"`
+	Message       string           `json:"message"`
+}
+
+// SourceRange identifies a span of a configuration file. Lines and columns are
+// 1-based, matching hcl.Range.
+type SourceRange struct {
+	File        string `json:"file"`
+	StartLine   int    `json:"start_line"`
+	StartColumn int    `json:"start_column"`
+	EndLine     int    `json:"end_line"`
+	EndColumn   int    `json:"end_column"`
+}
+
+// WorkspaceSearchOptions filters discovered workspaces. Patterns are globs
+// matched against the workspace path relative to the search root, with
+// forward slashes and "." for the root itself. A workspace must match an
+// Include pattern, when any are given, and no Exclude pattern.
+type WorkspaceSearchOptions struct {
+	Include []string
+	Exclude []string
 }
 
-// Backend represents a backend configuration.
+// Backend represents a backend configuration. Config holds primitive settings
+// as strings, collections as []interface{} or map[string]interface{}, and
+// nested blocks as objects. SensitiveKeys lists the dotted paths of settings
+// whose names look like credentials.
 type Backend struct {
-	Type   string            `json:"type"`
-	Config map[string]string `json:"config,omitempty"`
+	Type          string                 `json:"type"`
+	Config        map[string]interface{} `json:"config,omitempty"`
+	SensitiveKeys []string               `json:"sensitive_keys,omitempty"`
 }
 
 // GraphOptions holds options for graph rendering. NoLocals remains for CLI
@@ -159,6 +320,27 @@ type GraphOptions struct {
 	NoModules     bool
 	Compact       bool
 	Verbose       bool
+	// EdgeLabels prints each edge's evidence kinds on the edge.
+	EdgeLabels bool
+	// Focus limits the graph to nodes whose addresses match these globs and
+	// their neighborhood. Upstream follows dependencies and Downstream follows
+	// dependents; when neither is set both are followed. Depth bounds the
+	// number of edges from a focus node; zero is unlimited.
+	Focus      []string
+	Upstream   bool
+	Downstream bool
+	Depth      int
+	// CollapseModules replaces the nodes of every module at this depth or
+	// deeper with one summary node per module at this depth; zero keeps
+	// every node.
+	CollapseModules int
+	// MaxPlanBytes bounds the plan JSON read from disk or from show -json.
+	MaxPlanBytes int64
+	// Engine is the source engine (auto, terraform, opentofu).
+	Engine string
+	// TerraformBinary reads saved binary plans; empty means tofu for the
+	// opentofu engine and terraform otherwise.
+	TerraformBinary string
 }
 
 // GraphData is a renderer-facing projection of ir.DependencyGraph.
@@ -177,12 +359,36 @@ type GraphNode struct {
 	Provider  string
 	Actions   []string
 	Sensitive bool
+	// Focus marks a node selected by GraphOptions.Focus.
+	Focus bool
+	// Summary counts the collapsed nodes of a NodeTypeModule node.
+	Summary *ModuleSummary
+}
+
+// ModuleSummary counts the planned actions of the nodes collapsed into one
+// module node by GraphOptions.CollapseModules.
+type ModuleSummary struct {
+	Nodes   int
+	Create  int
+	Update  int
+	Delete  int
+	Replace int
+	NoOp    int
+}
+
+// String lists the create, update, delete and replace counts.
+func (s ModuleSummary) String() string {
+	return fmt.Sprintf("%d create, %d update, %d delete, %d replace", s.Create, s.Update, s.Delete, s.Replace)
 }
 
-// GraphEdge represents a rendered graph edge.
+// GraphEdge represents a rendered graph edge. One edge may stand for several
+// pieces of dependency evidence: Kinds lists their distinct kinds in sorted
+// order and Confidence is the strongest of their confidences.
 type GraphEdge struct {
-	From string
-	To   string
+	From       string
+	To         string
+	Kinds      []ir.EdgeKind
+	Confidence ir.EvidenceConfidence
 }
 
 // GraphFormat represents the output format for the graph.
@@ -224,4 +430,5 @@ const (
 	NodeTypeOutput   NodeType = "output"
 	NodeTypeVariable NodeType = "variable"
 	NodeTypeLocal    NodeType = "local"
+	NodeTypeModule   NodeType = "module"
 )
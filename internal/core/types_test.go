@@ -80,7 +80,7 @@ func TestTerraformConfigRemainsShowTerraformDomain(t *testing.T) {
 		RequiredProviders: map[string]string{"aws": "~> 5.0"},
 		Backend: &Backend{
 			Type:   "s3",
-			Config: map[string]string{"bucket": "terraform-state"},
+			Config: map[string]interface{}{"bucket": "terraform-state"},
 		},
 	}
 	assert.Equal(t, "/path/to/config", config.Path)
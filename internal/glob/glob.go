@@ -0,0 +1,59 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package glob matches Terraform/OpenTofu addresses and type names against
+// simple wildcard patterns.
+//
+// Unlike path.Match, brackets are literal so instance keys such as
+// aws_instance.web[0] or module.app["blue"] can be written verbatim. Only two
+// wildcards are supported: '*' matches any run of characters (including dots)
+// and '?' matches exactly one character.
+package glob
+
+// Match reports whether value matches pattern.
+func Match(pattern, value string) bool {
+	p, v := 0, 0
+	starP, starV := -1, 0
+	for v < len(value) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == value[v]):
+			p++
+			v++
+		case p < len(pattern) && pattern[p] == '*':
+			starP, starV = p, v
+			p++
+		case starP >= 0:
+			// Backtrack: let the most recent '*' absorb one more character.
+			starV++
+			p, v = starP+1, starV
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// MatchAny reports whether value matches at least one pattern. An empty
+// pattern list matches nothing.
+func MatchAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if Match(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
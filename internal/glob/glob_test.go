@@ -0,0 +1,54 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glob
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"aws_db_instance", "aws_db_instance", true},
+		{"aws_db_*", "aws_db_instance", true},
+		{"aws_db_*", "aws_dbx", false},
+		{"module.network.*", "module.network.aws_subnet.a[0]", true},
+		{"module.network.*", "module.network", false},
+		{"module.*.aws_instance.web", "module.a.module.b.aws_instance.web", true},
+		{`module.app["blue"].*`, `module.app["blue"].aws_instance.web`, true},
+		{"aws_instance.web[?]", "aws_instance.web[0]", true},
+		{"aws_instance.web[?]", "aws_instance.web[10]", false},
+		{"*", "", true},
+		{"", "", true},
+		{"", "x", false},
+		{"*_role", "aws_iam_role", true},
+		{"*_role", "aws_iam_role_policy", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.pattern, tt.value); got != tt.want {
+			t.Errorf("Match(%q, %q) = %t, want %t", tt.pattern, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMatchAny(t *testing.T) {
+	if MatchAny(nil, "anything") {
+		t.Fatal("empty pattern list must not match")
+	}
+	if !MatchAny([]string{"google_*", "aws_*"}, "aws_s3_bucket") {
+		t.Fatal("expected second pattern to match")
+	}
+}
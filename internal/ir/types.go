@@ -17,6 +17,7 @@ package ir
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -44,6 +45,81 @@ type PlanMetadata struct {
 
 type Address string
 
+// ModulePath returns the addresses of a module and its ancestors, outermost
+// first: module.a["x"].module.b yields module.a["x"] and
+// module.a["x"].module.b. The root module has an empty path. Quoted instance
+// keys may contain dots and brackets.
+func (a Address) ModulePath() []Address {
+	module := string(a)
+	if module == "" {
+		return nil
+	}
+	var path []Address
+	inString := false
+	escaped := false
+	depth := 0
+	for i := 0; i < len(module); i++ {
+		ch := module[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inString && ch == '\\':
+			escaped = true
+		case ch == '"':
+			inString = !inString
+		case inString:
+		case ch == '[':
+			depth++
+		case ch == ']':
+			depth--
+		case ch == '.' && depth == 0 && strings.HasPrefix(module[i+1:], "module."):
+			path = append(path, Address(module[:i]))
+		}
+	}
+	return append(path, a)
+}
+
+// WithoutInstanceKeys removes count/for_each instance keys from every address
+// segment, for example module.app["blue"].aws_instance.web[0] becomes
+// module.app.aws_instance.web. Quoted keys may contain brackets.
+func (a Address) WithoutInstanceKeys() Address {
+	address := string(a)
+	var out strings.Builder
+	out.Grow(len(address))
+
+	inIndex := false
+	inString := false
+	escaped := false
+	for i := 0; i < len(address); i++ {
+		ch := address[i]
+		if !inIndex {
+			if ch == '[' {
+				inIndex = true
+				continue
+			}
+			out.WriteByte(ch)
+			continue
+		}
+
+		if escaped {
+			escaped = false
+			continue
+		}
+		if inString && ch == '\\' {
+			escaped = true
+			continue
+		}
+		if ch == '"' {
+			inString = !inString
+			continue
+		}
+		if ch == ']' && !inString {
+			inIndex = false
+		}
+	}
+	return Address(out.String())
+}
+
 type ResourceMode string
 
 const (
@@ -123,11 +199,70 @@ func (p AttributePath) String() string {
 	return b.String()
 }
 
+// HasPrefix reports whether prefix addresses p itself or one of its ancestors.
+func (p AttributePath) HasPrefix(prefix AttributePath) bool {
+	if len(prefix) > len(p) {
+		return false
+	}
+	for i, step := range prefix {
+		if !step.equal(p[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s PathStep) equal(other PathStep) bool {
+	return stringPtrEqual(s.Attribute, other.Attribute) && stringPtrEqual(s.Index, other.Index)
+}
+
+func stringPtrEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
 type SafeValue struct {
 	Value    any  `json:"value,omitempty"`
 	Redacted bool `json:"redacted,omitempty"`
 }
 
+// Lookup returns the sanitized value at path. Attribute steps select object
+// keys; index steps select list positions or map keys. ok is false when the
+// path does not exist or the value was removed by redaction.
+func (v SafeValue) Lookup(path AttributePath) (value any, ok bool) {
+	if v.Redacted {
+		return nil, false
+	}
+	current := v.Value
+	for _, step := range path {
+		key := ""
+		switch {
+		case step.Attribute != nil:
+			key = *step.Attribute
+		case step.Index != nil:
+			key = *step.Index
+		}
+		switch typed := current.(type) {
+		case map[string]any:
+			current, ok = typed[key]
+			if !ok {
+				return nil, false
+			}
+		case []any:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(typed) {
+				return nil, false
+			}
+			current = typed[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
 type ImportInfo struct {
 	ID      string `json:"id,omitempty"`
 	Unknown bool   `json:"unknown,omitempty"`
@@ -150,6 +285,64 @@ type ResourceChange struct {
 	After           SafeValue       `json:"after"`
 	UnknownPaths    []AttributePath `json:"unknown_paths,omitempty"`
 	SensitivePaths  []AttributePath `json:"sensitive_paths,omitempty"`
+	// ProviderName is the provider's fully qualified source address, for
+	// example registry.terraform.io/hashicorp/aws, when the plan records it.
+	ProviderName string `json:"provider_name,omitempty"`
+	// ProviderConfig is the address of the provider configuration that
+	// manages the resource, for example
+	// provider["registry.terraform.io/hashicorp/aws"].us_east_1.
+	ProviderConfig string `json:"provider_config,omitempty"`
+	// ProviderAlias is the alias of that configuration, empty for the
+	// default configuration.
+	ProviderAlias string `json:"provider_alias,omitempty"`
+	// SchemaVersion is the resource type schema version recorded in the
+	// planned or prior state.
+	SchemaVersion *int `json:"schema_version,omitempty"`
+}
+
+// ProviderLabel names the provider for grouping: the provider type from
+// ProviderName (google-beta for registry.terraform.io/hashicorp/google-beta),
+// suffixed with the alias when the resource uses an aliased configuration.
+// It is empty when the plan did not record a provider name.
+func (r ResourceChange) ProviderLabel() string {
+	providerType := ProviderType(r.ProviderName)
+	if providerType == "" {
+		return ""
+	}
+	if r.ProviderAlias != "" {
+		return providerType + "." + r.ProviderAlias
+	}
+	return providerType
+}
+
+// ProviderType returns the type segment of a provider source address, so
+// registry.terraform.io/hashicorp/aws and hashicorp/aws both yield aws.
+func ProviderType(providerName string) string {
+	if index := strings.LastIndex(providerName, "/"); index >= 0 {
+		return providerName[index+1:]
+	}
+	return providerName
+}
+
+// StateResource is a resource instance recorded in the plan's prior state.
+// Only identity and dependency metadata are kept; state values never cross
+// the source boundary.
+type StateResource struct {
+	Address       Address      `json:"address"`
+	Mode          ResourceMode `json:"mode"`
+	Type          string       `json:"type"`
+	ProviderName  string       `json:"provider_name,omitempty"`
+	SchemaVersion *int         `json:"schema_version,omitempty"`
+	DependsOn     []Address    `json:"depends_on,omitempty"`
+}
+
+// PriorStateSummary counts the managed resources recorded in the prior state.
+// Unchanged resources are those the plan does not change (no-op or absent
+// from resource_changes).
+type PriorStateSummary struct {
+	Present   bool `json:"present"`
+	Resources int  `json:"resources"`
+	Unchanged int  `json:"unchanged"`
 }
 
 type OutputChange struct {
@@ -274,6 +467,46 @@ func (g DependencyGraph) TransitiveDependents(node NodeID) []NodeID {
 	return sortedNodeIDs(seen)
 }
 
+// Neighborhood returns the seeds and every node reachable from them within
+// depth edges, following edges to dependencies (upstream), to dependents
+// (downstream) or both. Unlike TransitiveDependents it includes variable and
+// output nodes, so renderers can show the full picture around a node. A depth
+// of zero or less is unlimited.
+func (g DependencyGraph) Neighborhood(seeds []NodeID, upstream, downstream bool, depth int) []NodeID {
+	adjacent := make(map[NodeID][]NodeID)
+	for _, edge := range g.Edges {
+		if downstream {
+			adjacent[edge.From] = append(adjacent[edge.From], edge.To)
+		}
+		if upstream {
+			adjacent[edge.To] = append(adjacent[edge.To], edge.From)
+		}
+	}
+
+	seen := make(map[NodeID]struct{}, len(seeds))
+	frontier := make([]NodeID, 0, len(seeds))
+	for _, seed := range seeds {
+		if _, ok := seen[seed]; !ok {
+			seen[seed] = struct{}{}
+			frontier = append(frontier, seed)
+		}
+	}
+	for distance := 1; len(frontier) > 0 && (depth <= 0 || distance <= depth); distance++ {
+		var next []NodeID
+		for _, current := range frontier {
+			for _, neighbor := range adjacent[current] {
+				if _, ok := seen[neighbor]; ok {
+					continue
+				}
+				seen[neighbor] = struct{}{}
+				next = append(next, neighbor)
+			}
+		}
+		frontier = next
+	}
+	return sortedNodeIDs(seen)
+}
+
 func (g DependencyGraph) NodeKind(id NodeID) NodeKind {
 	for _, node := range g.Nodes {
 		if node.ID == id {
@@ -328,6 +561,56 @@ type ChangeSet struct {
 	Relevant      []RelevantAttribute `json:"relevant,omitempty"`
 	Graph         DependencyGraph     `json:"graph"`
 	Redaction     RedactionSummary    `json:"redaction"`
+	PriorState    PriorStateSummary   `json:"prior_state"`
+	// Unchanged lists prior-state managed resources the plan leaves as they
+	// are. They are not graph nodes but can depend on changed resources.
+	Unchanged []StateResource `json:"unchanged,omitempty"`
+}
+
+// UnchangedIndex maps a configuration resource address to the unchanged
+// prior-state resources that list it in their dependencies.
+type UnchangedIndex map[Address][]Address
+
+// UnchangedIndex indexes c.Unchanged by dependency. Build it once when looking
+// up the dependents of many addresses.
+func (c *ChangeSet) UnchangedIndex() UnchangedIndex {
+	index := make(UnchangedIndex)
+	for _, resource := range c.Unchanged {
+		for _, dependency := range resource.DependsOn {
+			key := dependency.WithoutInstanceKeys()
+			index[key] = append(index[key], resource.Address)
+		}
+	}
+	return index
+}
+
+// Dependents returns the unchanged resources that depend on address, directly
+// or through other unchanged resources. Dependencies are recorded per
+// configuration resource, so instance keys are ignored.
+func (index UnchangedIndex) Dependents(address Address) []Address {
+	seen := map[Address]struct{}{}
+	queue := []Address{address.WithoutInstanceKeys()}
+	var out []Address
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dependent := range index[current] {
+			if _, ok := seen[dependent]; ok || dependent == address {
+				continue
+			}
+			seen[dependent] = struct{}{}
+			out = append(out, dependent)
+			queue = append(queue, dependent.WithoutInstanceKeys())
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// UnchangedDependents returns the unchanged prior-state resources that depend
+// on address. It indexes c.Unchanged on every call; see UnchangedIndex.
+func (c *ChangeSet) UnchangedDependents(address Address) []Address {
+	return c.UnchangedIndex().Dependents(address)
 }
 
 func (c *ChangeSet) Sort() {
@@ -335,6 +618,7 @@ func (c *ChangeSet) Sort() {
 	sort.Slice(c.Outputs, func(i, j int) bool { return c.Outputs[i].Name < c.Outputs[j].Name })
 	sort.Slice(c.Checks, func(i, j int) bool { return c.Checks[i].Address < c.Checks[j].Address })
 	sort.Slice(c.Drift, func(i, j int) bool { return c.Drift[i].Resource.Address < c.Drift[j].Resource.Address })
+	sort.Slice(c.Unchanged, func(i, j int) bool { return c.Unchanged[i].Address < c.Unchanged[j].Address })
 	sort.Slice(c.Graph.Nodes, func(i, j int) bool { return c.Graph.Nodes[i].ID < c.Graph.Nodes[j].ID })
 	sort.Slice(c.Graph.Edges, func(i, j int) bool {
 		a, b := c.Graph.Edges[i], c.Graph.Edges[j]
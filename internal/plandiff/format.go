@@ -0,0 +1,164 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plandiff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+// Render renders a diff report in one of the analysis report formats.
+func Render(diff Report, format report.Format) ([]byte, error) {
+	switch format {
+	case report.FormatJSON:
+		return renderJSON(diff)
+	case report.FormatMarkdown:
+		return []byte(renderMarkdown(diff)), nil
+	case report.FormatText, "":
+		return []byte(renderText(diff)), nil
+	default:
+		return nil, fmt.Errorf("unsupported plan diff format %q", format)
+	}
+}
+
+func renderJSON(diff Report) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(diff); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func renderText(diff Report) string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "Terraform/OpenTofu Plan Diff")
+	fmt.Fprintln(&b, "===========================")
+	fmt.Fprintf(&b, "Old: %s (%s)\n", diff.Old.Path, changeCounts(diff.Old.Summary))
+	fmt.Fprintf(&b, "New: %s (%s)\n\n", diff.New.Path, changeCounts(diff.New.Summary))
+	if !diff.Diverged {
+		fmt.Fprintln(&b, "Plans are equivalent.")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Resources: %d appeared, %d disappeared, %d changed\n", diff.Summary.Appeared, diff.Summary.Disappeared, diff.Summary.Changed)
+	fmt.Fprintf(&b, "Findings: %d new, %d resolved\n", diff.Summary.NewFindings, diff.Summary.ResolvedFindings)
+
+	if len(diff.Appeared) > 0 {
+		fmt.Fprintln(&b, "\nAppeared")
+		fmt.Fprintln(&b, "--------")
+		for _, delta := range diff.Appeared {
+			fmt.Fprintf(&b, "+ %s  %s\n", delta.NewAction, delta.Address)
+		}
+	}
+	if len(diff.Disappeared) > 0 {
+		fmt.Fprintln(&b, "\nDisappeared")
+		fmt.Fprintln(&b, "-----------")
+		for _, delta := range diff.Disappeared {
+			fmt.Fprintf(&b, "- %s  %s\n", delta.OldAction, delta.Address)
+		}
+	}
+	if len(diff.Changed) > 0 {
+		fmt.Fprintln(&b, "\nChanged")
+		fmt.Fprintln(&b, "-------")
+		for _, delta := range diff.Changed {
+			fmt.Fprintf(&b, "~ %s\n", delta.Address)
+			fmt.Fprintf(&b, "  %s\n", describeDelta(delta))
+		}
+	}
+	writeTextFindings(&b, "New findings", "------------", diff.NewFindings)
+	writeTextFindings(&b, "Resolved findings", "-----------------", diff.ResolvedFindings)
+	return b.String()
+}
+
+func writeTextFindings(b *strings.Builder, title, underline string, findings []report.Finding) {
+	if len(findings) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n%s\n%s\n", title, underline)
+	for _, finding := range findings {
+		resource := ""
+		if finding.Resource != nil {
+			resource = " " + finding.Resource.Address
+		}
+		fmt.Fprintf(b, "[%s] %s%s: %s\n", strings.ToUpper(string(finding.Severity)), finding.RuleID, resource, finding.Message)
+	}
+}
+
+func renderMarkdown(diff Report) string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "## Terraform/OpenTofu plan diff")
+	fmt.Fprintln(&b)
+	fmt.Fprintf(&b, "**Old:** `%s` (%s)  \n", diff.Old.Path, changeCounts(diff.Old.Summary))
+	fmt.Fprintf(&b, "**New:** `%s` (%s)\n", diff.New.Path, changeCounts(diff.New.Summary))
+	fmt.Fprintln(&b)
+	if !diff.Diverged {
+		fmt.Fprintln(&b, "Plans are equivalent.")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "**Resources:** %d appeared / %d disappeared / %d changed  \n", diff.Summary.Appeared, diff.Summary.Disappeared, diff.Summary.Changed)
+	fmt.Fprintf(&b, "**Findings:** %d new / %d resolved\n", diff.Summary.NewFindings, diff.Summary.ResolvedFindings)
+
+	if len(diff.Appeared)+len(diff.Disappeared)+len(diff.Changed) > 0 {
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b, "### Resources")
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b, "| Change | Resource | Details |")
+		fmt.Fprintln(&b, "|---|---|---|")
+		for _, delta := range diff.Appeared {
+			fmt.Fprintf(&b, "| appeared | `%s` | new action `%s` |\n", escapeTable(delta.Address), delta.NewAction)
+		}
+		for _, delta := range diff.Disappeared {
+			fmt.Fprintf(&b, "| disappeared | `%s` | old action `%s` |\n", escapeTable(delta.Address), delta.OldAction)
+		}
+		for _, delta := range diff.Changed {
+			fmt.Fprintf(&b, "| changed | `%s` | %s |\n", escapeTable(delta.Address), escapeTable(describeDelta(delta)))
+		}
+	}
+	writeMarkdownFindings(&b, "New findings", diff.NewFindings)
+	writeMarkdownFindings(&b, "Resolved findings", diff.ResolvedFindings)
+	return b.String()
+}
+
+func writeMarkdownFindings(b *strings.Builder, title string, findings []report.Finding) {
+	if len(findings) == 0 {
+		return
+	}
+	fmt.Fprintln(b)
+	fmt.Fprintf(b, "### %s\n", title)
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, "| Severity | Rule | Resource | Finding |")
+	fmt.Fprintln(b, "|---|---|---|---|")
+	for _, finding := range findings {
+		resource := ""
+		if finding.Resource != nil {
+			resource = "`" + escapeTable(finding.Resource.Address) + "`"
+		}
+		fmt.Fprintf(b, "| %s | `%s` | %s | %s |\n", strings.ToUpper(string(finding.Severity)), escapeTable(finding.RuleID), resource, escapeTable(finding.Message))
+	}
+}
+
+func changeCounts(summary report.Summary) string {
+	return fmt.Sprintf("+%d ~%d -%d replace:%d", summary.Create, summary.Update, summary.Delete, summary.Replace)
+}
+
+func escapeTable(value string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(value, "|", "\\|"), "\n", " ")
+}
@@ -0,0 +1,337 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plandiff compares two normalized ChangeSets so reviewers can see how
+// a re-plan differs from the plan they approved. It only ever reads sanitized
+// IR; the report lists which attributes changed, never their values.
+package plandiff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/yu/terraform-ops/internal/ir"
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+const SchemaVersion = "1.0"
+
+// Input is one side of a comparison: a normalized plan and its findings.
+type Input struct {
+	Path      string
+	ChangeSet *ir.ChangeSet
+	Findings  []report.Finding
+}
+
+type PlanRef struct {
+	Path    string            `json:"path"`
+	Source  ir.SourceMetadata `json:"source"`
+	Plan    ir.PlanMetadata   `json:"plan"`
+	Summary report.Summary    `json:"summary"`
+}
+
+// PathDelta lists attribute paths that were added to or removed from a
+// resource's replacement or unknown-after-apply paths.
+type PathDelta struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+func (d PathDelta) empty() bool { return len(d.Added) == 0 && len(d.Removed) == 0 }
+
+// ResourceDelta describes how one resource address differs between plans.
+// Fields names the aspects that changed (action, replace_paths,
+// unknown_paths, before, after). ChangedAttributes lists top-level attribute
+// names whose sanitized before or after values differ.
+type ResourceDelta struct {
+	Address           string     `json:"address"`
+	Type              string     `json:"type,omitempty"`
+	OldAction         string     `json:"old_action,omitempty"`
+	NewAction         string     `json:"new_action,omitempty"`
+	Fields            []string   `json:"fields,omitempty"`
+	ReplacePaths      *PathDelta `json:"replace_paths,omitempty"`
+	UnknownPaths      *PathDelta `json:"unknown_paths,omitempty"`
+	ChangedAttributes []string   `json:"changed_attributes,omitempty"`
+}
+
+type Summary struct {
+	Appeared         int `json:"appeared"`
+	Disappeared      int `json:"disappeared"`
+	Changed          int `json:"changed"`
+	NewFindings      int `json:"new_findings"`
+	ResolvedFindings int `json:"resolved_findings"`
+}
+
+type Report struct {
+	SchemaVersion    string              `json:"schema_version"`
+	Tool             report.ToolMetadata `json:"tool"`
+	Old              PlanRef             `json:"old"`
+	New              PlanRef             `json:"new"`
+	Diverged         bool                `json:"diverged"`
+	Summary          Summary             `json:"summary"`
+	Appeared         []ResourceDelta     `json:"appeared,omitempty"`
+	Disappeared      []ResourceDelta     `json:"disappeared,omitempty"`
+	Changed          []ResourceDelta     `json:"changed,omitempty"`
+	NewFindings      []report.Finding    `json:"new_findings,omitempty"`
+	ResolvedFindings []report.Finding    `json:"resolved_findings,omitempty"`
+}
+
+// Compare builds a deterministic diff report from two analyzed plans.
+func Compare(oldInput, newInput Input, toolVersion string) (Report, error) {
+	if oldInput.ChangeSet == nil || newInput.ChangeSet == nil {
+		return Report{}, fmt.Errorf("change set is nil")
+	}
+	out := Report{
+		SchemaVersion: SchemaVersion,
+		Tool:          report.ToolMetadata{Name: "terraform-ops", Version: toolVersion},
+		Old:           planRef(oldInput, toolVersion),
+		New:           planRef(newInput, toolVersion),
+	}
+
+	oldResources := indexResources(oldInput.ChangeSet.Resources)
+	newResources := indexResources(newInput.ChangeSet.Resources)
+	for address, newResource := range newResources {
+		oldResource, ok := oldResources[address]
+		if !ok {
+			out.Appeared = append(out.Appeared, ResourceDelta{
+				Address:   address,
+				Type:      newResource.Type,
+				NewAction: string(newResource.Action.Semantic),
+			})
+			continue
+		}
+		if delta, changed := compareResource(oldResource, newResource); changed {
+			out.Changed = append(out.Changed, delta)
+		}
+	}
+	for address, oldResource := range oldResources {
+		if _, ok := newResources[address]; !ok {
+			out.Disappeared = append(out.Disappeared, ResourceDelta{
+				Address:   address,
+				Type:      oldResource.Type,
+				OldAction: string(oldResource.Action.Semantic),
+			})
+		}
+	}
+
+	oldFindings := indexFindings(oldInput.Findings)
+	newFindings := indexFindings(newInput.Findings)
+	for key, finding := range newFindings {
+		if _, ok := oldFindings[key]; !ok {
+			out.NewFindings = append(out.NewFindings, finding)
+		}
+	}
+	for key, finding := range oldFindings {
+		if _, ok := newFindings[key]; !ok {
+			out.ResolvedFindings = append(out.ResolvedFindings, finding)
+		}
+	}
+
+	out.Summary = Summary{
+		Appeared:         len(out.Appeared),
+		Disappeared:      len(out.Disappeared),
+		Changed:          len(out.Changed),
+		NewFindings:      len(out.NewFindings),
+		ResolvedFindings: len(out.ResolvedFindings),
+	}
+	out.Diverged = out.Summary != Summary{}
+	sortReport(&out)
+	return out, nil
+}
+
+func planRef(input Input, toolVersion string) PlanRef {
+	built := report.Build(input.ChangeSet, input.Findings, toolVersion)
+	return PlanRef{
+		Path:    input.Path,
+		Source:  input.ChangeSet.Source,
+		Plan:    input.ChangeSet.Plan,
+		Summary: built.Summary,
+	}
+}
+
+func indexResources(resources []ir.ResourceChange) map[string]ir.ResourceChange {
+	out := make(map[string]ir.ResourceChange, len(resources))
+	for _, resource := range resources {
+		out[string(resource.Address)] = resource
+	}
+	return out
+}
+
+// indexFindings keys findings by rule and resource. Messages and evidence may
+// legitimately shift between plans without the finding being new or resolved.
+func indexFindings(findings []report.Finding) map[string]report.Finding {
+	out := make(map[string]report.Finding, len(findings))
+	for _, finding := range findings {
+		out[FindingKey(finding)] = finding
+	}
+	return out
+}
+
+// FindingKey identifies a finding across plans by rule ID and resource address.
+func FindingKey(finding report.Finding) string {
+	address := ""
+	if finding.Resource != nil {
+		address = finding.Resource.Address
+	}
+	return finding.RuleID + "\x00" + address
+}
+
+func compareResource(oldResource, newResource ir.ResourceChange) (ResourceDelta, bool) {
+	delta := ResourceDelta{
+		Address:   string(newResource.Address),
+		Type:      newResource.Type,
+		OldAction: string(oldResource.Action.Semantic),
+		NewAction: string(newResource.Action.Semantic),
+	}
+	if oldResource.Action.Semantic != newResource.Action.Semantic {
+		delta.Fields = append(delta.Fields, "action")
+	}
+	if paths := comparePaths(oldResource.ReplacePaths, newResource.ReplacePaths); !paths.empty() {
+		delta.Fields = append(delta.Fields, "replace_paths")
+		delta.ReplacePaths = &paths
+	}
+	if paths := comparePaths(oldResource.UnknownPaths, newResource.UnknownPaths); !paths.empty() {
+		delta.Fields = append(delta.Fields, "unknown_paths")
+		delta.UnknownPaths = &paths
+	}
+	attributes := make(map[string]struct{})
+	if !reflect.DeepEqual(oldResource.Before, newResource.Before) {
+		delta.Fields = append(delta.Fields, "before")
+		for _, key := range changedKeys(oldResource.Before, newResource.Before) {
+			attributes[key] = struct{}{}
+		}
+	}
+	if !reflect.DeepEqual(oldResource.After, newResource.After) {
+		delta.Fields = append(delta.Fields, "after")
+		for _, key := range changedKeys(oldResource.After, newResource.After) {
+			attributes[key] = struct{}{}
+		}
+	}
+	delta.ChangedAttributes = sortedKeys(attributes)
+	return delta, len(delta.Fields) > 0
+}
+
+func comparePaths(oldPaths, newPaths []ir.AttributePath) PathDelta {
+	oldSet := pathSet(oldPaths)
+	newSet := pathSet(newPaths)
+	var delta PathDelta
+	for path := range newSet {
+		if _, ok := oldSet[path]; !ok {
+			delta.Added = append(delta.Added, path)
+		}
+	}
+	for path := range oldSet {
+		if _, ok := newSet[path]; !ok {
+			delta.Removed = append(delta.Removed, path)
+		}
+	}
+	sort.Strings(delta.Added)
+	sort.Strings(delta.Removed)
+	return delta
+}
+
+func pathSet(paths []ir.AttributePath) map[string]struct{} {
+	out := make(map[string]struct{}, len(paths))
+	for _, path := range paths {
+		out[path.String()] = struct{}{}
+	}
+	return out
+}
+
+// changedKeys lists top-level object keys whose sanitized values differ. Values
+// that are not objects (or that are fully redacted) yield no keys; the caller
+// still records the before/after field as changed.
+func changedKeys(oldValue, newValue ir.SafeValue) []string {
+	oldMap, oldOK := oldValue.Value.(map[string]any)
+	newMap, newOK := newValue.Value.(map[string]any)
+	if !oldOK && !newOK {
+		return nil
+	}
+	var keys []string
+	for key, value := range newMap {
+		if previous, ok := oldMap[key]; !ok || !reflect.DeepEqual(previous, value) {
+			keys = append(keys, key)
+		}
+	}
+	for key := range oldMap {
+		if _, ok := newMap[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(set))
+	for key := range set {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func sortReport(out *Report) {
+	for _, deltas := range [][]ResourceDelta{out.Appeared, out.Disappeared, out.Changed} {
+		sort.Slice(deltas, func(i, j int) bool { return deltas[i].Address < deltas[j].Address })
+	}
+	for _, findings := range [][]report.Finding{out.NewFindings, out.ResolvedFindings} {
+		wrapper := report.AnalysisReport{Findings: findings}
+		report.Sort(&wrapper)
+	}
+}
+
+func describeDelta(delta ResourceDelta) string {
+	var parts []string
+	for _, field := range delta.Fields {
+		switch field {
+		case "action":
+			parts = append(parts, fmt.Sprintf("action %s -> %s", delta.OldAction, delta.NewAction))
+		case "replace_paths":
+			parts = append(parts, "replacement paths "+describePaths(*delta.ReplacePaths))
+		case "unknown_paths":
+			parts = append(parts, "unknown paths "+describePaths(*delta.UnknownPaths))
+		}
+	}
+	if len(delta.ChangedAttributes) > 0 {
+		parts = append(parts, "values changed: "+strings.Join(delta.ChangedAttributes, ", "))
+	} else if containsField(delta.Fields, "before") || containsField(delta.Fields, "after") {
+		parts = append(parts, "values changed")
+	}
+	return strings.Join(parts, "; ")
+}
+
+func describePaths(delta PathDelta) string {
+	var parts []string
+	for _, path := range delta.Added {
+		parts = append(parts, "+"+path)
+	}
+	for _, path := range delta.Removed {
+		parts = append(parts, "-"+path)
+	}
+	return strings.Join(parts, " ")
+}
+
+func containsField(fields []string, want string) bool {
+	for _, field := range fields {
+		if field == want {
+			return true
+		}
+	}
+	return false
+}
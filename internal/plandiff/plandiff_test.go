@@ -0,0 +1,119 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plandiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yu/terraform-ops/internal/ir"
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+func TestCompareReportsResourceAndFindingDeltas(t *testing.T) {
+	oldSet := &ir.ChangeSet{Resources: []ir.ResourceChange{
+		{Address: "test_resource.kept", Type: "test_resource", Action: ir.NormalizeAction([]string{"update"}),
+			After: ir.SafeValue{Value: map[string]any{"size": "small", "name": "a"}}},
+		{Address: "test_resource.gone", Type: "test_resource", Action: ir.NormalizeAction([]string{"create"})},
+		{Address: "test_resource.same", Type: "test_resource", Action: ir.NormalizeAction([]string{"update"})},
+	}}
+	newSet := &ir.ChangeSet{Resources: []ir.ResourceChange{
+		{Address: "test_resource.kept", Type: "test_resource", Action: ir.NormalizeAction([]string{"delete", "create"}),
+			ReplacePaths: []ir.AttributePath{{ir.Attribute("size")}},
+			After:        ir.SafeValue{Value: map[string]any{"size": "large", "name": "a"}}},
+		{Address: "test_resource.new", Type: "test_resource", Action: ir.NormalizeAction([]string{"create"})},
+		{Address: "test_resource.same", Type: "test_resource", Action: ir.NormalizeAction([]string{"update"})},
+	}}
+	replace := report.Finding{RuleID: "TFOPS-LIFECYCLE-REPLACE", Severity: report.SeverityMedium, Resource: &report.ResourceRef{Address: "test_resource.kept"}}
+	unknown := report.Finding{RuleID: "TFOPS-UNKNOWN-AFTER", Severity: report.SeverityInfo, Resource: &report.ResourceRef{Address: "test_resource.gone"}}
+
+	diff, err := Compare(
+		Input{Path: "old.json", ChangeSet: oldSet, Findings: []report.Finding{unknown}},
+		Input{Path: "new.json", ChangeSet: newSet, Findings: []report.Finding{replace}},
+		"test",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Diverged {
+		t.Fatal("expected plans to diverge")
+	}
+	want := Summary{Appeared: 1, Disappeared: 1, Changed: 1, NewFindings: 1, ResolvedFindings: 1}
+	if diff.Summary != want {
+		t.Fatalf("summary = %#v, want %#v", diff.Summary, want)
+	}
+	if diff.Appeared[0].Address != "test_resource.new" || diff.Disappeared[0].Address != "test_resource.gone" {
+		t.Fatalf("unexpected appeared/disappeared: %#v %#v", diff.Appeared, diff.Disappeared)
+	}
+	changed := diff.Changed[0]
+	if strings.Join(changed.Fields, ",") != "action,replace_paths,after" {
+		t.Fatalf("fields = %v", changed.Fields)
+	}
+	if changed.OldAction != "update" || changed.NewAction != "replace_destroy_create" {
+		t.Fatalf("actions = %s -> %s", changed.OldAction, changed.NewAction)
+	}
+	if len(changed.ChangedAttributes) != 1 || changed.ChangedAttributes[0] != "size" {
+		t.Fatalf("changed attributes = %v", changed.ChangedAttributes)
+	}
+	if diff.NewFindings[0].RuleID != "TFOPS-LIFECYCLE-REPLACE" || diff.ResolvedFindings[0].RuleID != "TFOPS-UNKNOWN-AFTER" {
+		t.Fatalf("unexpected finding deltas: %#v %#v", diff.NewFindings, diff.ResolvedFindings)
+	}
+}
+
+func TestCompareIdenticalPlansDoNotDiverge(t *testing.T) {
+	set := &ir.ChangeSet{Resources: []ir.ResourceChange{
+		{Address: "test_resource.a", Action: ir.NormalizeAction([]string{"update"})},
+	}}
+	diff, err := Compare(Input{ChangeSet: set}, Input{ChangeSet: set}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.Diverged {
+		t.Fatalf("identical plans diverged: %#v", diff)
+	}
+	text, err := Render(diff, report.FormatText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(text), "Plans are equivalent.") {
+		t.Fatalf("unexpected text output:\n%s", text)
+	}
+}
+
+func TestRenderDoesNotPublishValues(t *testing.T) {
+	const canary = "TFOPS_DIFF_CANARY_5c1e"
+	oldSet := &ir.ChangeSet{Resources: []ir.ResourceChange{
+		{Address: "test_resource.a", Action: ir.NormalizeAction([]string{"update"}), After: ir.SafeValue{Value: map[string]any{"v": "old"}}},
+	}}
+	newSet := &ir.ChangeSet{Resources: []ir.ResourceChange{
+		{Address: "test_resource.a", Action: ir.NormalizeAction([]string{"update"}), After: ir.SafeValue{Value: map[string]any{"v": canary}}},
+	}}
+	diff, err := Compare(Input{ChangeSet: oldSet}, Input{ChangeSet: newSet}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, format := range []report.Format{report.FormatText, report.FormatJSON, report.FormatMarkdown} {
+		out, err := Render(diff, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(out), canary) {
+			t.Fatalf("%s output published an attribute value", format)
+		}
+		if !strings.Contains(string(out), "test_resource.a") {
+			t.Fatalf("%s output omitted changed resource", format)
+		}
+	}
+}
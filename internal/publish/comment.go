@@ -0,0 +1,108 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publish
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxCommentLength is the largest issue or pull request comment body GitHub
+// accepts, in characters.
+const MaxCommentLength = 65536
+
+const (
+	detailsOpen  = "<details>\n<summary>Truncated report</summary>\n\n"
+	detailsClose = "\n\n</details>\n"
+	fenceClose   = "\n```"
+)
+
+// FormatComment prefixes body with the hidden marker that identifies the
+// sticky comment. A body that would exceed MaxCommentLength is cut at a line
+// boundary and folded into a collapsible details section below a note that
+// says how much was omitted; an open code fence is closed so the rest of the
+// comment still renders.
+func FormatComment(marker, body string) string {
+	header := markerComment(marker) + "\n"
+	full := header + body
+	total := utf8.RuneCountInString(body)
+	if utf8.RuneCountInString(full) <= MaxCommentLength {
+		return full
+	}
+
+	// Reserve room for the note as if every character were kept, so its
+	// length can only shrink once the real count is known.
+	noteFor := func(shown int) string {
+		return fmt.Sprintf("> [!NOTE]\n> This report is longer than GitHub's comment limit; showing the first %d of %d characters.\n\n", shown, total)
+	}
+	budget := MaxCommentLength - utf8.RuneCountInString(header+noteFor(total)+detailsOpen+detailsClose+fenceClose)
+	kept := truncateRunes(body, budget)
+	if index := strings.LastIndexByte(kept, '\n'); index > 0 {
+		kept = kept[:index]
+	}
+
+	var b strings.Builder
+	b.WriteString(header)
+	b.WriteString(noteFor(utf8.RuneCountInString(kept)))
+	b.WriteString(detailsOpen)
+	b.WriteString(kept)
+	if openFence(kept) {
+		b.WriteString(fenceClose)
+	}
+	b.WriteString(detailsClose)
+	return b.String()
+}
+
+func truncateRunes(value string, limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	count := 0
+	for index := range value {
+		if count == limit {
+			return value[:index]
+		}
+		count++
+	}
+	return value
+}
+
+// openFence reports whether markdown ends inside a ``` code fence.
+func openFence(markdown string) bool {
+	open := false
+	for _, line := range strings.Split(markdown, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			open = !open
+		}
+	}
+	return open
+}
+
+// PullRequestNumberFromRef extracts the pull request number from a
+// refs/pull/<number>/merge style ref such as GITHUB_REF.
+func PullRequestNumberFromRef(ref string) (int, bool) {
+	rest, ok := strings.CutPrefix(ref, "refs/pull/")
+	if !ok {
+		return 0, false
+	}
+	number, _, _ := strings.Cut(rest, "/")
+	value, err := strconv.Atoi(number)
+	if err != nil || value <= 0 {
+		return 0, false
+	}
+	return value, true
+}
@@ -0,0 +1,258 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package publish delivers rendered reports to code review systems.
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/yu/terraform-ops/internal/version"
+)
+
+const (
+	// DefaultGitHubAPIURL is the REST API root of github.com.
+	DefaultGitHubAPIURL = "https://api.github.com"
+	// DefaultMarker tags the comment terraform-ops keeps updating.
+	DefaultMarker = "terraform-ops"
+	// ActionsLogin is the author of comments posted with the GITHUB_TOKEN of a
+	// GitHub Actions run. That token cannot read the authenticated user.
+	ActionsLogin = "github-actions[bot]"
+
+	commentsPerPage  = 100
+	githubAPIVersion = "2022-11-28"
+)
+
+// PullRequest identifies the pull request a comment is published to.
+type PullRequest struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+// ParseRepository splits an owner/name repository slug such as the value of
+// GITHUB_REPOSITORY.
+func ParseRepository(slug string) (owner, repo string, err error) {
+	owner, repo, ok := strings.Cut(slug, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", fmt.Errorf("invalid repository %q: expected owner/name", slug)
+	}
+	return owner, repo, nil
+}
+
+// CommentResult describes the comment that was created or updated.
+type CommentResult struct {
+	ID      int64
+	URL     string
+	Created bool
+}
+
+// APIError is a non-2xx response from the GitHub REST API.
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("github api %s %s: status %d", e.Method, e.URL, e.StatusCode)
+	}
+	return fmt.Sprintf("github api %s %s: status %d: %s", e.Method, e.URL, e.StatusCode, e.Message)
+}
+
+// GitHubClient talks to the subset of the GitHub REST API needed to keep one
+// sticky pull request comment up to date.
+type GitHubClient struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewGitHubClient returns a client for the REST API rooted at baseURL, which
+// is DefaultGitHubAPIURL for github.com and https://HOST/api/v3 for GitHub
+// Enterprise Server. A nil httpClient uses http.DefaultClient.
+func NewGitHubClient(baseURL, token string, httpClient *http.Client) *GitHubClient {
+	if baseURL == "" {
+		baseURL = DefaultGitHubAPIURL
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &GitHubClient{baseURL: strings.TrimRight(baseURL, "/"), token: token, httpClient: httpClient}
+}
+
+type githubComment struct {
+	ID      int64      `json:"id"`
+	Body    string     `json:"body"`
+	HTMLURL string     `json:"html_url"`
+	User    githubUser `json:"user"`
+}
+
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+// UpsertComment updates the pull request comment tagged with marker, or
+// creates it when none exists, so repeated runs edit one comment instead of
+// adding a new one per push. The body is wrapped by FormatComment. Only
+// comments written by author are updated; an empty author means the
+// authenticated user, or ActionsLogin when the token cannot read it.
+func (c *GitHubClient) UpsertComment(ctx context.Context, pr PullRequest, marker, author, body string) (CommentResult, error) {
+	if err := validateMarker(marker); err != nil {
+		return CommentResult{}, err
+	}
+	if pr.Owner == "" || pr.Repo == "" || pr.Number <= 0 {
+		return CommentResult{}, fmt.Errorf("invalid pull request %s/%s#%d", pr.Owner, pr.Repo, pr.Number)
+	}
+	text := FormatComment(marker, body)
+	author, err := c.commentAuthor(ctx, author)
+	if err != nil {
+		return CommentResult{}, err
+	}
+	existing, err := c.findComment(ctx, pr, markerComment(marker), author)
+	if err != nil {
+		return CommentResult{}, err
+	}
+
+	payload := map[string]string{"body": text}
+	var saved githubComment
+	if existing != nil {
+		endpoint := fmt.Sprintf("/repos/%s/%s/issues/comments/%d", url.PathEscape(pr.Owner), url.PathEscape(pr.Repo), existing.ID)
+		if err := c.do(ctx, http.MethodPatch, endpoint, payload, &saved); err != nil {
+			return CommentResult{}, err
+		}
+		return CommentResult{ID: saved.ID, URL: saved.HTMLURL}, nil
+	}
+	if err := c.do(ctx, http.MethodPost, issueCommentsPath(pr), payload, &saved); err != nil {
+		return CommentResult{}, err
+	}
+	return CommentResult{ID: saved.ID, URL: saved.HTMLURL, Created: true}, nil
+}
+
+// commentAuthor resolves the login whose comment UpsertComment may edit.
+func (c *GitHubClient) commentAuthor(ctx context.Context, author string) (string, error) {
+	if author != "" {
+		return author, nil
+	}
+	var user githubUser
+	err := c.do(ctx, http.MethodGet, "/user", nil, &user)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden {
+		return ActionsLogin, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return user.Login, nil
+}
+
+// findComment pages through the pull request's comments and returns the
+// first one by author that starts with the marker, or nil. Comments that
+// merely quote the marker, or that someone else wrote, are never edited.
+func (c *GitHubClient) findComment(ctx context.Context, pr PullRequest, marker, author string) (*githubComment, error) {
+	for page := 1; ; page++ {
+		query := url.Values{"per_page": {strconv.Itoa(commentsPerPage)}, "page": {strconv.Itoa(page)}}
+		var comments []githubComment
+		if err := c.do(ctx, http.MethodGet, issueCommentsPath(pr)+"?"+query.Encode(), nil, &comments); err != nil {
+			return nil, err
+		}
+		for i := range comments {
+			if strings.EqualFold(comments[i].User.Login, author) && strings.HasPrefix(strings.TrimSpace(comments[i].Body), marker) {
+				return &comments[i], nil
+			}
+		}
+		if len(comments) < commentsPerPage {
+			return nil, nil
+		}
+	}
+}
+
+func issueCommentsPath(pr PullRequest) string {
+	return fmt.Sprintf("/repos/%s/%s/issues/%d/comments", url.PathEscape(pr.Owner), url.PathEscape(pr.Repo), pr.Number)
+}
+
+func (c *GitHubClient) do(ctx context.Context, method, endpoint string, payload, out any) error {
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encoded)
+	}
+	requestURL := c.baseURL + endpoint
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", githubAPIVersion)
+	req.Header.Set("User-Agent", "terraform-ops/"+version.Version)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("github api %s %s: %w", method, requestURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{Method: method, URL: requestURL, StatusCode: resp.StatusCode}
+		var message struct {
+			Message string `json:"message"`
+		}
+		if json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&message) == nil {
+			apiErr.Message = message.Message
+		}
+		return apiErr
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("github api %s %s: decode response: %w", method, requestURL, err)
+	}
+	return nil
+}
+
+func validateMarker(marker string) error {
+	if marker == "" {
+		return errors.New("comment marker must not be empty")
+	}
+	// The marker is embedded in an HTML comment; these sequences would end it
+	// early or make it ambiguous.
+	if strings.Contains(marker, "--") || strings.ContainsAny(marker, "<>\n") {
+		return fmt.Errorf("invalid comment marker %q: must not contain \"--\", \"<\", \">\" or newlines", marker)
+	}
+	return nil
+}
+
+func markerComment(marker string) string {
+	return "<!-- " + marker + " -->"
+}
@@ -0,0 +1,221 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+)
+
+// fakeGitHub is a minimal stand-in for the issue comments REST endpoints.
+type fakeGitHub struct {
+	mu    sync.Mutex
+	token string
+	// login is the token's user; empty behaves like the Actions GITHUB_TOKEN,
+	// which cannot read /user and posts as github-actions[bot].
+	login    string
+	comments []githubComment
+	nextID   int64
+	requests []string
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	if r.Header.Get("Authorization") != "Bearer "+f.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"Bad credentials"}`))
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/user":
+		if f.login == "" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"Resource not accessible by integration"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(githubUser{Login: f.login})
+	case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/infra/issues/7/comments":
+		page := 1
+		_, _ = fmt.Sscan(r.URL.Query().Get("page"), &page)
+		start := (page - 1) * commentsPerPage
+		end := min(start+commentsPerPage, len(f.comments))
+		if start > end {
+			start = end
+		}
+		_ = json.NewEncoder(w).Encode(f.comments[start:end])
+	case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/infra/issues/7/comments":
+		var payload map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		f.nextID++
+		login := f.login
+		if login == "" {
+			login = ActionsLogin
+		}
+		comment := githubComment{ID: f.nextID, Body: payload["body"], HTMLURL: fmt.Sprintf("https://github.test/acme/infra/pull/7#issuecomment-%d", f.nextID), User: githubUser{Login: login}}
+		f.comments = append(f.comments, comment)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(comment)
+	case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/repos/acme/infra/issues/comments/"):
+		var id int64
+		_, _ = fmt.Sscan(strings.TrimPrefix(r.URL.Path, "/repos/acme/infra/issues/comments/"), &id)
+		var payload map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		for i := range f.comments {
+			if f.comments[i].ID == id {
+				f.comments[i].Body = payload["body"]
+				_ = json.NewEncoder(w).Encode(f.comments[i])
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestUpsertCommentCreatesThenUpdatesStickyComment(t *testing.T) {
+	fake := &fakeGitHub{token: "test-token"}
+	// Fill more than one page with unrelated comments so the marker search has
+	// to follow pagination.
+	for i := 0; i < commentsPerPage+5; i++ {
+		fake.nextID++
+		fake.comments = append(fake.comments, githubComment{ID: fake.nextID, Body: "looks good"})
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := NewGitHubClient(server.URL, "test-token", server.Client())
+	pr := PullRequest{Owner: "acme", Repo: "infra", Number: 7}
+	first, err := client.UpsertComment(context.Background(), pr, DefaultMarker, "", "## Plan\n\nfirst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !first.Created {
+		t.Fatal("expected the first publish to create a comment")
+	}
+	second, err := client.UpsertComment(context.Background(), pr, DefaultMarker, "", "## Plan\n\nsecond")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Created || second.ID != first.ID {
+		t.Fatalf("second publish = %+v, want update of comment %d", second, first.ID)
+	}
+	if got := len(fake.comments); got != commentsPerPage+6 {
+		t.Fatalf("comments = %d, want exactly one added", got)
+	}
+	last := fake.comments[len(fake.comments)-1].Body
+	if !strings.HasPrefix(last, "<!-- terraform-ops -->\n") || !strings.HasSuffix(last, "second") {
+		t.Fatalf("unexpected comment body %q", last)
+	}
+}
+
+func TestUpsertCommentOnlyEditsOwnMarkedComment(t *testing.T) {
+	fake := &fakeGitHub{token: "test-token", login: "tfops-bot", nextID: 2, comments: []githubComment{
+		{ID: 1, Body: "Why does `<!-- terraform-ops -->` show up here?", User: githubUser{Login: "tfops-bot"}},
+		{ID: 2, Body: "<!-- terraform-ops -->\nhand-written", User: githubUser{Login: "octocat"}},
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := NewGitHubClient(server.URL, "test-token", server.Client())
+	pr := PullRequest{Owner: "acme", Repo: "infra", Number: 7}
+	result, err := client.UpsertComment(context.Background(), pr, DefaultMarker, "", "report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Created || result.ID != 3 {
+		t.Fatalf("result = %+v, want a new comment", result)
+	}
+	if fake.comments[0].Body != "Why does `<!-- terraform-ops -->` show up here?" || fake.comments[1].Body != "<!-- terraform-ops -->\nhand-written" {
+		t.Fatalf("other comments were edited: %+v", fake.comments[:2])
+	}
+
+	// An explicit author selects whose marked comment is updated.
+	result, err = client.UpsertComment(context.Background(), pr, DefaultMarker, "octocat", "report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Created || result.ID != 2 {
+		t.Fatalf("result = %+v, want update of comment 2", result)
+	}
+}
+
+func TestUpsertCommentSurfacesAPIErrors(t *testing.T) {
+	server := httptest.NewServer(&fakeGitHub{token: "expected"})
+	defer server.Close()
+
+	client := NewGitHubClient(server.URL, "wrong", server.Client())
+	_, err := client.UpsertComment(context.Background(), PullRequest{Owner: "acme", Repo: "infra", Number: 7}, DefaultMarker, "", "body")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized || apiErr.Message != "Bad credentials" {
+		t.Fatalf("err = %v, want 401 APIError", err)
+	}
+}
+
+func TestUpsertCommentRejectsInvalidMarker(t *testing.T) {
+	client := NewGitHubClient("http://127.0.0.1:0", "token", nil)
+	_, err := client.UpsertComment(context.Background(), PullRequest{Owner: "acme", Repo: "infra", Number: 7}, "a-->b", "", "body")
+	if err == nil {
+		t.Fatal("expected marker validation error")
+	}
+}
+
+func TestFormatCommentTruncatesAtGitHubLimit(t *testing.T) {
+	var body strings.Builder
+	body.WriteString("## Plan\n\n```text\n")
+	for body.Len() < 2*MaxCommentLength {
+		body.WriteString("~ aws_instance.web: ami changed — é\n")
+	}
+	body.WriteString("```\n")
+
+	got := FormatComment(DefaultMarker, body.String())
+	if n := utf8.RuneCountInString(got); n > MaxCommentLength {
+		t.Fatalf("comment has %d characters, limit is %d", n, MaxCommentLength)
+	}
+	if !strings.HasPrefix(got, "<!-- terraform-ops -->\n> [!NOTE]") {
+		t.Fatalf("missing marker or truncation note: %q", got[:80])
+	}
+	if !strings.Contains(got, "<details>") || !strings.HasSuffix(got, "</details>\n") {
+		t.Fatal("truncated report is not folded into a details section")
+	}
+	if openFence(strings.TrimSuffix(got, detailsClose)) {
+		t.Fatal("truncated report leaves a code fence open")
+	}
+}
+
+func TestFormatCommentKeepsShortBodies(t *testing.T) {
+	if got := FormatComment("tfops:prod", "ok"); got != "<!-- tfops:prod -->\nok" {
+		t.Fatalf("FormatComment() = %q", got)
+	}
+}
+
+func TestPullRequestNumberFromRef(t *testing.T) {
+	if number, ok := PullRequestNumberFromRef("refs/pull/42/merge"); !ok || number != 42 {
+		t.Fatalf("got %d, %v", number, ok)
+	}
+	if _, ok := PullRequestNumberFromRef("refs/heads/main"); ok {
+		t.Fatal("branch ref must not yield a pull request number")
+	}
+}
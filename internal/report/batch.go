@@ -0,0 +1,253 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"github.com/yu/terraform-ops/internal/ir"
+)
+
+// WorkspaceReport is the share of one plan in a batch analysis. It carries
+// the same sections as a single-plan AnalysisReport.
+type WorkspaceReport struct {
+	Path       string              `json:"path"`
+	Source     ir.SourceMetadata   `json:"source"`
+	Plan       ir.PlanMetadata     `json:"plan"`
+	Summary    Summary             `json:"summary"`
+	Findings   []Finding           `json:"findings,omitempty"`
+	Suppressed []SuppressedFinding `json:"suppressed,omitempty"`
+	Changes    []ChangeReport      `json:"changes,omitempty"`
+	Drift      []DriftReport       `json:"drift,omitempty"`
+	Checks     []CheckReport       `json:"checks,omitempty"`
+	Graph      GraphSummary        `json:"graph"`
+	Redaction  ir.RedactionSummary `json:"redaction"`
+}
+
+// Workspace projects a single-plan report onto a batch workspace entry.
+func (r AnalysisReport) Workspace(path string) WorkspaceReport {
+	return WorkspaceReport{
+		Path:       path,
+		Source:     r.Source,
+		Plan:       r.Plan,
+		Summary:    r.Summary,
+		Findings:   r.Findings,
+		Suppressed: r.Suppressed,
+		Changes:    r.Changes,
+		Drift:      r.Drift,
+		Checks:     r.Checks,
+		Graph:      r.Graph,
+		Redaction:  r.Redaction,
+	}
+}
+
+// analysisReport turns a workspace entry back into a single-plan report so
+// the single-plan renderers can be reused per workspace.
+func (w WorkspaceReport) analysisReport(parent AnalysisReport) AnalysisReport {
+	return AnalysisReport{
+		SchemaVersion: parent.SchemaVersion,
+		Tool:          parent.Tool,
+		Source:        w.Source,
+		Plan:          w.Plan,
+		Summary:       w.Summary,
+		FailOn:        parent.FailOn,
+		Findings:      w.Findings,
+		Suppressed:    w.Suppressed,
+		Changes:       w.Changes,
+		Drift:         w.Drift,
+		Checks:        w.Checks,
+		Graph:         w.Graph,
+		Redaction:     w.Redaction,
+	}
+}
+
+// Aggregate combines per-workspace reports, keyed by workspace name, into one
+// batch report. Findings and changes stay with their workspace; the top-level
+// summary, graph and redaction counts are rolled up across all of them. The
+// plan is applyable and complete only when every plan is, and errored when
+// any plan errored. The source engine is kept only when all plans agree.
+func Aggregate(workspaces map[string]WorkspaceReport, toolVersion string) AnalysisReport {
+	out := AnalysisReport{
+		SchemaVersion: SchemaVersion,
+		Tool:          ToolMetadata{Name: "terraform-ops", Version: toolVersion},
+		Plan:          ir.PlanMetadata{Applyable: true, Complete: true},
+		Workspaces:    workspaces,
+	}
+	for i, name := range WorkspaceNames(out) {
+		workspace := workspaces[name]
+		if i == 0 {
+			out.Source = workspace.Source
+			out.Redaction.Mode = workspace.Redaction.Mode
+		} else {
+			if out.Source.Engine != workspace.Source.Engine {
+				out.Source.Engine = ir.EngineUnknown
+			}
+			if out.Source.EngineVersion != workspace.Source.EngineVersion {
+				out.Source.EngineVersion = ""
+			}
+			if out.Source.PlanFormatVersion != workspace.Source.PlanFormatVersion {
+				out.Source.PlanFormatVersion = ""
+			}
+		}
+		out.Plan.Applyable = out.Plan.Applyable && workspace.Plan.Applyable
+		out.Plan.Complete = out.Plan.Complete && workspace.Plan.Complete
+		out.Plan.Errored = out.Plan.Errored || workspace.Plan.Errored
+		out.Summary = addSummaries(out.Summary, workspace.Summary)
+		out.Graph.Nodes += workspace.Graph.Nodes
+		out.Graph.Edges += workspace.Graph.Edges
+		out.Redaction.TerraformSensitivePaths += workspace.Redaction.TerraformSensitivePaths
+		out.Redaction.VariableValuesRemoved += workspace.Redaction.VariableValuesRemoved
+		out.Redaction.StrictValuesRemoved += workspace.Redaction.StrictValuesRemoved
+	}
+	return out
+}
+
+// WorkspaceNames lists the workspaces of a batch report in sorted order.
+func WorkspaceNames(report AnalysisReport) []string {
+	names := make([]string, 0, len(report.Workspaces))
+	for name := range report.Workspaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AllFindings returns the active findings of a report. For a batch report
+// these are the findings of every workspace, each tagged with its workspace
+// name, in workspace order.
+func AllFindings(report AnalysisReport) []Finding {
+	if len(report.Workspaces) == 0 {
+		return report.Findings
+	}
+	var out []Finding
+	for _, name := range WorkspaceNames(report) {
+		for _, finding := range report.Workspaces[name].Findings {
+			finding.Workspace = name
+			out = append(out, finding)
+		}
+	}
+	return out
+}
+
+// allSuppressed is AllFindings for baseline suppressions.
+func allSuppressed(report AnalysisReport) []SuppressedFinding {
+	if len(report.Workspaces) == 0 {
+		return report.Suppressed
+	}
+	var out []SuppressedFinding
+	for _, name := range WorkspaceNames(report) {
+		for _, suppressed := range report.Workspaces[name].Suppressed {
+			suppressed.Workspace = name
+			out = append(out, suppressed)
+		}
+	}
+	return out
+}
+
+func addSummaries(a, b Summary) Summary {
+	return Summary{
+		Create:  a.Create + b.Create,
+		Update:  a.Update + b.Update,
+		Delete:  a.Delete + b.Delete,
+		Replace: a.Replace + b.Replace,
+		Read:    a.Read + b.Read,
+		NoOp:    a.NoOp + b.NoOp,
+		Unknown: a.Unknown + b.Unknown,
+		Findings: FindingCounts{
+			Critical: a.Findings.Critical + b.Findings.Critical,
+			High:     a.Findings.High + b.Findings.High,
+			Medium:   a.Findings.Medium + b.Findings.Medium,
+			Low:      a.Findings.Low + b.Findings.Low,
+			Info:     a.Findings.Info + b.Findings.Info,
+		},
+	}
+}
+
+func renderTextBatch(report AnalysisReport) string {
+	var b strings.Builder
+	names := WorkspaceNames(report)
+	suppressed := len(allSuppressed(report))
+	fmt.Fprintln(&b, "Terraform/OpenTofu Change Analysis")
+	fmt.Fprintln(&b, "=================================")
+	fmt.Fprintf(&b, "Workspaces: %d\n", len(names))
+	fmt.Fprintf(&b, "Changes: +%d ~%d -%d replace:%d read:%d no-op:%d\n", report.Summary.Create, report.Summary.Update, report.Summary.Delete, report.Summary.Replace, report.Summary.Read, report.Summary.NoOp)
+	fmt.Fprintf(&b, "Findings: critical:%d high:%d medium:%d low:%d info:%d", report.Summary.Findings.Critical, report.Summary.Findings.High, report.Summary.Findings.Medium, report.Summary.Findings.Low, report.Summary.Findings.Info)
+	if suppressed > 0 {
+		fmt.Fprintf(&b, " suppressed:%d", suppressed)
+	}
+	fmt.Fprintln(&b)
+
+	width := len("Workspace")
+	for _, name := range names {
+		width = max(width, len(name))
+	}
+	fmt.Fprintf(&b, "\n%-*s  %-30s  %s\n", width, "Workspace", "Changes", "Findings")
+	for _, name := range names {
+		summary := report.Workspaces[name].Summary
+		fmt.Fprintf(&b, "%-*s  %-30s  %s\n", width, name, changeCounts(summary), findingCounts(summary.Findings))
+	}
+
+	for _, name := range names {
+		workspace := report.Workspaces[name]
+		title := fmt.Sprintf("Workspace %s (%s)", name, workspace.Path)
+		fmt.Fprintf(&b, "\n%s\n%s\n", title, strings.Repeat("=", len(title)))
+		writeText(&b, workspace.analysisReport(report))
+	}
+	return b.String()
+}
+
+func renderMarkdownBatch(report AnalysisReport) string {
+	var b strings.Builder
+	names := WorkspaceNames(report)
+	fmt.Fprintln(&b, "## Terraform/OpenTofu change analysis")
+	fmt.Fprintln(&b)
+	fmt.Fprintf(&b, "**Workspaces:** %d  \n", len(names))
+	fmt.Fprintf(&b, "**Changes:** +%d / ~%d / -%d / replace %d  \n", report.Summary.Create, report.Summary.Update, report.Summary.Delete, report.Summary.Replace)
+	fmt.Fprintf(&b, "**Findings:** %s\n", findingCounts(report.Summary.Findings))
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "| Workspace | Changes | Findings |")
+	fmt.Fprintln(&b, "|---|---|---|")
+	for _, name := range names {
+		summary := report.Workspaces[name].Summary
+		fmt.Fprintf(&b, "| `%s` | %s | %s |\n", escapeTable(name), changeCounts(summary), findingCounts(summary.Findings))
+	}
+
+	// Each workspace folds into its own section; those with findings start
+	// expanded so reviewers see them without extra clicks.
+	for _, name := range names {
+		workspace := report.Workspaces[name]
+		open := ""
+		if len(workspace.Findings) > 0 {
+			open = " open"
+		}
+		fmt.Fprintln(&b)
+		fmt.Fprintf(&b, "<details%s>\n<summary><code>%s</code> (%s)</summary>\n\n", open, html.EscapeString(name), findingCounts(workspace.Summary.Findings))
+		writeMarkdown(&b, workspace.analysisReport(report))
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b, "</details>")
+	}
+	return b.String()
+}
+
+func changeCounts(summary Summary) string {
+	return fmt.Sprintf("+%d ~%d -%d replace:%d", summary.Create, summary.Update, summary.Delete, summary.Replace)
+}
+
+func findingCounts(counts FindingCounts) string {
+	return fmt.Sprintf("critical:%d high:%d medium:%d low:%d info:%d", counts.Critical, counts.High, counts.Medium, counts.Low, counts.Info)
+}
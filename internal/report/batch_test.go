@@ -0,0 +1,97 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/yu/terraform-ops/internal/ir"
+)
+
+func TestAggregateRollsUpWorkspaces(t *testing.T) {
+	finding := Finding{
+		RuleID:   "TFOPS-LIFECYCLE-DELETE",
+		Category: CategoryLifecycle,
+		Severity: SeverityHigh,
+		Resource: &ResourceRef{Address: "aws_db_instance.main"},
+		Message:  "deleted",
+	}
+	aggregated := Aggregate(map[string]WorkspaceReport{
+		"envs/prod": {
+			Path:     "envs/prod/plan.json",
+			Source:   ir.SourceMetadata{Engine: ir.EngineTerraform, PlanFormatVersion: "1.2"},
+			Plan:     ir.PlanMetadata{Applyable: true, Complete: true},
+			Summary:  Summary{Delete: 1, Findings: FindingCounts{High: 1}},
+			Findings: []Finding{finding},
+		},
+		"envs/dev": {
+			Path:     "envs/dev/plan.json",
+			Source:   ir.SourceMetadata{Engine: ir.EngineOpenTofu, PlanFormatVersion: "1.2"},
+			Plan:     ir.PlanMetadata{Applyable: true, Complete: false},
+			Summary:  Summary{Create: 2},
+			Findings: []Finding{finding},
+		},
+	}, "v1.0.0")
+
+	if aggregated.Summary.Create != 2 || aggregated.Summary.Delete != 1 || aggregated.Summary.Findings.High != 1 {
+		t.Fatalf("summary = %+v", aggregated.Summary)
+	}
+	if aggregated.Source.Engine != ir.EngineUnknown || aggregated.Source.PlanFormatVersion != "1.2" {
+		t.Fatalf("source = %+v", aggregated.Source)
+	}
+	if !aggregated.Plan.Applyable || aggregated.Plan.Complete {
+		t.Fatalf("plan = %+v", aggregated.Plan)
+	}
+
+	all := AllFindings(aggregated)
+	if len(all) != 2 || all[0].Workspace != "envs/dev" || all[1].Workspace != "envs/prod" {
+		t.Fatalf("AllFindings() = %+v", all)
+	}
+	if findingFingerprint(all[0]) == findingFingerprint(all[1]) {
+		t.Fatal("equal findings in different workspaces must not share a SARIF fingerprint")
+	}
+
+	text, err := Render(aggregated, FormatText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Workspaces: 2", "Workspace envs/dev (envs/dev/plan.json)", "Workspace envs/prod (envs/prod/plan.json)", "[HIGH] TFOPS-LIFECYCLE-DELETE aws_db_instance.main"} {
+		if !strings.Contains(string(text), want) {
+			t.Fatalf("text report is missing %q:\n%s", want, text)
+		}
+	}
+	markdown, err := Render(aggregated, FormatMarkdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(markdown), "| `envs/prod` | +0 ~0 -1 replace:0 | critical:0 high:1 medium:0 low:0 info:0 |") ||
+		!strings.Contains(string(markdown), "<details open>\n<summary><code>envs/prod</code>") {
+		t.Fatalf("unexpected markdown report:\n%s", markdown)
+	}
+
+	data, err := Render(aggregated, FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded AnalysisReport
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Workspaces) != 2 || decoded.Workspaces["envs/prod"].Findings[0].Workspace != "" {
+		t.Fatalf("unexpected JSON workspaces: %s", data)
+	}
+}
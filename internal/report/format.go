@@ -27,6 +27,8 @@ const (
 	FormatText     Format = "text"
 	FormatJSON     Format = "json"
 	FormatMarkdown Format = "markdown"
+	FormatSARIF    Format = "sarif"
+	FormatJUnit    Format = "junit"
 )
 
 func Render(report AnalysisReport, format Format) ([]byte, error) {
@@ -35,6 +37,10 @@ func Render(report AnalysisReport, format Format) ([]byte, error) {
 		return renderJSON(report)
 	case FormatMarkdown:
 		return []byte(renderMarkdown(report)), nil
+	case FormatSARIF:
+		return renderSARIF(report)
+	case FormatJUnit:
+		return renderJUnit(report)
 	case FormatText, "":
 		return []byte(renderText(report)), nil
 	default:
@@ -53,91 +59,163 @@ func renderJSON(report AnalysisReport) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// evidenceLine renders evidence as "kind: path (description)", or an empty
+// string when it has neither a path nor a description.
+func evidenceLine(evidence Evidence) string {
+	switch {
+	case evidence.Path != "" && evidence.Description != "":
+		return fmt.Sprintf("%s: %s (%s)", evidence.Kind, evidence.Path, evidence.Description)
+	case evidence.Path != "":
+		return fmt.Sprintf("%s: %s", evidence.Kind, evidence.Path)
+	case evidence.Description != "":
+		return fmt.Sprintf("%s: %s", evidence.Kind, evidence.Description)
+	default:
+		return ""
+	}
+}
+
 func renderText(report AnalysisReport) string {
+	if len(report.Workspaces) > 0 {
+		return renderTextBatch(report)
+	}
 	var b strings.Builder
 	fmt.Fprintln(&b, "Terraform/OpenTofu Change Analysis")
 	fmt.Fprintln(&b, "=================================")
-	fmt.Fprintf(&b, "Engine: %s", report.Source.Engine)
+	writeText(&b, report)
+	return b.String()
+}
+
+// writeText writes the body of a single-plan text report below its title.
+func writeText(b *strings.Builder, report AnalysisReport) {
+	fmt.Fprintf(b, "Engine: %s", report.Source.Engine)
 	if report.Source.EngineVersion != "" {
-		fmt.Fprintf(&b, " %s", report.Source.EngineVersion)
+		fmt.Fprintf(b, " %s", report.Source.EngineVersion)
 	}
-	fmt.Fprintln(&b)
-	fmt.Fprintf(&b, "Plan format: %s\n", report.Source.PlanFormatVersion)
-	fmt.Fprintf(&b, "Applyable: %t  Complete: %t  Errored: %t\n\n", report.Plan.Applyable, report.Plan.Complete, report.Plan.Errored)
-	fmt.Fprintf(&b, "Changes: +%d ~%d -%d replace:%d read:%d no-op:%d\n", report.Summary.Create, report.Summary.Update, report.Summary.Delete, report.Summary.Replace, report.Summary.Read, report.Summary.NoOp)
-	fmt.Fprintf(&b, "Findings: critical:%d high:%d medium:%d low:%d info:%d\n", report.Summary.Findings.Critical, report.Summary.Findings.High, report.Summary.Findings.Medium, report.Summary.Findings.Low, report.Summary.Findings.Info)
-	fmt.Fprintf(&b, "Graph: %d nodes, %d edges\n", report.Graph.Nodes, report.Graph.Edges)
-	fmt.Fprintf(&b, "Redaction: %s (%d sensitive paths, %d variable values removed)\n", report.Redaction.Mode, report.Redaction.TerraformSensitivePaths, report.Redaction.VariableValuesRemoved)
+	fmt.Fprintln(b)
+	fmt.Fprintf(b, "Plan format: %s\n", report.Source.PlanFormatVersion)
+	fmt.Fprintf(b, "Applyable: %t  Complete: %t  Errored: %t\n\n", report.Plan.Applyable, report.Plan.Complete, report.Plan.Errored)
+	fmt.Fprintf(b, "Changes: +%d ~%d -%d replace:%d read:%d no-op:%d\n", report.Summary.Create, report.Summary.Update, report.Summary.Delete, report.Summary.Replace, report.Summary.Read, report.Summary.NoOp)
+	fmt.Fprintf(b, "Findings: critical:%d high:%d medium:%d low:%d info:%d", report.Summary.Findings.Critical, report.Summary.Findings.High, report.Summary.Findings.Medium, report.Summary.Findings.Low, report.Summary.Findings.Info)
+	if len(report.Suppressed) > 0 {
+		fmt.Fprintf(b, " suppressed:%d", len(report.Suppressed))
+	}
+	fmt.Fprintln(b)
+	fmt.Fprintf(b, "Graph: %d nodes, %d edges\n", report.Graph.Nodes, report.Graph.Edges)
+	fmt.Fprintf(b, "Redaction: %s (%d sensitive paths, %d variable values removed)\n", report.Redaction.Mode, report.Redaction.TerraformSensitivePaths, report.Redaction.VariableValuesRemoved)
 
 	if len(report.Findings) > 0 {
-		fmt.Fprintln(&b, "\nFindings")
-		fmt.Fprintln(&b, "--------")
+		fmt.Fprintln(b, "\nFindings")
+		fmt.Fprintln(b, "--------")
 		for _, finding := range report.Findings {
 			resource := ""
 			if finding.Resource != nil {
 				resource = " " + finding.Resource.Address
 			}
-			fmt.Fprintf(&b, "[%s] %s%s: %s\n", strings.ToUpper(string(finding.Severity)), finding.RuleID, resource, finding.Message)
+			fmt.Fprintf(b, "[%s] %s%s: %s\n", strings.ToUpper(string(finding.Severity)), finding.RuleID, resource, finding.Message)
 			for _, evidence := range finding.Evidence {
-				if evidence.Path != "" {
-					fmt.Fprintf(&b, "  - %s: %s\n", evidence.Kind, evidence.Path)
-				} else if evidence.Description != "" {
-					fmt.Fprintf(&b, "  - %s: %s\n", evidence.Kind, evidence.Description)
+				if line := evidenceLine(evidence); line != "" {
+					fmt.Fprintf(b, "  - %s\n", line)
 				}
 			}
 		}
 	}
 
+	if len(report.Suppressed) > 0 {
+		fmt.Fprintln(b, "\nSuppressed findings")
+		fmt.Fprintln(b, "-------------------")
+		for _, suppressed := range report.Suppressed {
+			resource := ""
+			if suppressed.Resource != nil {
+				resource = " " + suppressed.Resource.Address
+			}
+			fmt.Fprintf(b, "[%s] %s%s: %s\n", strings.ToUpper(string(suppressed.Severity)), suppressed.RuleID, resource, suppressed.Message)
+			if suppressed.Justification != "" {
+				fmt.Fprintf(b, "  justification: %s\n", suppressed.Justification)
+			}
+			if suppressed.Expires != "" {
+				fmt.Fprintf(b, "  expires: %s\n", suppressed.Expires)
+			}
+		}
+	}
+
 	if len(report.Changes) > 0 {
-		fmt.Fprintln(&b, "\nChanges")
-		fmt.Fprintln(&b, "-------")
+		fmt.Fprintln(b, "\nChanges")
+		fmt.Fprintln(b, "-------")
 		for _, change := range report.Changes {
-			fmt.Fprintf(&b, "%s  %s", change.Action, change.Address)
+			fmt.Fprintf(b, "%s  %s", change.Action, change.Address)
 			if change.ActionReason != "" {
-				fmt.Fprintf(&b, " (%s)", change.ActionReason)
+				fmt.Fprintf(b, " (%s)", change.ActionReason)
 			}
-			fmt.Fprintln(&b)
+			fmt.Fprintln(b)
 			if len(change.ReplacePaths) > 0 {
-				fmt.Fprintf(&b, "  replacement paths: %s\n", strings.Join(change.ReplacePaths, ", "))
+				fmt.Fprintf(b, "  replacement paths: %s\n", strings.Join(change.ReplacePaths, ", "))
 			}
-			if change.BlastRadius.DirectDependents > 0 || change.BlastRadius.TransitiveDependents > 0 {
-				fmt.Fprintf(&b, "  blast radius: %d direct / %d transitive dependents\n", change.BlastRadius.DirectDependents, change.BlastRadius.TransitiveDependents)
+			if change.BlastRadius.DirectDependents > 0 || change.BlastRadius.TransitiveDependents > 0 || change.BlastRadius.UnchangedDependents > 0 {
+				fmt.Fprintf(b, "  blast radius: %d direct / %d transitive dependents", change.BlastRadius.DirectDependents, change.BlastRadius.TransitiveDependents)
+				if change.BlastRadius.UnchangedDependents > 0 {
+					fmt.Fprintf(b, " / %d unchanged dependents", change.BlastRadius.UnchangedDependents)
+				}
+				fmt.Fprintln(b)
 			}
 		}
 	}
-	return b.String()
 }
 
 func renderMarkdown(report AnalysisReport) string {
+	if len(report.Workspaces) > 0 {
+		return renderMarkdownBatch(report)
+	}
 	var b strings.Builder
 	fmt.Fprintln(&b, "## Terraform/OpenTofu change analysis")
 	fmt.Fprintln(&b)
-	fmt.Fprintf(&b, "**Engine:** `%s`  \n", report.Source.Engine)
-	fmt.Fprintf(&b, "**Plan:** applyable `%t`, complete `%t`, errored `%t`  \n", report.Plan.Applyable, report.Plan.Complete, report.Plan.Errored)
-	fmt.Fprintf(&b, "**Changes:** +%d / ~%d / -%d / replace %d  \n", report.Summary.Create, report.Summary.Update, report.Summary.Delete, report.Summary.Replace)
-	fmt.Fprintf(&b, "**Redaction:** `%s`; %d sensitive paths; %d variable values removed\n", report.Redaction.Mode, report.Redaction.TerraformSensitivePaths, report.Redaction.VariableValuesRemoved)
+	writeMarkdown(&b, report)
+	return b.String()
+}
+
+// writeMarkdown writes the body of a single-plan markdown report below its
+// heading.
+func writeMarkdown(b *strings.Builder, report AnalysisReport) {
+	fmt.Fprintf(b, "**Engine:** `%s`  \n", report.Source.Engine)
+	fmt.Fprintf(b, "**Plan:** applyable `%t`, complete `%t`, errored `%t`  \n", report.Plan.Applyable, report.Plan.Complete, report.Plan.Errored)
+	fmt.Fprintf(b, "**Changes:** +%d / ~%d / -%d / replace %d  \n", report.Summary.Create, report.Summary.Update, report.Summary.Delete, report.Summary.Replace)
+	fmt.Fprintf(b, "**Redaction:** `%s`; %d sensitive paths; %d variable values removed\n", report.Redaction.Mode, report.Redaction.TerraformSensitivePaths, report.Redaction.VariableValuesRemoved)
 
 	if len(report.Findings) > 0 {
-		fmt.Fprintln(&b)
-		fmt.Fprintln(&b, "### Findings")
-		fmt.Fprintln(&b)
-		fmt.Fprintln(&b, "| Severity | Rule | Resource | Finding |")
-		fmt.Fprintln(&b, "|---|---|---|---|")
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, "### Findings")
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, "| Severity | Rule | Resource | Finding |")
+		fmt.Fprintln(b, "|---|---|---|---|")
 		for _, finding := range report.Findings {
 			resource := ""
 			if finding.Resource != nil {
 				resource = "`" + escapeTable(finding.Resource.Address) + "`"
 			}
-			fmt.Fprintf(&b, "| %s | `%s` | %s | %s |\n", strings.ToUpper(string(finding.Severity)), escapeTable(finding.RuleID), resource, escapeTable(finding.Message))
+			fmt.Fprintf(b, "| %s | `%s` | %s | %s |\n", strings.ToUpper(string(finding.Severity)), escapeTable(finding.RuleID), resource, escapeTable(finding.Message))
+		}
+	}
+
+	if len(report.Suppressed) > 0 {
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, "### Suppressed findings")
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, "| Severity | Rule | Resource | Justification | Expires |")
+		fmt.Fprintln(b, "|---|---|---|---|---|")
+		for _, suppressed := range report.Suppressed {
+			resource := ""
+			if suppressed.Resource != nil {
+				resource = "`" + escapeTable(suppressed.Resource.Address) + "`"
+			}
+			fmt.Fprintf(b, "| %s | `%s` | %s | %s | %s |\n", strings.ToUpper(string(suppressed.Severity)), escapeTable(suppressed.RuleID), resource, escapeTable(suppressed.Justification), suppressed.Expires)
 		}
 	}
 
 	if len(report.Changes) > 0 {
-		fmt.Fprintln(&b)
-		fmt.Fprintln(&b, "### Changes")
-		fmt.Fprintln(&b)
-		fmt.Fprintln(&b, "| Action | Resource | Why / replacement paths | Blast radius |")
-		fmt.Fprintln(&b, "|---|---|---|---:|")
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, "### Changes")
+		fmt.Fprintln(b)
+		fmt.Fprintln(b, "| Action | Resource | Why / replacement paths | Blast radius |")
+		fmt.Fprintln(b, "|---|---|---|---:|")
 		for _, change := range report.Changes {
 			why := change.ActionReason
 			if len(change.ReplacePaths) > 0 {
@@ -146,10 +224,13 @@ func renderMarkdown(report AnalysisReport) string {
 				}
 				why += strings.Join(change.ReplacePaths, ", ")
 			}
-			fmt.Fprintf(&b, "| `%s` | `%s` | %s | %d direct / %d transitive |\n", change.Action, escapeTable(change.Address), escapeTable(why), change.BlastRadius.DirectDependents, change.BlastRadius.TransitiveDependents)
+			blast := fmt.Sprintf("%d direct / %d transitive", change.BlastRadius.DirectDependents, change.BlastRadius.TransitiveDependents)
+			if change.BlastRadius.UnchangedDependents > 0 {
+				blast += fmt.Sprintf(" / %d unchanged", change.BlastRadius.UnchangedDependents)
+			}
+			fmt.Fprintf(b, "| `%s` | `%s` | %s | %s |\n", change.Action, escapeTable(change.Address), escapeTable(why), blast)
 		}
 	}
-	return b.String()
 }
 
 func escapeTable(value string) string {
@@ -0,0 +1,192 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yu/terraform-ops/internal/ir"
+)
+
+// The JUnit types below follow the de facto schema that Jenkins, GitLab and
+// most CI test dashboards accept.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// renderJUnit emits one test suite per finding category. Each finding is a
+// test case named after its resource: a failure when its severity meets
+// report.FailOn and skipped otherwise, so dashboards show lower-severity
+// findings without failing the build. Baseline suppressions are skipped too.
+// Passing Terraform checks become passing test cases in the validation suite.
+// Batch reports are flattened, with each test case named after its workspace.
+func renderJUnit(report AnalysisReport) ([]byte, error) {
+	suites := make(map[Category]*junitTestSuite)
+	suite := func(category Category) *junitTestSuite {
+		if existing, ok := suites[category]; ok {
+			return existing
+		}
+		created := &junitTestSuite{Name: "terraform-ops." + string(category)}
+		suites[category] = created
+		return created
+	}
+
+	for _, finding := range AllFindings(report) {
+		testCase := junitFindingCase(finding)
+		if MeetsThreshold(finding.Severity, report.FailOn) {
+			testCase.Failure = &junitFailure{
+				Message: finding.Message,
+				Type:    strings.ToUpper(string(finding.Severity)),
+				Body:    junitFailureBody(finding),
+			}
+		} else {
+			testCase.Skipped = &junitSkipped{
+				Message: fmt.Sprintf("%s: %s (below the fail-on threshold)", strings.ToUpper(string(finding.Severity)), finding.Message),
+			}
+		}
+		suite(finding.Category).TestCases = append(suite(finding.Category).TestCases, testCase)
+	}
+	for _, suppressed := range allSuppressed(report) {
+		testCase := junitFindingCase(suppressed.Finding)
+		message := "suppressed by baseline"
+		if suppressed.Justification != "" {
+			message += ": " + suppressed.Justification
+		}
+		testCase.Skipped = &junitSkipped{Message: message}
+		suite(suppressed.Category).TestCases = append(suite(suppressed.Category).TestCases, testCase)
+	}
+	addChecks := func(workspace string, checks []CheckReport) {
+		for _, check := range checks {
+			if check.Status != string(ir.CheckPass) {
+				continue
+			}
+			kind := check.Kind
+			if kind == "" {
+				kind = "check"
+			}
+			suite(CategoryValidation).TestCases = append(suite(CategoryValidation).TestCases, junitTestCase{
+				Name:      junitCaseName(workspace, check.Address),
+				ClassName: "terraform." + kind,
+			})
+		}
+	}
+	addChecks("", report.Checks)
+	for _, name := range WorkspaceNames(report) {
+		addChecks(name, report.Workspaces[name].Checks)
+	}
+
+	categories := make([]string, 0, len(suites))
+	for category := range suites {
+		categories = append(categories, string(category))
+	}
+	sort.Strings(categories)
+	out := junitTestSuites{Name: report.Tool.Name, Suites: []junitTestSuite{}}
+	for _, category := range categories {
+		current := suites[Category(category)]
+		for _, testCase := range current.TestCases {
+			current.Tests++
+			switch {
+			case testCase.Failure != nil:
+				current.Failures++
+			case testCase.Skipped != nil:
+				current.Skipped++
+			}
+		}
+		out.Tests += current.Tests
+		out.Failures += current.Failures
+		out.Skipped += current.Skipped
+		out.Suites = append(out.Suites, *current)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+func junitFindingCase(finding Finding) junitTestCase {
+	name := resourceAddress(finding.Resource)
+	if name == "" {
+		name = finding.Title
+	}
+	return junitTestCase{Name: junitCaseName(finding.Workspace, name), ClassName: finding.RuleID}
+}
+
+// junitCaseName prefixes batch test cases with their workspace.
+func junitCaseName(workspace, name string) string {
+	if workspace == "" {
+		return name
+	}
+	return workspace + ": " + name
+}
+
+func junitFailureBody(finding Finding) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", finding.Title)
+	fmt.Fprintf(&b, "severity: %s, confidence: %s\n", finding.Severity, finding.Confidence)
+	if finding.Resource != nil && finding.Resource.Location != nil && finding.Resource.Location.File != "" {
+		fmt.Fprintf(&b, "location: %s:%d\n", finding.Resource.Location.File, finding.Resource.Location.StartLine)
+	}
+	for _, evidence := range finding.Evidence {
+		if line := evidenceLine(evidence); line != "" {
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+	}
+	if finding.Remediation != "" {
+		fmt.Fprintf(&b, "remediation: %s\n", finding.Remediation)
+	}
+	return b.String()
+}
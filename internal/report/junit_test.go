@@ -0,0 +1,128 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestRenderJUnit(t *testing.T) {
+	analysisReport := AnalysisReport{
+		Tool:   ToolMetadata{Name: "terraform-ops", Version: "v1.2.3"},
+		FailOn: SeverityHigh,
+		Findings: []Finding{
+			{
+				RuleID:     "TFOPS-STATEFUL-DESTROY",
+				Title:      "Stateful resource destroyed",
+				Category:   CategoryLifecycle,
+				Severity:   SeverityCritical,
+				Confidence: ConfidenceStrong,
+				Resource:   &ResourceRef{Address: "aws_kms_key.main"},
+				Evidence:   []Evidence{{Kind: "action", Description: "delete"}},
+				Message:    "A KMS key is planned for deletion & <cannot> be restored.",
+			},
+			{
+				RuleID:   "TFOPS-LIFECYCLE-REPLACE",
+				Title:    "Managed resource replacement",
+				Category: CategoryLifecycle,
+				Severity: SeverityMedium,
+				Resource: &ResourceRef{Address: "aws_instance.web"},
+				Message:  "A managed resource is planned for replacement.",
+			},
+			{
+				RuleID:   "TFOPS-UNKNOWN-AFTER-APPLY",
+				Title:    "Values known only after apply",
+				Category: CategoryUncertainty,
+				Severity: SeverityInfo,
+				Message:  "Some values are unknown.",
+			},
+		},
+		Suppressed: []SuppressedFinding{{
+			Finding: Finding{
+				RuleID:   "TFOPS-LIFECYCLE-DELETE",
+				Category: CategoryLifecycle,
+				Severity: SeverityHigh,
+				Resource: &ResourceRef{Address: "aws_s3_bucket.old"},
+			},
+			Justification: "decommissioned",
+		}},
+		Checks: []CheckReport{
+			{Address: "check.health", Kind: "check", Status: "pass"},
+			{Address: "aws_instance.web", Kind: "resource", Status: "fail"},
+		},
+	}
+	data, err := Render(analysisReport, FormatJUnit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "<?xml") {
+		t.Fatalf("missing XML header: %s", data)
+	}
+	var suites junitTestSuites
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		t.Fatal(err)
+	}
+	if suites.Tests != 5 || suites.Failures != 1 || suites.Skipped != 3 {
+		t.Fatalf("totals = tests:%d failures:%d skipped:%d", suites.Tests, suites.Failures, suites.Skipped)
+	}
+	var names []string
+	for _, suite := range suites.Suites {
+		names = append(names, suite.Name)
+	}
+	if got := strings.Join(names, ","); got != "terraform-ops.lifecycle,terraform-ops.uncertainty,terraform-ops.validation" {
+		t.Fatalf("suites = %s", got)
+	}
+
+	lifecycle := suites.Suites[0]
+	if lifecycle.Tests != 3 || lifecycle.Failures != 1 || lifecycle.Skipped != 2 {
+		t.Fatalf("lifecycle suite = %+v", lifecycle)
+	}
+	failed := lifecycle.TestCases[0]
+	if failed.ClassName != "TFOPS-STATEFUL-DESTROY" || failed.Name != "aws_kms_key.main" || failed.Failure == nil {
+		t.Fatalf("unexpected failed case: %+v", failed)
+	}
+	if failed.Failure.Message != "A KMS key is planned for deletion & <cannot> be restored." || !strings.Contains(failed.Failure.Body, "- action: delete") {
+		t.Fatalf("unexpected failure: %+v", failed.Failure)
+	}
+	if skipped := lifecycle.TestCases[2].Skipped; skipped == nil || skipped.Message != "suppressed by baseline: decommissioned" {
+		t.Fatalf("suppressed finding is not skipped: %+v", lifecycle.TestCases[2])
+	}
+	if got := suites.Suites[1].TestCases[0].Name; got != "Values known only after apply" {
+		t.Fatalf("finding without a resource should be named by title, got %q", got)
+	}
+	validation := suites.Suites[2]
+	if len(validation.TestCases) != 1 || validation.TestCases[0].Name != "check.health" || validation.TestCases[0].Failure != nil || validation.TestCases[0].Skipped != nil {
+		t.Fatalf("expected one passing check case, got %+v", validation.TestCases)
+	}
+}
+
+func TestRenderJUnitWithoutThresholdSkipsEveryFinding(t *testing.T) {
+	data, err := Render(AnalysisReport{
+		Tool:     ToolMetadata{Name: "terraform-ops"},
+		Findings: []Finding{{RuleID: "TFOPS-PLAN-ERRORED", Category: CategoryPlan, Severity: SeverityCritical, Message: "errored"}},
+	}, FormatJUnit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var suites junitTestSuites
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		t.Fatal(err)
+	}
+	if suites.Failures != 0 || suites.Skipped != 1 {
+		t.Fatalf("totals = failures:%d skipped:%d", suites.Failures, suites.Skipped)
+	}
+}
@@ -15,7 +15,9 @@
 package report
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/yu/terraform-ops/internal/ir"
 )
@@ -50,6 +52,7 @@ const (
 	CategoryDrift       Category = "drift"
 	CategorySensitivity Category = "sensitivity"
 	CategoryUncertainty Category = "uncertainty"
+	CategoryPolicy      Category = "policy"
 )
 
 type ToolMetadata struct {
@@ -58,17 +61,38 @@ type ToolMetadata struct {
 }
 
 type ResourceRef struct {
-	Address string `json:"address"`
-	Type    string `json:"type,omitempty"`
+	Address  string          `json:"address"`
+	Type     string          `json:"type,omitempty"`
+	Location *SourceLocation `json:"location,omitempty"`
+}
+
+// SourceLocation points at the configuration block that declares a resource.
+// Plan JSON carries no source positions, so it is only populated when the
+// caller resolves addresses against the Terraform/OpenTofu configuration.
+type SourceLocation struct {
+	File        string `json:"file"`
+	StartLine   int    `json:"start_line,omitempty"`
+	StartColumn int    `json:"start_column,omitempty"`
+	EndLine     int    `json:"end_line,omitempty"`
+	EndColumn   int    `json:"end_column,omitempty"`
 }
 
+// Evidence supports a finding. Before and After carry an already-sanitized
+// rendering of the value at Path when the evidence describes a value change;
+// Unknown marks an after value that is only known after apply.
 type Evidence struct {
 	Kind        string `json:"kind"`
 	Description string `json:"description,omitempty"`
 	Path        string `json:"path,omitempty"`
+	Before      string `json:"before,omitempty"`
+	After       string `json:"after,omitempty"`
+	Unknown     bool   `json:"unknown,omitempty"`
 	Source      string `json:"source,omitempty"`
 }
 
+// Finding is one analyzer result. Workspace names the plan a finding came
+// from when renderers flatten a batch report; it is empty for single-plan
+// reports and inside AnalysisReport.Workspaces.
 type Finding struct {
 	RuleID      string       `json:"rule_id"`
 	Title       string       `json:"title"`
@@ -79,6 +103,15 @@ type Finding struct {
 	Evidence    []Evidence   `json:"evidence,omitempty"`
 	Message     string       `json:"message"`
 	Remediation string       `json:"remediation,omitempty"`
+	Workspace   string       `json:"workspace,omitempty"`
+}
+
+// SuppressedFinding is a finding accepted by a baseline entry. It stays in
+// the report for visibility but is excluded from counts and thresholds.
+type SuppressedFinding struct {
+	Finding
+	Justification string `json:"justification,omitempty"`
+	Expires       string `json:"expires,omitempty"`
 }
 
 type FindingCounts struct {
@@ -100,9 +133,13 @@ type Summary struct {
 	Findings FindingCounts `json:"findings"`
 }
 
+// BlastRadius counts the dependents of a changed resource. Direct and
+// transitive dependents come from the change graph; UnchangedDependents counts
+// prior-state resources that the plan leaves untouched but that depend on it.
 type BlastRadius struct {
 	DirectDependents     int `json:"direct_dependents"`
 	TransitiveDependents int `json:"transitive_dependents"`
+	UnchangedDependents  int `json:"unchanged_dependents,omitempty"`
 }
 
 type ChangeReport struct {
@@ -110,6 +147,7 @@ type ChangeReport struct {
 	PreviousAddress string      `json:"previous_address,omitempty"`
 	Type            string      `json:"type"`
 	Mode            string      `json:"mode"`
+	ProviderName    string      `json:"provider_name,omitempty"`
 	Action          string      `json:"action"`
 	RawActions      []string    `json:"raw_actions"`
 	ActionReason    string      `json:"action_reason,omitempty"`
@@ -136,18 +174,26 @@ type GraphSummary struct {
 	Edges int `json:"edges"`
 }
 
+// AnalysisReport is the analyze output contract. FailOn records the severity
+// threshold the run gates on (empty when it does not gate); JUnit output
+// reports findings at or above it as failures. Workspaces holds the per-plan
+// reports of a batch analysis keyed by workspace name, in which case the
+// top-level findings are empty and the summary is rolled up across plans.
 type AnalysisReport struct {
-	SchemaVersion string              `json:"schema_version"`
-	Tool          ToolMetadata        `json:"tool"`
-	Source        ir.SourceMetadata   `json:"source"`
-	Plan          ir.PlanMetadata     `json:"plan"`
-	Summary       Summary             `json:"summary"`
-	Findings      []Finding           `json:"findings,omitempty"`
-	Changes       []ChangeReport      `json:"changes,omitempty"`
-	Drift         []DriftReport       `json:"drift,omitempty"`
-	Checks        []CheckReport       `json:"checks,omitempty"`
-	Graph         GraphSummary        `json:"graph"`
-	Redaction     ir.RedactionSummary `json:"redaction"`
+	SchemaVersion string                     `json:"schema_version"`
+	Tool          ToolMetadata               `json:"tool"`
+	Source        ir.SourceMetadata          `json:"source"`
+	Plan          ir.PlanMetadata            `json:"plan"`
+	Summary       Summary                    `json:"summary"`
+	FailOn        Severity                   `json:"fail_on,omitempty"`
+	Findings      []Finding                  `json:"findings,omitempty"`
+	Suppressed    []SuppressedFinding        `json:"suppressed,omitempty"`
+	Changes       []ChangeReport             `json:"changes,omitempty"`
+	Drift         []DriftReport              `json:"drift,omitempty"`
+	Checks        []CheckReport              `json:"checks,omitempty"`
+	Graph         GraphSummary               `json:"graph"`
+	Redaction     ir.RedactionSummary        `json:"redaction"`
+	Workspaces    map[string]WorkspaceReport `json:"workspaces,omitempty"`
 }
 
 func Build(changeSet *ir.ChangeSet, findings []Finding, toolVersion string) AnalysisReport {
@@ -164,6 +210,7 @@ func Build(changeSet *ir.ChangeSet, findings []Finding, toolVersion string) Anal
 		Redaction: changeSet.Redaction,
 	}
 
+	unchanged := changeSet.UnchangedIndex()
 	for _, resource := range changeSet.Resources {
 		switch resource.Action.Semantic {
 		case ir.ActionCreate:
@@ -186,6 +233,7 @@ func Build(changeSet *ir.ChangeSet, findings []Finding, toolVersion string) Anal
 			Address:        string(resource.Address),
 			Type:           resource.Type,
 			Mode:           string(resource.Mode),
+			ProviderName:   resource.ProviderName,
 			Action:         string(resource.Action.Semantic),
 			RawActions:     append([]string(nil), resource.Action.Raw...),
 			ActionReason:   resource.ActionReason,
@@ -195,6 +243,7 @@ func Build(changeSet *ir.ChangeSet, findings []Finding, toolVersion string) Anal
 			BlastRadius: BlastRadius{
 				DirectDependents:     len(changeSet.Graph.DirectDependents(ir.NodeID(resource.Address))),
 				TransitiveDependents: len(changeSet.Graph.TransitiveDependents(ir.NodeID(resource.Address))),
+				UnchangedDependents:  len(unchanged.Dependents(resource.Address)),
 			},
 		}
 		if resource.PreviousAddress != nil {
@@ -243,21 +292,25 @@ func Build(changeSet *ir.ChangeSet, findings []Finding, toolVersion string) Anal
 }
 
 func Sort(report *AnalysisReport) {
-	sort.Slice(report.Findings, func(i, j int) bool {
-		a, b := report.Findings[i], report.Findings[j]
-		if severityRank(a.Severity) != severityRank(b.Severity) {
-			return severityRank(a.Severity) > severityRank(b.Severity)
-		}
-		if a.RuleID != b.RuleID {
-			return a.RuleID < b.RuleID
-		}
-		return resourceAddress(a.Resource) < resourceAddress(b.Resource)
+	sort.Slice(report.Findings, func(i, j int) bool { return findingLess(report.Findings[i], report.Findings[j]) })
+	sort.Slice(report.Suppressed, func(i, j int) bool {
+		return findingLess(report.Suppressed[i].Finding, report.Suppressed[j].Finding)
 	})
 	sort.Slice(report.Changes, func(i, j int) bool { return report.Changes[i].Address < report.Changes[j].Address })
 	sort.Slice(report.Drift, func(i, j int) bool { return report.Drift[i].Address < report.Drift[j].Address })
 	sort.Slice(report.Checks, func(i, j int) bool { return report.Checks[i].Address < report.Checks[j].Address })
 }
 
+func findingLess(a, b Finding) bool {
+	if severityRank(a.Severity) != severityRank(b.Severity) {
+		return severityRank(a.Severity) > severityRank(b.Severity)
+	}
+	if a.RuleID != b.RuleID {
+		return a.RuleID < b.RuleID
+	}
+	return resourceAddress(a.Resource) < resourceAddress(b.Resource)
+}
+
 func HighestSeverity(findings []Finding) Severity {
 	highest := SeverityInfo
 	if len(findings) == 0 {
@@ -271,6 +324,16 @@ func HighestSeverity(findings []Finding) Severity {
 	return highest
 }
 
+// ParseSeverity converts a case-insensitive severity name into a Severity.
+func ParseSeverity(value string) (Severity, error) {
+	switch severity := Severity(strings.ToLower(strings.TrimSpace(value))); severity {
+	case SeverityInfo, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+		return severity, nil
+	default:
+		return "", fmt.Errorf("unsupported severity %q: use info, low, medium, high, or critical", value)
+	}
+}
+
 func MeetsThreshold(severity, threshold Severity) bool {
 	if threshold == "" {
 		return false
@@ -0,0 +1,282 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+)
+
+const (
+	sarifVersion        = "2.1.0"
+	sarifSchema         = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifInformationURI = "https://github.com/yu-iskw/terraform-ops"
+)
+
+// The SARIF types below cover the subset of SARIF 2.1.0 that terraform-ops
+// emits. Field names follow the specification.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name,omitempty"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	FullDescription      *sarifMessage      `json:"fullDescription,omitempty"`
+	Help                 *sarifMessage      `json:"help,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	Properties           sarifRuleProps     `json:"properties"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifRuleProps struct {
+	Category         Category `json:"category"`
+	SecuritySeverity string   `json:"security-severity"`
+	Tags             []string `json:"tags"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string             `json:"ruleId"`
+	RuleIndex           int                `json:"ruleIndex"`
+	Level               string             `json:"level"`
+	Message             sarifMessage       `json:"message"`
+	Locations           []sarifLocation    `json:"locations,omitempty"`
+	Suppressions        []sarifSuppression `json:"suppressions,omitempty"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints"`
+	Properties          sarifResultProps   `json:"properties"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Status        string `json:"status"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type sarifResultProps struct {
+	Severity    Severity   `json:"severity"`
+	Confidence  Confidence `json:"confidence"`
+	Evidence    []Evidence `json:"evidence,omitempty"`
+	Remediation string     `json:"remediation,omitempty"`
+	Workspace   string     `json:"workspace,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+func renderSARIF(report AnalysisReport) ([]byte, error) {
+	driver := sarifDriver{
+		Name:           report.Tool.Name,
+		Version:        report.Tool.Version,
+		InformationURI: sarifInformationURI,
+		Rules:          []sarifRule{},
+	}
+	ruleIndex := make(map[string]int)
+	findings := AllFindings(report)
+	suppressions := allSuppressed(report)
+	results := make([]sarifResult, 0, len(findings)+len(suppressions))
+	addResult := func(finding Finding) *sarifResult {
+		index, ok := ruleIndex[finding.RuleID]
+		if !ok {
+			index = len(driver.Rules)
+			ruleIndex[finding.RuleID] = index
+			driver.Rules = append(driver.Rules, sarifRuleFor(finding))
+		}
+		results = append(results, sarifResult{
+			RuleID:    finding.RuleID,
+			RuleIndex: index,
+			Level:     sarifLevel(finding.Severity),
+			Message:   sarifMessage{Text: finding.Message},
+			Locations: sarifLocations(finding.Resource),
+			PartialFingerprints: map[string]string{
+				"terraformOpsFinding/v1": findingFingerprint(finding),
+			},
+			Properties: sarifResultProps{
+				Severity:    finding.Severity,
+				Confidence:  finding.Confidence,
+				Evidence:    finding.Evidence,
+				Remediation: finding.Remediation,
+				Workspace:   finding.Workspace,
+			},
+		})
+		return &results[len(results)-1]
+	}
+	for _, finding := range findings {
+		addResult(finding)
+	}
+	// Baseline suppressions are reported as accepted external suppressions so
+	// code scanning shows them as dismissed instead of dropping them.
+	for _, suppressed := range suppressions {
+		result := addResult(suppressed.Finding)
+		result.Suppressions = []sarifSuppression{{
+			Kind:          "external",
+			Status:        "accepted",
+			Justification: suppressed.Justification,
+		}}
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(log); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// sarifRuleFor derives a rules catalog entry from the first finding seen for a
+// rule. Findings of one rule share title, category and default severity.
+func sarifRuleFor(finding Finding) sarifRule {
+	rule := sarifRule{
+		ID:                   finding.RuleID,
+		Name:                 finding.Title,
+		ShortDescription:     sarifMessage{Text: finding.Title},
+		DefaultConfiguration: sarifConfiguration{Level: sarifLevel(finding.Severity)},
+		Properties: sarifRuleProps{
+			Category:         finding.Category,
+			SecuritySeverity: sarifSecuritySeverity(finding.Severity),
+			Tags:             []string{"terraform", string(finding.Category)},
+		},
+	}
+	if finding.Remediation != "" {
+		rule.Help = &sarifMessage{Text: finding.Remediation}
+	}
+	return rule
+}
+
+func sarifLocations(resource *ResourceRef) []sarifLocation {
+	if resource == nil || resource.Address == "" {
+		return nil
+	}
+	location := sarifLocation{
+		LogicalLocations: []sarifLogicalLocation{{
+			FullyQualifiedName: resource.Address,
+			Kind:               "resource",
+		}},
+	}
+	if resource.Location != nil && resource.Location.File != "" {
+		physical := &sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(resource.Location.File)},
+		}
+		if resource.Location.StartLine > 0 {
+			physical.Region = &sarifRegion{
+				StartLine:   resource.Location.StartLine,
+				StartColumn: resource.Location.StartColumn,
+				EndLine:     resource.Location.EndLine,
+				EndColumn:   resource.Location.EndColumn,
+			}
+		}
+		location.PhysicalLocation = physical
+	}
+	return []sarifLocation{location}
+}
+
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case SeverityCritical, SeverityHigh:
+		return "error"
+	case SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifSecuritySeverity maps severities onto the CVSS-like scale that code
+// scanning dashboards use to bucket results.
+func sarifSecuritySeverity(severity Severity) string {
+	switch severity {
+	case SeverityCritical:
+		return "9.5"
+	case SeverityHigh:
+		return "8.0"
+	case SeverityMedium:
+		return "5.5"
+	case SeverityLow:
+		return "3.0"
+	default:
+		return "0.0"
+	}
+}
+
+// findingFingerprint keeps a finding's identity stable across runs so code
+// scanning can track it between commits even without source positions. Batch
+// findings include their workspace so equal addresses in different root
+// modules stay distinct.
+func findingFingerprint(finding Finding) string {
+	key := finding.RuleID + "\x00" + resourceAddress(finding.Resource)
+	if finding.Workspace != "" {
+		key += "\x00" + finding.Workspace
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:16])
+}
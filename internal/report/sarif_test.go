@@ -0,0 +1,140 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRenderSARIF(t *testing.T) {
+	analysisReport := AnalysisReport{
+		Tool: ToolMetadata{Name: "terraform-ops", Version: "v1.2.3"},
+		Findings: []Finding{
+			{
+				RuleID:      "TFOPS-LIFECYCLE-REPLACE",
+				Title:       "Managed resource replacement",
+				Category:    CategoryLifecycle,
+				Severity:    SeverityMedium,
+				Confidence:  ConfidenceExact,
+				Resource:    &ResourceRef{Address: "aws_instance.web[0]", Location: &SourceLocation{File: "main.tf", StartLine: 3, StartColumn: 1, EndLine: 6, EndColumn: 2}},
+				Evidence:    []Evidence{{Kind: "replace_path", Path: "ami"}},
+				Message:     "A managed resource is planned for replacement.",
+				Remediation: "Confirm the replacement is expected.",
+			},
+			{
+				RuleID:   "TFOPS-LIFECYCLE-REPLACE",
+				Title:    "Managed resource replacement",
+				Category: CategoryLifecycle,
+				Severity: SeverityMedium,
+				Resource: &ResourceRef{Address: "aws_instance.web[1]"},
+				Message:  "A managed resource is planned for replacement.",
+			},
+			{
+				RuleID:   "TFOPS-PLAN-ERRORED",
+				Title:    "Terraform/OpenTofu planning errored",
+				Category: CategoryPlan,
+				Severity: SeverityHigh,
+				Message:  "Planning reported an error.",
+			},
+		},
+	}
+	data, err := Render(analysisReport, FormatSARIF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected SARIF envelope: %s", data)
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "terraform-ops" || run.Tool.Driver.Version != "v1.2.3" {
+		t.Fatalf("unexpected driver: %#v", run.Tool.Driver)
+	}
+	if len(run.Tool.Driver.Rules) != 2 {
+		t.Fatalf("expected one catalog entry per rule, got %#v", run.Tool.Driver.Rules)
+	}
+	if run.Tool.Driver.Rules[0].Help == nil || run.Tool.Driver.Rules[0].Help.Text != "Confirm the replacement is expected." {
+		t.Fatalf("remediation was not used as rule help: %#v", run.Tool.Driver.Rules[0])
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("got %d results", len(run.Results))
+	}
+	first := run.Results[0]
+	if first.Level != "warning" || first.RuleIndex != 0 {
+		t.Fatalf("unexpected first result: %#v", first)
+	}
+	physical := first.Locations[0].PhysicalLocation
+	if physical == nil || physical.ArtifactLocation.URI != "main.tf" || physical.Region.StartLine != 3 {
+		t.Fatalf("unexpected physical location: %#v", first.Locations)
+	}
+	if first.Locations[0].LogicalLocations[0].FullyQualifiedName != "aws_instance.web[0]" {
+		t.Fatalf("unexpected logical location: %#v", first.Locations)
+	}
+	second := run.Results[1]
+	if second.Locations[0].PhysicalLocation != nil {
+		t.Fatal("result without source position must not invent a physical location")
+	}
+	if second.PartialFingerprints["terraformOpsFinding/v1"] == first.PartialFingerprints["terraformOpsFinding/v1"] {
+		t.Fatal("distinct resources must have distinct fingerprints")
+	}
+	third := run.Results[2]
+	if third.Level != "error" || third.RuleIndex != 1 || len(third.Locations) != 0 {
+		t.Fatalf("unexpected plan-level result: %#v", third)
+	}
+}
+
+func TestRenderSARIFWithoutFindings(t *testing.T) {
+	data, err := Render(AnalysisReport{Tool: ToolMetadata{Name: "terraform-ops"}}, FormatSARIF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	run := raw["runs"].([]any)[0].(map[string]any)
+	if results, ok := run["results"].([]any); !ok || len(results) != 0 {
+		t.Fatalf("results must be an empty array, got %#v", run["results"])
+	}
+}
+
+func TestRenderSARIFMarksSuppressedResults(t *testing.T) {
+	analysisReport := AnalysisReport{
+		Tool: ToolMetadata{Name: "terraform-ops"},
+		Suppressed: []SuppressedFinding{{
+			Finding:       Finding{RuleID: "TFOPS-LIFECYCLE-DELETE", Severity: SeverityHigh, Resource: &ResourceRef{Address: "aws_s3_bucket.logs"}},
+			Justification: "bucket is being retired",
+		}},
+	}
+	data, err := Render(analysisReport, FormatSARIF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatal(err)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 1 || len(results[0].Suppressions) != 1 {
+		t.Fatalf("expected one suppressed result: %s", data)
+	}
+	if suppression := results[0].Suppressions[0]; suppression.Status != "accepted" || suppression.Justification != "bucket is being retired" {
+		t.Fatalf("unexpected suppression: %#v", suppression)
+	}
+}
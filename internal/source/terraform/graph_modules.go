@@ -426,40 +426,7 @@ func changedSourcesUnderModule(
 }
 
 func stripAddressIndexes(address string) string {
-	var out strings.Builder
-	out.Grow(len(address))
-
-	inIndex := false
-	inString := false
-	escaped := false
-	for i := 0; i < len(address); i++ {
-		ch := address[i]
-		if !inIndex {
-			if ch == '[' {
-				inIndex = true
-				continue
-			}
-			out.WriteByte(ch)
-			continue
-		}
-
-		if escaped {
-			escaped = false
-			continue
-		}
-		if inString && ch == '\\' {
-			escaped = true
-			continue
-		}
-		if ch == '"' {
-			inString = !inString
-			continue
-		}
-		if ch == ']' && !inString {
-			inIndex = false
-		}
-	}
-	return out.String()
+	return string(ir.Address(address).WithoutInstanceKeys())
 }
 
 func uniqueGraphSources(sources []graphSource) []graphSource {
@@ -53,11 +53,17 @@ func Normalize(plan *Plan, engine ir.Engine, mode ir.RedactionMode) (*ir.ChangeS
 		},
 	}
 
+	schemaVersions := stateSchemaVersions(plan)
+	providerBindings := resolveProviderBindings(plan.Configuration)
 	for _, raw := range plan.ResourceChanges {
 		change, count, strictCount, err := normalizeResourceChange(raw, mode)
 		if err != nil {
 			return nil, fmt.Errorf("normalize resource %q: %w", raw.Address, err)
 		}
+		if version, ok := schemaVersions[change.Address]; ok {
+			change.SchemaVersion = &version
+		}
+		applyProviderBinding(&change, providerBindings)
 		out.Resources = append(out.Resources, change)
 		out.Redaction.TerraformSensitivePaths += count
 		out.Redaction.StrictValuesRemoved += strictCount
@@ -97,6 +103,7 @@ func Normalize(plan *Plan, engine ir.Engine, mode ir.RedactionMode) (*ir.ChangeS
 		if err != nil {
 			return nil, fmt.Errorf("normalize drift resource %q: %w", raw.Address, err)
 		}
+		applyProviderBinding(&change, providerBindings)
 		out.Redaction.TerraformSensitivePaths += count
 		out.Redaction.StrictValuesRemoved += strictCount
 		out.Drift = append(out.Drift, ir.DriftChange{
@@ -105,6 +112,8 @@ func Normalize(plan *Plan, engine ir.Engine, mode ir.RedactionMode) (*ir.ChangeS
 		})
 	}
 
+	out.PriorState, out.Unchanged = normalizePriorState(plan.PriorState, out.Resources)
+
 	out.Graph = buildDependencyGraphWithModules(
 		plan.Configuration,
 		out.Resources,
@@ -145,6 +154,7 @@ func normalizeResourceChange(raw ResourceChange, mode ir.RedactionMode) (ir.Reso
 		DeposedKey:     raw.Deposed,
 		Action:         ir.NormalizeAction(raw.Change.Actions),
 		ActionReason:   raw.ActionReason,
+		ProviderName:   raw.ProviderName,
 		ReplacePaths:   replacePaths,
 		Before:         before,
 		After:          after,
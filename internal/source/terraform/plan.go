@@ -41,6 +41,44 @@ type Plan struct {
 	Checks             []Check                    `json:"checks"`
 	Configuration      Configuration              `json:"configuration"`
 	Variables          map[string]json.RawMessage `json:"variables"`
+	PriorState         *State                     `json:"prior_state"`
+	PlannedValues      *StateValues               `json:"planned_values"`
+}
+
+// State is the prior_state document embedded in a plan.
+type State struct {
+	FormatVersion    string       `json:"format_version"`
+	TerraformVersion string       `json:"terraform_version"`
+	Values           *StateValues `json:"values"`
+}
+
+// StateValues is the values representation shared by prior_state.values and
+// planned_values.
+type StateValues struct {
+	RootModule StateModule `json:"root_module"`
+}
+
+type StateModule struct {
+	Address      string          `json:"address"`
+	Resources    []StateResource `json:"resources"`
+	ChildModules []StateModule   `json:"child_modules"`
+}
+
+// StateResource deliberately omits the values and sensitive_values
+// properties: resource values are only read from resource_changes, where
+// their sensitivity masks are applied, so prior state values never enter the
+// DTO.
+type StateResource struct {
+	Address       string          `json:"address"`
+	Mode          string          `json:"mode"`
+	Type          string          `json:"type"`
+	Name          string          `json:"name"`
+	Index         json.RawMessage `json:"index"`
+	ProviderName  string          `json:"provider_name"`
+	SchemaVersion *int            `json:"schema_version"`
+	DependsOn     []string        `json:"depends_on"`
+	Tainted       bool            `json:"tainted"`
+	DeposedKey    string          `json:"deposed_key"`
 }
 
 type ResourceChange struct {
@@ -52,6 +90,7 @@ type ResourceChange struct {
 	Name            string          `json:"name"`
 	Index           json.RawMessage `json:"index"`
 	Deposed         string          `json:"deposed"`
+	ProviderName    string          `json:"provider_name"`
 	Change          Change          `json:"change"`
 	ActionReason    string          `json:"action_reason"`
 }
@@ -104,7 +143,19 @@ type CheckProblem struct {
 }
 
 type Configuration struct {
-	RootModule Module `json:"root_module"`
+	ProviderConfig map[string]ProviderConfig `json:"provider_config"`
+	RootModule     Module                    `json:"root_module"`
+}
+
+// ProviderConfig is one provider configuration block. Keys of
+// Configuration.ProviderConfig are opaque; resources refer to them through
+// ConfigResource.ProviderConfigKey.
+type ProviderConfig struct {
+	Name              string `json:"name"`
+	FullName          string `json:"full_name"`
+	Alias             string `json:"alias"`
+	ModuleAddress     string `json:"module_address"`
+	VersionConstraint string `json:"version_constraint"`
 }
 
 type Module struct {
@@ -114,12 +165,13 @@ type Module struct {
 }
 
 type ConfigResource struct {
-	Address     string                     `json:"address"`
-	Mode        string                     `json:"mode"`
-	Type        string                     `json:"type"`
-	Name        string                     `json:"name"`
-	Expressions map[string]json.RawMessage `json:"expressions"`
-	DependsOn   []string                   `json:"depends_on"`
+	Address           string                     `json:"address"`
+	Mode              string                     `json:"mode"`
+	Type              string                     `json:"type"`
+	Name              string                     `json:"name"`
+	Expressions       map[string]json.RawMessage `json:"expressions"`
+	DependsOn         []string                   `json:"depends_on"`
+	ProviderConfigKey string                     `json:"provider_config_key"`
 }
 
 type ConfigOutput struct {
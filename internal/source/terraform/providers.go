@@ -0,0 +1,116 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"strings"
+
+	"github.com/yu/terraform-ops/internal/ir"
+)
+
+// providerBinding is the provider configuration a configuration resource
+// resolved to.
+type providerBinding struct {
+	fullName      string
+	alias         string
+	moduleAddress string
+}
+
+// resolveProviderBindings maps configuration resource addresses without
+// instance keys to the provider configuration they use. Resources whose
+// provider_config_key is missing are left out.
+func resolveProviderBindings(configuration Configuration) map[ir.Address]providerBinding {
+	bindings := make(map[ir.Address]providerBinding)
+	for _, context := range flattenConfigResourceContexts(configuration.RootModule) {
+		key := context.resource.ProviderConfigKey
+		if key == "" {
+			continue
+		}
+		bindings[ir.Address(context.address).WithoutInstanceKeys()] = lookupProviderConfig(configuration.ProviderConfig, key)
+	}
+	return bindings
+}
+
+// lookupProviderConfig resolves an opaque provider_config_key. Terraform
+// versions disagree on whether module-scoped keys carry the "module." prefix
+// ("child:aws" versus "module.child:aws"), so both spellings are tried. When
+// the key has no entry the alias is recovered from the key itself.
+func lookupProviderConfig(configs map[string]ProviderConfig, key string) providerBinding {
+	candidates := []string{key}
+	if strings.Contains(key, ":") && !strings.HasPrefix(key, "module.") {
+		candidates = append(candidates, "module."+key)
+	}
+	for _, candidate := range candidates {
+		if config, ok := configs[candidate]; ok {
+			return providerBinding{
+				fullName:      config.FullName,
+				alias:         config.Alias,
+				moduleAddress: config.ModuleAddress,
+			}
+		}
+	}
+	binding := providerBinding{}
+	local := key
+	if index := strings.LastIndex(key, ":"); index >= 0 {
+		binding.moduleAddress = key[:index]
+		if !strings.HasPrefix(binding.moduleAddress, "module.") {
+			binding.moduleAddress = "module." + binding.moduleAddress
+		}
+		local = key[index+1:]
+	}
+	if _, alias, ok := strings.Cut(local, "."); ok {
+		binding.alias = alias
+	}
+	return binding
+}
+
+// applyProviderBinding records the provider configuration on a change. The
+// plan's provider_name wins over the configuration's full_name, which only
+// fills the gap for plans that omit it.
+func applyProviderBinding(change *ir.ResourceChange, bindings map[ir.Address]providerBinding) {
+	binding, ok := bindings[change.Address.WithoutInstanceKeys()]
+	if !ok {
+		if change.ProviderName != "" {
+			change.ProviderConfig = providerConfigAddress("", change.ProviderName, "")
+		}
+		return
+	}
+	if change.ProviderName == "" {
+		change.ProviderName = binding.fullName
+	}
+	change.ProviderAlias = binding.alias
+	if change.ProviderName != "" {
+		change.ProviderConfig = providerConfigAddress(binding.moduleAddress, change.ProviderName, binding.alias)
+	}
+}
+
+// providerConfigAddress formats an absolute provider configuration address
+// the way Terraform prints it in state, for example
+// module.app.provider["registry.terraform.io/hashicorp/aws"].west.
+func providerConfigAddress(moduleAddress, providerName, alias string) string {
+	var b strings.Builder
+	if moduleAddress != "" {
+		b.WriteString(moduleAddress)
+		b.WriteString(".")
+	}
+	b.WriteString(`provider["`)
+	b.WriteString(providerName)
+	b.WriteString(`"]`)
+	if alias != "" {
+		b.WriteString(".")
+		b.WriteString(alias)
+	}
+	return b.String()
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yu/terraform-ops/internal/ir"
+)
+
+func TestNormalizeResolvesProviderConfigurations(t *testing.T) {
+	planJSON := `{
+  "format_version":"1.2",
+  "resource_changes":[
+    {"address":"aws_s3_bucket.logs","mode":"managed","type":"aws_s3_bucket","name":"logs",
+     "provider_name":"registry.terraform.io/hashicorp/aws",
+     "change":{"actions":["create"],"before":null,"after":{}}},
+    {"address":"google_compute_instance.vm","mode":"managed","type":"google_compute_instance","name":"vm",
+     "provider_name":"registry.terraform.io/hashicorp/google-beta",
+     "change":{"actions":["create"],"before":null,"after":{}}},
+    {"address":"module.edge[0].aws_instance.web","module_address":"module.edge[0]","mode":"managed","type":"aws_instance","name":"web",
+     "change":{"actions":["create"],"before":null,"after":{}}},
+    {"address":"terraform_data.marker","mode":"managed","type":"terraform_data","name":"marker",
+     "change":{"actions":["create"],"before":null,"after":{}}}
+  ],
+  "configuration":{
+    "provider_config":{
+      "aws":{"name":"aws","full_name":"registry.terraform.io/hashicorp/aws"},
+      "aws.us_east_1":{"name":"aws","full_name":"registry.terraform.io/hashicorp/aws","alias":"us_east_1"},
+      "google-beta":{"name":"google-beta","full_name":"registry.terraform.io/hashicorp/google-beta"},
+      "module.edge:aws":{"name":"aws","full_name":"registry.terraform.io/hashicorp/aws","module_address":"module.edge"}
+    },
+    "root_module":{
+      "resources":[
+        {"address":"aws_s3_bucket.logs","mode":"managed","type":"aws_s3_bucket","name":"logs","provider_config_key":"aws.us_east_1"},
+        {"address":"google_compute_instance.vm","mode":"managed","type":"google_compute_instance","name":"vm","provider_config_key":"google-beta"}
+      ],
+      "module_calls":{"edge":{"module":{"resources":[
+        {"address":"aws_instance.web","mode":"managed","type":"aws_instance","name":"web","provider_config_key":"edge:aws"}
+      ]}}}
+    }
+  }
+}`
+	plan, err := ParseReader(strings.NewReader(planJSON), DefaultMaxPlanBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changeSet, err := Normalize(plan, ir.EngineTerraform, ir.RedactionStandard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resources := make(map[ir.Address]ir.ResourceChange)
+	for _, resource := range changeSet.Resources {
+		resources[resource.Address] = resource
+	}
+
+	tests := []struct {
+		address string
+		name    string
+		config  string
+		alias   string
+		label   string
+	}{
+		{
+			address: "aws_s3_bucket.logs",
+			name:    "registry.terraform.io/hashicorp/aws",
+			config:  `provider["registry.terraform.io/hashicorp/aws"].us_east_1`,
+			alias:   "us_east_1",
+			label:   "aws.us_east_1",
+		},
+		{
+			address: "google_compute_instance.vm",
+			name:    "registry.terraform.io/hashicorp/google-beta",
+			config:  `provider["registry.terraform.io/hashicorp/google-beta"]`,
+			label:   "google-beta",
+		},
+		{
+			// provider_name is absent, so full_name from the configuration fills it.
+			address: "module.edge[0].aws_instance.web",
+			name:    "registry.terraform.io/hashicorp/aws",
+			config:  `module.edge.provider["registry.terraform.io/hashicorp/aws"]`,
+			label:   "aws",
+		},
+		{address: "terraform_data.marker"},
+	}
+	for _, tt := range tests {
+		resource, ok := resources[ir.Address(tt.address)]
+		if !ok {
+			t.Fatalf("missing resource %s", tt.address)
+		}
+		if resource.ProviderName != tt.name || resource.ProviderConfig != tt.config || resource.ProviderAlias != tt.alias {
+			t.Errorf("%s provider = (%q, %q, %q), want (%q, %q, %q)", tt.address,
+				resource.ProviderName, resource.ProviderConfig, resource.ProviderAlias, tt.name, tt.config, tt.alias)
+		}
+		if got := resource.ProviderLabel(); got != tt.label {
+			t.Errorf("%s ProviderLabel() = %q, want %q", tt.address, got, tt.label)
+		}
+	}
+}
+
+func TestLookupProviderConfigFallsBackToKey(t *testing.T) {
+	binding := lookupProviderConfig(nil, "network:aws.west")
+	if binding.moduleAddress != "module.network" || binding.alias != "west" || binding.fullName != "" {
+		t.Fatalf("binding = %+v", binding)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import "github.com/yu/terraform-ops/internal/ir"
+
+// flattenStateResources lists every resource of a values representation,
+// including those of nested child modules.
+func flattenStateResources(values *StateValues) []StateResource {
+	if values == nil {
+		return nil
+	}
+	var out []StateResource
+	var walk func(module StateModule)
+	walk = func(module StateModule) {
+		out = append(out, module.Resources...)
+		for _, child := range module.ChildModules {
+			walk(child)
+		}
+	}
+	walk(values.RootModule)
+	return out
+}
+
+// stateSchemaVersions maps resource instance addresses to the schema version
+// recorded for them. Planned values describe the schema the apply will write,
+// so they win over the prior state.
+func stateSchemaVersions(plan *Plan) map[ir.Address]int {
+	versions := make(map[ir.Address]int)
+	if plan.PriorState != nil {
+		for _, resource := range flattenStateResources(plan.PriorState.Values) {
+			if resource.SchemaVersion != nil {
+				versions[ir.Address(resource.Address)] = *resource.SchemaVersion
+			}
+		}
+	}
+	for _, resource := range flattenStateResources(plan.PlannedValues) {
+		if resource.SchemaVersion != nil {
+			versions[ir.Address(resource.Address)] = *resource.SchemaVersion
+		}
+	}
+	return versions
+}
+
+// normalizePriorState summarizes the managed resources of the prior state and
+// returns those that the plan leaves unchanged. Deposed objects are skipped;
+// they are always planned for deletion.
+func normalizePriorState(state *State, resources []ir.ResourceChange) (ir.PriorStateSummary, []ir.StateResource) {
+	if state == nil || state.Values == nil {
+		return ir.PriorStateSummary{}, nil
+	}
+	changed := make(map[ir.Address]struct{}, len(resources))
+	for _, resource := range resources {
+		if resource.Action.Semantic != ir.ActionNoOp && resource.DeposedKey == "" {
+			changed[resource.Address] = struct{}{}
+		}
+	}
+
+	summary := ir.PriorStateSummary{Present: true}
+	var unchanged []ir.StateResource
+	for _, raw := range flattenStateResources(state.Values) {
+		if ir.ResourceMode(raw.Mode) != ir.ResourceModeManaged || raw.DeposedKey != "" {
+			continue
+		}
+		summary.Resources++
+		address := ir.Address(raw.Address)
+		if _, ok := changed[address]; ok {
+			continue
+		}
+		resource := ir.StateResource{
+			Address:       address,
+			Mode:          ir.ResourceMode(raw.Mode),
+			Type:          raw.Type,
+			ProviderName:  raw.ProviderName,
+			SchemaVersion: raw.SchemaVersion,
+		}
+		for _, dependency := range raw.DependsOn {
+			resource.DependsOn = append(resource.DependsOn, ir.Address(dependency))
+		}
+		unchanged = append(unchanged, resource)
+	}
+	summary.Unchanged = len(unchanged)
+	return summary, unchanged
+}
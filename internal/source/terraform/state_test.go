@@ -0,0 +1,107 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/yu/terraform-ops/internal/ir"
+)
+
+func TestNormalizeCarriesProviderSchemaAndPriorState(t *testing.T) {
+	const stateCanary = "TFOPS_STATE_CANARY_5d1e0b"
+	planJSON := `{
+  "format_version":"1.2",
+  "terraform_version":"1.9.0",
+  "applyable":true,
+  "complete":true,
+  "errored":false,
+  "resource_changes":[
+    {"address":"aws_vpc.main","mode":"managed","type":"aws_vpc","name":"main",
+     "provider_name":"registry.terraform.io/hashicorp/aws",
+     "change":{"actions":["delete","create"],"before":{},"after":{}}},
+    {"address":"aws_subnet.a","mode":"managed","type":"aws_subnet","name":"a",
+     "provider_name":"registry.terraform.io/hashicorp/aws",
+     "change":{"actions":["no-op"],"before":{},"after":{}}}
+  ],
+  "planned_values":{"root_module":{"resources":[
+    {"address":"aws_vpc.main","mode":"managed","type":"aws_vpc","name":"main","provider_name":"registry.terraform.io/hashicorp/aws","schema_version":1,"values":{"cidr_block":"` + stateCanary + `"}}
+  ]}},
+  "prior_state":{"format_version":"1.0","terraform_version":"1.9.0","values":{"root_module":{
+    "resources":[
+      {"address":"aws_vpc.main","mode":"managed","type":"aws_vpc","name":"main","provider_name":"registry.terraform.io/hashicorp/aws","schema_version":0,"values":{"secret":"` + stateCanary + `"}},
+      {"address":"aws_subnet.a","mode":"managed","type":"aws_subnet","name":"a","provider_name":"registry.terraform.io/hashicorp/aws","schema_version":1,"depends_on":["aws_vpc.main"]},
+      {"address":"data.aws_region.current","mode":"data","type":"aws_region","name":"current","provider_name":"registry.terraform.io/hashicorp/aws"}
+    ],
+    "child_modules":[{"address":"module.app","resources":[
+      {"address":"module.app.aws_instance.web[0]","mode":"managed","type":"aws_instance","name":"web","index":0,"provider_name":"registry.terraform.io/hashicorp/aws","depends_on":["aws_subnet.a"]}
+    ]}]
+  }}},
+  "output_changes":{},
+  "configuration":{"root_module":{"resources":[],"module_calls":{},"outputs":{}}}
+}`
+	plan, err := ParseReader(strings.NewReader(planJSON), DefaultMaxPlanBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changeSet, err := Normalize(plan, ir.EngineTerraform, ir.RedactionStandard)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vpc := changeSet.Resources[1]
+	if vpc.Address != "aws_vpc.main" || vpc.ProviderName != "registry.terraform.io/hashicorp/aws" {
+		t.Fatalf("provider name not carried: %#v", vpc)
+	}
+	if vpc.SchemaVersion == nil || *vpc.SchemaVersion != 1 {
+		t.Fatalf("planned schema version should win over prior state: %#v", vpc.SchemaVersion)
+	}
+
+	want := ir.PriorStateSummary{Present: true, Resources: 3, Unchanged: 2}
+	if changeSet.PriorState != want {
+		t.Fatalf("prior state summary = %#v, want %#v", changeSet.PriorState, want)
+	}
+	if len(changeSet.Unchanged) != 2 || changeSet.Unchanged[0].Address != "aws_subnet.a" {
+		t.Fatalf("unexpected unchanged resources: %#v", changeSet.Unchanged)
+	}
+	dependents := changeSet.UnchangedDependents("aws_vpc.main")
+	if len(dependents) != 2 || dependents[0] != "aws_subnet.a" || dependents[1] != "module.app.aws_instance.web[0]" {
+		t.Fatalf("unexpected unchanged dependents: %v", dependents)
+	}
+
+	encoded, err := json.Marshal(changeSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(encoded), stateCanary) {
+		t.Fatal("state values leaked into the ChangeSet")
+	}
+}
+
+func TestNormalizeWithoutPriorState(t *testing.T) {
+	plan, err := ParseReader(strings.NewReader(`{"format_version":"1.2","applyable":true,"complete":true,"errored":false}`), DefaultMaxPlanBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changeSet, err := Normalize(plan, ir.EngineTerraform, ir.RedactionStandard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changeSet.PriorState.Present || len(changeSet.Unchanged) != 0 {
+		t.Fatalf("unexpected prior state: %#v", changeSet.PriorState)
+	}
+}
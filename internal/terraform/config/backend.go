@@ -0,0 +1,273 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/yu/terraform-ops/internal/core"
+)
+
+// RedactedValue replaces backend values that look like credentials in output.
+const RedactedValue = "<redacted>"
+
+// backendBodyValues reads every attribute and nested block of a backend body.
+// Primitive values are rendered as strings, collections as []interface{} and
+// map[string]interface{}, and nested blocks as objects, or as a list of objects
+// when a block type repeats. Attributes that cannot be evaluated without a
+// scope are skipped and reported.
+func backendBodyValues(body hcl.Body) (map[string]interface{}, hcl.Diagnostics) {
+	values := map[string]interface{}{}
+	syntaxBody, ok := body.(*hclsyntax.Body)
+	if !ok {
+		attrs, diags := body.JustAttributes()
+		for name, attr := range attrs {
+			val, valDiags := attr.Expr.Value(nil)
+			diags = append(diags, valDiags...)
+			if !valDiags.HasErrors() {
+				if converted, ok := ctyToInterface(val); ok {
+					values[name] = converted
+				}
+			}
+		}
+		return values, diags
+	}
+
+	var diags hcl.Diagnostics
+	for name, attr := range syntaxBody.Attributes {
+		val, valDiags := attr.Expr.Value(nil)
+		if valDiags.HasErrors() {
+			diags = append(diags, valDiags...)
+			continue
+		}
+		if converted, ok := ctyToInterface(val); ok {
+			values[name] = converted
+		}
+	}
+
+	blocks := map[string][]interface{}{}
+	for _, block := range syntaxBody.Blocks {
+		nested, nestedDiags := backendBodyValues(block.Body)
+		diags = append(diags, nestedDiags...)
+		blocks[block.Type] = append(blocks[block.Type], nested)
+	}
+	for name, list := range blocks {
+		if len(list) == 1 {
+			values[name] = list[0]
+		} else {
+			values[name] = list
+		}
+	}
+	return values, diags
+}
+
+// ctyToInterface converts a known value. Unknown values report false; null
+// values become nil.
+func ctyToInterface(val cty.Value) (interface{}, bool) {
+	if !val.IsWhollyKnown() {
+		return nil, false
+	}
+	if val.IsNull() {
+		return nil, true
+	}
+	ty := val.Type()
+	switch {
+	case ty == cty.String:
+		return val.AsString(), true
+	case ty == cty.Bool:
+		return strconv.FormatBool(val.True()), true
+	case ty == cty.Number:
+		return val.AsBigFloat().Text('f', -1), true
+	case ty.IsListType(), ty.IsSetType(), ty.IsTupleType():
+		out := make([]interface{}, 0, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			_, element := it.Element()
+			if converted, ok := ctyToInterface(element); ok {
+				out = append(out, converted)
+			}
+		}
+		return out, true
+	case ty.IsMapType(), ty.IsObjectType():
+		out := make(map[string]interface{}, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			key, element := it.Element()
+			if converted, ok := ctyToInterface(element); ok {
+				out[key.AsString()] = converted
+			}
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
+
+// ParseBackendConfig reads -backend-config style values, in order. A value
+// containing "=" is a key=value pair whose value is taken as a string; any
+// other value is the path of an HCL file of backend settings. Later values
+// override earlier ones key by key.
+func ParseBackendConfig(items []string) (map[string]interface{}, error) {
+	overrides := map[string]interface{}{}
+	for _, item := range items {
+		if key, value, ok := strings.Cut(item, "="); ok {
+			key = strings.TrimSpace(key)
+			if key == "" {
+				return nil, fmt.Errorf("invalid backend config %q: expected key=value or a file path", item)
+			}
+			overrides[key] = value
+			continue
+		}
+		src, err := os.ReadFile(item)
+		if err != nil {
+			return nil, fmt.Errorf("read backend config file: %w", err)
+		}
+		file, diags := hclparse.NewParser().ParseHCL(src, item)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("parse backend config file %s: %w", item, diags)
+		}
+		values, diags := backendBodyValues(file.Body)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("evaluate backend config file %s: %w", item, diags)
+		}
+		for key, value := range values {
+			overrides[key] = value
+		}
+	}
+	return overrides, nil
+}
+
+// MergeBackendConfig applies overrides to the backend the way terraform init
+// merges partial configuration: each top-level key, including nested blocks,
+// replaces the value from the backend block.
+func MergeBackendConfig(backend *core.Backend, overrides map[string]interface{}) {
+	if backend == nil || len(overrides) == 0 {
+		return
+	}
+	if backend.Config == nil {
+		backend.Config = map[string]interface{}{}
+	}
+	for key, value := range overrides {
+		backend.Config[key] = value
+	}
+	backend.SensitiveKeys = sensitiveBackendKeys(backend.Config, "")
+}
+
+// sensitiveBackendKeys lists the dotted paths of settings whose names look
+// like credentials, such as access_key, secret_key, token or password.
+func sensitiveBackendKeys(values map[string]interface{}, prefix string) []string {
+	var keys []string
+	for key, value := range values {
+		path := prefix + key
+		if isSensitiveBackendKey(key) {
+			keys = append(keys, path)
+			continue
+		}
+		switch nested := value.(type) {
+		case map[string]interface{}:
+			keys = append(keys, sensitiveBackendKeys(nested, path+".")...)
+		case []interface{}:
+			for _, item := range nested {
+				if object, ok := item.(map[string]interface{}); ok {
+					keys = append(keys, sensitiveBackendKeys(object, path+".")...)
+				}
+			}
+		}
+	}
+	sort.Strings(keys)
+	return dedupeSorted(keys)
+}
+
+// secretBackendKeys are backend settings that hold a secret. Settings that
+// only mention one, such as skip_credentials_validation or
+// shared_credentials_files, stay visible.
+var secretBackendKeys = map[string]struct{}{
+	"credentials":      {},
+	"conn_str":         {},
+	"encryption_key":   {},
+	"sse_customer_key": {},
+	"client_key":       {},
+	"key_material":     {},
+}
+
+// secretBackendSuffixes match a secret setting as the whole key or as its
+// last words, as in client_secret, sas_token or client_certificate_password.
+var secretBackendSuffixes = []string{"secret", "password", "token", "access_key", "secret_key", "private_key"}
+
+func isSensitiveBackendKey(key string) bool {
+	key = strings.ToLower(key)
+	if _, ok := secretBackendKeys[key]; ok {
+		return true
+	}
+	for _, suffix := range secretBackendSuffixes {
+		if key == suffix || strings.HasSuffix(key, "_"+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func dedupeSorted(values []string) []string {
+	out := values[:0]
+	for i, value := range values {
+		if i == 0 || value != values[i-1] {
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+// RedactBackendConfig returns a copy of the backend settings with every
+// sensitive key replaced by RedactedValue.
+func RedactBackendConfig(backend *core.Backend) map[string]interface{} {
+	if backend == nil {
+		return nil
+	}
+	return redactValues(backend.Config)
+}
+
+func redactValues(values map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if isSensitiveBackendKey(key) && value != nil {
+			out[key] = RedactedValue
+			continue
+		}
+		switch nested := value.(type) {
+		case map[string]interface{}:
+			out[key] = redactValues(nested)
+		case []interface{}:
+			items := make([]interface{}, len(nested))
+			for i, item := range nested {
+				if object, ok := item.(map[string]interface{}); ok {
+					items[i] = redactValues(object)
+				} else {
+					items[i] = item
+				}
+			}
+			out[key] = items
+		default:
+			out[key] = value
+		}
+	}
+	return out
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConfigFiles_BackendComplexValues(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainTf := `terraform {
+  backend "s3" {
+    bucket     = "terraform-state"
+    key        = "prod/terraform.tfstate"
+    region     = "eu-west-1"
+    access_key = "AKIAEXAMPLE"
+    max_retries = 5
+    allowed_account_ids = ["111111111111", "222222222222"]
+    shared_config_files = []
+
+    assume_role {
+      role_arn     = "arn:aws:iam::111111111111:role/terraform"
+      session_name = "terraform"
+      tags = {
+        team = "platform"
+      }
+    }
+
+    endpoints = {
+      s3 = "https://s3.example.com"
+    }
+  }
+}`
+	assert.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(mainTf), 0644))
+
+	configs, err := NewParser().ParseConfigFiles([]string{tmpDir})
+	assert.NoError(t, err)
+	backend := configs[0].Backend
+	assert.NotNil(t, backend)
+	assert.Empty(t, configs[0].Diagnostics)
+
+	assert.Equal(t, map[string]interface{}{
+		"bucket":              "terraform-state",
+		"key":                 "prod/terraform.tfstate",
+		"region":              "eu-west-1",
+		"access_key":          "AKIAEXAMPLE",
+		"max_retries":         "5",
+		"allowed_account_ids": []interface{}{"111111111111", "222222222222"},
+		"shared_config_files": []interface{}{},
+		"assume_role": map[string]interface{}{
+			"role_arn":     "arn:aws:iam::111111111111:role/terraform",
+			"session_name": "terraform",
+			"tags":         map[string]interface{}{"team": "platform"},
+		},
+		"endpoints": map[string]interface{}{"s3": "https://s3.example.com"},
+	}, backend.Config)
+	assert.Equal(t, []string{"access_key"}, backend.SensitiveKeys)
+
+	redacted := RedactBackendConfig(backend)
+	assert.Equal(t, RedactedValue, redacted["access_key"])
+	assert.Equal(t, "terraform-state", redacted["bucket"])
+	assert.Equal(t, "AKIAEXAMPLE", backend.Config["access_key"], "redaction must not modify the parsed config")
+}
+
+func TestParseBackendConfig(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "prod.s3.tfbackend")
+	content := `bucket = "prod-state"
+assume_role {
+  role_arn = "arn:aws:iam::222222222222:role/terraform"
+}
+secret_key = "shh"
+`
+	assert.NoError(t, os.WriteFile(file, []byte(content), 0644))
+
+	overrides, err := ParseBackendConfig([]string{file, "bucket=override-state", "region=us-east-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"bucket":      "override-state",
+		"region":      "us-east-1",
+		"secret_key":  "shh",
+		"assume_role": map[string]interface{}{"role_arn": "arn:aws:iam::222222222222:role/terraform"},
+	}, overrides)
+
+	_, err = ParseBackendConfig([]string{"=value"})
+	assert.Error(t, err)
+	_, err = ParseBackendConfig([]string{filepath.Join(t.TempDir(), "missing.hcl")})
+	assert.Error(t, err)
+}
+
+func TestMergeBackendConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainTf := `terraform {
+  backend "s3" {
+    bucket = "default-state"
+    key    = "terraform.tfstate"
+    assume_role {
+      role_arn    = "arn:aws:iam::111111111111:role/terraform"
+      external_id = "abc"
+    }
+  }
+}`
+	assert.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(mainTf), 0644))
+	configs, err := NewParser().ParseConfigFiles([]string{tmpDir})
+	assert.NoError(t, err)
+	backend := configs[0].Backend
+
+	MergeBackendConfig(backend, map[string]interface{}{
+		"bucket":      "prod-state",
+		"token":       "session-token",
+		"assume_role": map[string]interface{}{"role_arn": "arn:aws:iam::222222222222:role/terraform"},
+	})
+	assert.Equal(t, map[string]interface{}{
+		"bucket":      "prod-state",
+		"key":         "terraform.tfstate",
+		"token":       "session-token",
+		"assume_role": map[string]interface{}{"role_arn": "arn:aws:iam::222222222222:role/terraform"},
+	}, backend.Config)
+	assert.Equal(t, []string{"token"}, backend.SensitiveKeys)
+}
+
+func TestSensitiveBackendKeys(t *testing.T) {
+	values := map[string]interface{}{
+		"key":         "terraform.tfstate",
+		"password":    "hunter2",
+		"kms_key_id":  "alias/terraform",
+		"assume_role": map[string]interface{}{"role_arn": "arn", "session_token": "t"},
+		"credentials": nil,
+	}
+	assert.Equal(t, []string{"assume_role.session_token", "credentials", "password"}, sensitiveBackendKeys(values, ""))
+	redacted := redactValues(values)
+	assert.Equal(t, RedactedValue, redacted["assume_role"].(map[string]interface{})["session_token"])
+	assert.Nil(t, redacted["credentials"])
+}
+
+func TestIsSensitiveBackendKey(t *testing.T) {
+	for _, key := range []string{
+		"access_key", "secret_key", "token", "password", "credentials", "sas_token",
+		"client_secret", "client_certificate_password", "session_token", "conn_str", "Encryption_Key",
+	} {
+		assert.True(t, isSensitiveBackendKey(key), key)
+	}
+	for _, key := range []string{
+		"skip_credentials_validation", "shared_credentials_files", "shared_credentials_file",
+		"kms_key_id", "kms_encryption_key", "token_file", "secret_id", "bucket",
+	} {
+		assert.False(t, isSensitiveBackendKey(key), key)
+	}
+
+	redacted := redactValues(map[string]interface{}{
+		"skip_credentials_validation": true,
+		"shared_credentials_files":    []interface{}{"~/.aws/credentials"},
+	})
+	assert.Equal(t, true, redacted["skip_credentials_validation"])
+	assert.Equal(t, []interface{}{"~/.aws/credentials"}, redacted["shared_credentials_files"])
+}
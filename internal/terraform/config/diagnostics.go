@@ -0,0 +1,101 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/yu/terraform-ops/internal/core"
+)
+
+// hclDiagnostics converts HCL diagnostics, keeping the subject range of each.
+func hclDiagnostics(diags hcl.Diagnostics) []core.Diagnostic {
+	out := make([]core.Diagnostic, 0, len(diags))
+	for _, diag := range diags {
+		converted := core.Diagnostic{
+			Severity: core.DiagnosticError,
+			Summary:  diag.Summary,
+			Detail:   diag.Detail,
+		}
+		if diag.Severity == hcl.DiagWarning {
+			converted.Severity = core.DiagnosticWarning
+		}
+		if diag.Subject != nil {
+			rng := sourceRange(*diag.Subject)
+			converted.File = rng.File
+			converted.Range = &rng
+		}
+		out = append(out, converted)
+	}
+	return out
+}
+
+// errorDiagnostics describes err as diagnostics. HCL diagnostics wrapped in
+// err keep their own ranges; any other error becomes one diagnostic with the
+// given summary, located at rng when it is known.
+func errorDiagnostics(err error, summary, file string, rng *hcl.Range) []core.Diagnostic {
+	var diags hcl.Diagnostics
+	if errors.As(err, &diags) && len(diags) > 0 {
+		return hclDiagnostics(diags)
+	}
+	diag := core.Diagnostic{
+		Severity: core.DiagnosticError,
+		Summary:  summary,
+		Detail:   err.Error(),
+		File:     file,
+	}
+	if rng != nil {
+		converted := sourceRange(*rng)
+		diag.Range = &converted
+	}
+	return []core.Diagnostic{diag}
+}
+
+// parseErrorDiagnostics describes a file that could not be read or parsed.
+func parseErrorDiagnostics(err error) []core.Diagnostic {
+	var parseErr *core.ConfigParseError
+	if !errors.As(err, &parseErr) {
+		return errorDiagnostics(err, "Failed to read configuration", "", nil)
+	}
+	var diags hcl.Diagnostics
+	if errors.As(parseErr.Cause, &diags) && len(diags) > 0 {
+		return hclDiagnostics(diags)
+	}
+	diag := core.Diagnostic{
+		Severity: core.DiagnosticError,
+		Summary:  "Failed to read configuration",
+		File:     parseErr.Path,
+	}
+	if parseErr.Message != "" {
+		diag.Summary = strings.ToUpper(parseErr.Message[:1]) + parseErr.Message[1:]
+	}
+	if parseErr.Cause != nil {
+		diag.Detail = parseErr.Cause.Error()
+	}
+	return []core.Diagnostic{diag}
+}
+
+func sourceRange(rng hcl.Range) core.SourceRange {
+	return core.SourceRange{
+		File:        rng.Filename,
+		StartLine:   rng.Start.Line,
+		StartColumn: rng.Start.Column,
+		EndLine:     rng.End.Line,
+		EndColumn:   rng.End.Column,
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/yu/terraform-ops/internal/core"
+	"github.com/yu/terraform-ops/internal/ir"
+)
+
+var locationSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+		{Type: "data", LabelNames: []string{"type", "name"}},
+		{Type: "module", LabelNames: []string{"name"}},
+	},
+}
+
+// ResourceLocations maps configuration resource addresses without instance
+// keys (for example module.network.aws_subnet.private) to the blocks that
+// declare them. It reads dir and follows module calls whose source is a local
+// path; registry and remote modules are not downloaded and are skipped. Files
+// that fail to parse are skipped so a single bad file does not hide every
+// other location.
+func (p *Parser) ResourceLocations(dir string) (map[ir.Address]core.SourceRange, error) {
+	locations := make(map[ir.Address]core.SourceRange)
+	if err := p.collectResourceLocations(dir, "", locations, map[string]struct{}{}); err != nil {
+		return nil, &core.ConfigParseError{Path: dir, Message: "failed to collect resource locations", Cause: err}
+	}
+	return locations, nil
+}
+
+func (p *Parser) collectResourceLocations(dir, modulePrefix string, dest map[ir.Address]core.SourceRange, visiting map[string]struct{}) error {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	// Local module cycles are invalid Terraform, but guard anyway so a broken
+	// configuration cannot recurse forever.
+	if _, ok := visiting[absDir]; ok {
+		return nil
+	}
+	visiting[absDir] = struct{}{}
+	defer delete(visiting, absDir)
+
+	files, err := p.findTerraformFiles(dir)
+	if err != nil {
+		return err
+	}
+	for _, filePath := range files {
+		src, err := os.ReadFile(filePath)
+		if err != nil {
+			continue
+		}
+		file, diags := hclparse.NewParser().ParseHCL(src, filePath)
+		if diags.HasErrors() || file == nil || file.Body == nil {
+			continue
+		}
+		content, _, _ := file.Body.PartialContent(locationSchema)
+		if content == nil {
+			continue
+		}
+		for _, block := range content.Blocks {
+			switch block.Type {
+			case "resource":
+				dest[ir.Address(modulePrefix+block.Labels[0]+"."+block.Labels[1])] = blockRange(block)
+			case "data":
+				dest[ir.Address(modulePrefix+"data."+block.Labels[0]+"."+block.Labels[1])] = blockRange(block)
+			case "module":
+				source, ok := localModuleSource(block)
+				if !ok {
+					continue
+				}
+				// A missing or unreadable local module only loses its own locations.
+				childPrefix := modulePrefix + "module." + block.Labels[0] + "."
+				_ = p.collectResourceLocations(filepath.Join(dir, source), childPrefix, dest, visiting)
+			}
+		}
+	}
+	return nil
+}
+
+// localModuleSource returns the module block's source when it is a literal
+// relative path, the only kind of module source readable without terraform init.
+func localModuleSource(block *hcl.Block) (string, bool) {
+	attrs, _ := block.Body.JustAttributes()
+	attr, ok := attrs["source"]
+	if !ok {
+		return "", false
+	}
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || value.Type() != cty.String || !value.IsKnown() || value.IsNull() {
+		return "", false
+	}
+	source := value.AsString()
+	if !strings.HasPrefix(source, "./") && !strings.HasPrefix(source, "../") {
+		return "", false
+	}
+	return source, true
+}
+
+// blockRange spans the block from its header to its closing brace when the
+// native syntax body is available, and falls back to the header otherwise.
+func blockRange(block *hcl.Block) core.SourceRange {
+	rng := block.DefRange
+	if body, ok := block.Body.(*hclsyntax.Body); ok {
+		rng = hcl.RangeBetween(block.DefRange, body.SrcRange)
+	}
+	return sourceRange(rng)
+}
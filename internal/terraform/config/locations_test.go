@@ -0,0 +1,106 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu/terraform-ops/internal/core"
+	"github.com/yu/terraform-ops/internal/ir"
+)
+
+func TestResourceLocations(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "modules", "network"), 0o755))
+
+	mainTf := `resource "aws_instance" "web" {
+  ami = "ami-123"
+}
+
+data "aws_ami" "ubuntu" {}
+
+module "network" {
+  source = "./modules/network"
+}
+
+module "remote" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.0.0"
+}
+`
+	networkTf := `
+resource "aws_subnet" "private" {
+  cidr_block = "10.0.0.0/24"
+}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(mainTf), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "modules", "network", "main.tf"), []byte(networkTf), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "broken.tf"), []byte(`resource "x" {`), 0o644))
+
+	locations, err := NewParser().ResourceLocations(tmpDir)
+	require.NoError(t, err)
+	assert.Len(t, locations, 3)
+
+	web := locations[ir.Address("aws_instance.web")]
+	assert.Equal(t, filepath.Join(tmpDir, "main.tf"), web.File)
+	assert.Equal(t, 1, web.StartLine)
+	assert.Equal(t, 3, web.EndLine)
+
+	assert.Equal(t, 5, locations[ir.Address("data.aws_ami.ubuntu")].StartLine)
+
+	subnet := locations[ir.Address("module.network.aws_subnet.private")]
+	assert.Equal(t, filepath.Join(tmpDir, "modules", "network", "main.tf"), subnet.File)
+	assert.Equal(t, 2, subnet.StartLine)
+}
+
+func TestResourceLocationsMissingDirectory(t *testing.T) {
+	_, err := NewParser().ResourceLocations(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestNonStringModuleSourceIsSkipped(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"envs/prod/main.tf": `provider "aws" {}
+
+resource "aws_instance" "web" {}
+
+module "number" {
+  source = 1
+}
+
+module "flag" {
+  source = true
+}
+
+module "unknown" {
+  source = var.source
+}
+`,
+	})
+
+	locations, err := NewParser().ResourceLocations(filepath.Join(root, "envs", "prod"))
+	require.NoError(t, err)
+	assert.Len(t, locations, 1)
+
+	workspaces, _, err := NewParser().FindWorkspaces(root, core.WorkspaceSearchOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(root, "envs", "prod")}, workspaces)
+}
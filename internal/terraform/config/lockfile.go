@@ -0,0 +1,168 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/yu/terraform-ops/internal/core"
+	"github.com/yu/terraform-ops/internal/versionpolicy"
+)
+
+// LockFileName is the dependency lock file written by terraform init.
+const LockFileName = ".terraform.lock.hcl"
+
+var lockFileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "provider", LabelNames: []string{"source"}},
+	},
+}
+
+// readLockFile parses the lock file in dir. It returns nil without an error
+// when the workspace has no lock file.
+func readLockFile(dir string) (*core.LockFile, error) {
+	path := filepath.Join(dir, LockFileName)
+	src, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &core.ConfigParseError{Path: path, Message: "failed to read lock file", Cause: err}
+	}
+	file, diags := hclparse.NewParser().ParseHCL(src, path)
+	if diags.HasErrors() {
+		return nil, &core.ConfigParseError{Path: path, Message: "failed to parse lock file", Cause: diags}
+	}
+	content, _, diags := file.Body.PartialContent(lockFileSchema)
+	if diags.HasErrors() {
+		return nil, &core.ConfigParseError{Path: path, Message: "failed to process lock file", Cause: diags}
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		absPath = path
+	}
+	lockFile := &core.LockFile{Path: absPath, Providers: map[string]core.LockedProvider{}}
+	for _, block := range content.Blocks {
+		provider, err := parseLockedProvider(block)
+		if err != nil {
+			return nil, &core.ConfigParseError{Path: path, Message: fmt.Sprintf("invalid provider %q", block.Labels[0]), Cause: err}
+		}
+		lockFile.Providers[providerAddress("", block.Labels[0])] = provider
+	}
+	return lockFile, nil
+}
+
+func parseLockedProvider(block *hcl.Block) (core.LockedProvider, error) {
+	provider := core.LockedProvider{Hashes: []string{}}
+	attrs, diags := block.Body.JustAttributes()
+	if diags.HasErrors() {
+		return provider, diags
+	}
+	for name, attr := range attrs {
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return provider, diags
+		}
+		switch name {
+		case "version":
+			if val.Type() == cty.String && !val.IsNull() {
+				provider.Version = val.AsString()
+			}
+		case "constraints":
+			if val.Type() == cty.String && !val.IsNull() {
+				provider.Constraints = val.AsString()
+			}
+		case "hashes":
+			if !val.CanIterateElements() {
+				return provider, fmt.Errorf("hashes must be a list of strings")
+			}
+			for it := val.ElementIterator(); it.Next(); {
+				_, hash := it.Element()
+				if hash.Type() == cty.String && !hash.IsNull() {
+					provider.Hashes = append(provider.Hashes, hash.AsString())
+				}
+			}
+		}
+	}
+	return provider, nil
+}
+
+// lockMismatches compares the root module's provider requirements with the
+// lock file. Without a lock file there is nothing to compare: the workspace
+// has not been initialized.
+func lockMismatches(providers map[string]core.ProviderRequirement, lockFile *core.LockFile) []core.LockMismatch {
+	if lockFile == nil {
+		return nil
+	}
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var mismatches []core.LockMismatch
+	for _, name := range names {
+		requirement := providers[name]
+		if isBuiltinProvider(requirement.Address) {
+			continue
+		}
+		locked, ok := findLockedProvider(lockFile, requirement)
+		if !ok {
+			mismatches = append(mismatches, core.LockMismatch{
+				Provider:   name,
+				Address:    requirement.Address,
+				Kind:       core.LockMismatchMissing,
+				Constraint: requirement.Version,
+				Message:    fmt.Sprintf("provider %s (%s) is not in %s; run terraform init", name, requirement.Address, LockFileName),
+			})
+			continue
+		}
+		constraint, err := versionpolicy.ParseConstraint(requirement.Version)
+		if err != nil {
+			continue
+		}
+		version, err := versionpolicy.ParseVersion(locked.Version)
+		if err != nil || constraint.Allows(version) {
+			continue
+		}
+		mismatches = append(mismatches, core.LockMismatch{
+			Provider:      name,
+			Address:       requirement.Address,
+			Kind:          core.LockMismatchVersion,
+			Constraint:    requirement.Version,
+			LockedVersion: locked.Version,
+			Message:       fmt.Sprintf("provider %s is locked to %s, which does not satisfy %q", name, locked.Version, requirement.Version),
+		})
+	}
+	return mismatches
+}
+
+func findLockedProvider(lockFile *core.LockFile, requirement core.ProviderRequirement) (core.LockedProvider, bool) {
+	for _, address := range lockAddresses(requirement) {
+		if locked, ok := lockFile.Providers[address]; ok {
+			return locked, true
+		}
+	}
+	return core.LockedProvider{}, false
+}
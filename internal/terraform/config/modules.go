@@ -0,0 +1,130 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/yu/terraform-ops/internal/core"
+)
+
+var moduleCallSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "source"},
+		{Name: "version"},
+		{Name: "count"},
+		{Name: "for_each"},
+	},
+}
+
+// registrySourcePattern matches <namespace>/<name>/<system> with an optional
+// registry hostname in front and an optional //subdirectory after.
+var registrySourcePattern = regexp.MustCompile(`^([a-z0-9][a-z0-9.-]*\.[a-z0-9-]+(:[0-9]+)?/)?[A-Za-z0-9][A-Za-z0-9_-]*/[A-Za-z0-9][A-Za-z0-9_-]*/[A-Za-z0-9]+(//.*)?$`)
+
+// parseModuleCall reads the source, version and repetition arguments of a
+// module block. Non-literal values are left empty.
+func parseModuleCall(block *hcl.Block) core.ModuleCall {
+	call := core.ModuleCall{Name: block.Labels[0], Location: blockRange(block)}
+	content, _, _ := block.Body.PartialContent(moduleCallSchema)
+	if content == nil {
+		call.SourceType = core.ModuleSourceUnknown
+		return call
+	}
+	if attr, ok := content.Attributes["source"]; ok {
+		if source, err := stringValue(attr.Expr); err == nil {
+			call.Source = source
+		}
+	}
+	if attr, ok := content.Attributes["version"]; ok {
+		if version, err := stringValue(attr.Expr); err == nil {
+			call.Version = version
+		}
+	}
+	_, call.Count = content.Attributes["count"]
+	_, call.ForEach = content.Attributes["for_each"]
+	call.SourceType, call.Ref = classifyModuleSource(call.Source)
+	return call
+}
+
+// classifyModuleSource follows the source address forms Terraform accepts,
+// in the order Terraform's module installer detects them, and returns the
+// ref argument of git and mercurial sources.
+func classifyModuleSource(source string) (core.ModuleSourceType, string) {
+	switch {
+	case source == "":
+		return core.ModuleSourceUnknown, ""
+	case strings.HasPrefix(source, "./"), strings.HasPrefix(source, "../"):
+		return core.ModuleSourceLocal, ""
+	}
+
+	if forced, rest, ok := strings.Cut(source, "::"); ok && !strings.Contains(forced, "/") {
+		switch forced {
+		case "git":
+			return core.ModuleSourceGit, sourceRef(rest)
+		case "hg":
+			return core.ModuleSourceMercurial, sourceRef(rest)
+		case "s3":
+			return core.ModuleSourceS3, ""
+		case "gcs":
+			return core.ModuleSourceGCS, ""
+		case "http", "https":
+			return core.ModuleSourceHTTP, ""
+		default:
+			return core.ModuleSourceUnknown, ""
+		}
+	}
+
+	lower := strings.ToLower(source)
+	switch {
+	case strings.HasPrefix(lower, "git@"),
+		strings.HasPrefix(lower, "github.com/"),
+		strings.HasPrefix(lower, "bitbucket.org/"),
+		strings.HasPrefix(lower, "ssh://"):
+		return core.ModuleSourceGit, sourceRef(source)
+	case strings.HasSuffix(sourceHost(lower), ".amazonaws.com"):
+		return core.ModuleSourceS3, ""
+	case strings.HasPrefix(lower, "www.googleapis.com/storage/"):
+		return core.ModuleSourceGCS, ""
+	case strings.HasPrefix(lower, "http://"), strings.HasPrefix(lower, "https://"):
+		return core.ModuleSourceHTTP, ""
+	case registrySourcePattern.MatchString(source):
+		return core.ModuleSourceRegistry, ""
+	default:
+		return core.ModuleSourceUnknown, ""
+	}
+}
+
+// sourceHost returns the part of a scheme-less source before the first slash.
+func sourceHost(source string) string {
+	host, _, _ := strings.Cut(source, "/")
+	return host
+}
+
+// sourceRef extracts the ref query argument of a version control source.
+func sourceRef(source string) string {
+	_, query, ok := strings.Cut(source, "?")
+	if !ok {
+		return ""
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return ""
+	}
+	return values.Get("ref")
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yu/terraform-ops/internal/core"
+)
+
+func TestClassifyModuleSource(t *testing.T) {
+	tests := []struct {
+		source   string
+		wantType core.ModuleSourceType
+		wantRef  string
+	}{
+		{"./modules/network", core.ModuleSourceLocal, ""},
+		{"../shared", core.ModuleSourceLocal, ""},
+		{"terraform-aws-modules/vpc/aws", core.ModuleSourceRegistry, ""},
+		{"app.terraform.io/example-corp/k8s-cluster/azurerm", core.ModuleSourceRegistry, ""},
+		{"hashicorp/consul/aws//modules/consul-cluster", core.ModuleSourceRegistry, ""},
+		{"github.com/hashicorp/example?ref=v1.2.0", core.ModuleSourceGit, "v1.2.0"},
+		{"git@github.com:hashicorp/example.git", core.ModuleSourceGit, ""},
+		{"git::https://example.com/network.git//modules/vpc?ref=v0.4.1", core.ModuleSourceGit, "v0.4.1"},
+		{"git::ssh://username@example.com/storage.git?depth=1&ref=main", core.ModuleSourceGit, "main"},
+		{"bitbucket.org/hashicorp/terraform-consul-aws", core.ModuleSourceGit, ""},
+		{"hg::http://example.com/vpc.hg?ref=v1.2.0", core.ModuleSourceMercurial, "v1.2.0"},
+		{"s3::https://s3-eu-west-1.amazonaws.com/examplecorp-terraform-modules/vpc.zip", core.ModuleSourceS3, ""},
+		{"examplecorp-terraform-modules.s3.amazonaws.com/vpc.zip", core.ModuleSourceS3, ""},
+		{"gcs::https://www.googleapis.com/storage/v1/modules/foomodule.zip", core.ModuleSourceGCS, ""},
+		{"www.googleapis.com/storage/v1/modules/foomodule.zip", core.ModuleSourceGCS, ""},
+		{"https://example.com/vpc-module.zip", core.ModuleSourceHTTP, ""},
+		{"", core.ModuleSourceUnknown, ""},
+		{"modules", core.ModuleSourceUnknown, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			gotType, gotRef := classifyModuleSource(tt.source)
+			assert.Equal(t, tt.wantType, gotType)
+			assert.Equal(t, tt.wantRef, gotRef)
+		})
+	}
+}
+
+func TestParseConfigFiles_ModuleCalls(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainTf := `module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "~> 5.1"
+}
+
+module "buckets" {
+  source   = "git::https://example.com/storage.git?ref=v0.3.0"
+  for_each = toset(["logs", "assets"])
+  name     = each.key
+}
+
+module "dns" {
+  source = "./modules/dns"
+  count  = var.enable_dns ? 1 : 0
+}
+
+module "dynamic" {
+  source = local.module_source
+}
+`
+	assert.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(mainTf), 0644))
+
+	configs, err := NewParser().ParseConfigFiles([]string{tmpDir})
+	assert.NoError(t, err)
+	assert.Len(t, configs, 1)
+	modules := configs[0].Modules
+	assert.Len(t, modules, 4)
+
+	names := make([]string, 0, len(modules))
+	for _, module := range modules {
+		names = append(names, module.Name)
+	}
+	assert.Equal(t, []string{"buckets", "dns", "dynamic", "vpc"}, names)
+
+	assert.Equal(t, core.ModuleSourceGit, modules[0].SourceType)
+	assert.Equal(t, "v0.3.0", modules[0].Ref)
+	assert.True(t, modules[0].ForEach)
+	assert.False(t, modules[0].Count)
+
+	assert.Equal(t, core.ModuleSourceLocal, modules[1].SourceType)
+	assert.True(t, modules[1].Count)
+
+	assert.Equal(t, "", modules[2].Source)
+	assert.Equal(t, core.ModuleSourceUnknown, modules[2].SourceType)
+
+	assert.Equal(t, core.ModuleSourceRegistry, modules[3].SourceType)
+	assert.Equal(t, "~> 5.1", modules[3].Version)
+	assert.Equal(t, filepath.Join(tmpDir, "main.tf"), modules[3].Location.File)
+	assert.Equal(t, 1, modules[3].Location.StartLine)
+}
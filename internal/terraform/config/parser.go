@@ -18,12 +18,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strconv"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclparse"
-	"github.com/zclconf/go-cty/cty"
 
 	"github.com/yu/terraform-ops/internal/core"
 )
@@ -37,7 +36,9 @@ func NewParser() *Parser {
 }
 
 // ParseConfigFiles scans the provided paths (non-recursive) for *.tf files and
-// extracts information from the terraform block
+// extracts information from the terraform block. Problems in individual files
+// do not fail the call; they are recorded in each config's Diagnostics with
+// absolute file paths, however the path was given.
 func (p *Parser) ParseConfigFiles(paths []string) ([]core.TerraformConfig, error) {
 	var allConfigs []core.TerraformConfig
 
@@ -60,7 +61,7 @@ func (p *Parser) ParseConfigFiles(paths []string) ([]core.TerraformConfig, error
 			}
 		}
 
-		tfFiles, err := p.findTerraformFiles(path)
+		tfFiles, err := p.findTerraformFiles(absPath)
 		if err != nil {
 			return nil, &core.ConfigParseError{
 				Path:    path,
@@ -72,16 +73,25 @@ func (p *Parser) ParseConfigFiles(paths []string) ([]core.TerraformConfig, error
 		config := core.TerraformConfig{
 			Path:              absPath,
 			RequiredProviders: map[string]string{},
+			Providers:         map[string]core.ProviderRequirement{},
 		}
 
 		for _, filePath := range tfFiles {
 			if err := p.collectFromFile(filePath, &config); err != nil {
-				// Log error but continue processing other files
-				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				// Record the error but continue processing other files
+				config.Diagnostics = append(config.Diagnostics, parseErrorDiagnostics(err)...)
 				continue
 			}
 		}
 
+		lockFile, err := readLockFile(absPath)
+		if err != nil {
+			config.Diagnostics = append(config.Diagnostics, parseErrorDiagnostics(err)...)
+		}
+		config.LockFile = lockFile
+		config.LockMismatches = lockMismatches(config.Providers, lockFile)
+		sort.SliceStable(config.Modules, func(i, j int) bool { return config.Modules[i].Name < config.Modules[j].Name })
+
 		allConfigs = append(allConfigs, config)
 	}
 
@@ -119,7 +129,7 @@ func (p *Parser) collectFromFile(filePath string, dest *core.TerraformConfig) er
 	}
 
 	parser := hclparse.NewParser()
-	hclFile, diags := parser.ParseHCL(src, filepath.Base(filePath))
+	hclFile, diags := parser.ParseHCL(src, filePath)
 	if diags.HasErrors() {
 		return &core.ConfigParseError{
 			Path:    filePath,
@@ -135,6 +145,7 @@ func (p *Parser) collectFromFile(filePath string, dest *core.TerraformConfig) er
 	rootSchema := &hcl.BodySchema{
 		Blocks: []hcl.BlockHeaderSchema{
 			{Type: "terraform"},
+			{Type: "module", LabelNames: []string{"name"}},
 		},
 	}
 
@@ -148,6 +159,10 @@ func (p *Parser) collectFromFile(filePath string, dest *core.TerraformConfig) er
 	}
 
 	for _, block := range content.Blocks {
+		if block.Type == "module" {
+			dest.Modules = append(dest.Modules, parseModuleCall(block))
+			continue
+		}
 		if block.Type != "terraform" {
 			continue
 		}
@@ -165,7 +180,7 @@ func (p *Parser) collectFromFile(filePath string, dest *core.TerraformConfig) er
 
 		bodyContent, _, diags := block.Body.PartialContent(terraformAttrsSchema)
 		if diags.HasErrors() {
-			fmt.Fprintf(os.Stderr, "Warning: Error parsing terraform block in '%s': %v\n", filePath, diags.Error())
+			dest.Diagnostics = append(dest.Diagnostics, hclDiagnostics(diags)...)
 		}
 
 		// required_version attribute
@@ -184,9 +199,9 @@ func (p *Parser) collectFromFile(filePath string, dest *core.TerraformConfig) er
 		for _, b := range bodyContent.Blocks {
 			switch b.Type {
 			case "required_providers":
-				p.parseRequiredProvidersBlock(b.Body, dest.RequiredProviders, filePath)
+				p.parseRequiredProvidersBlock(b.Body, dest)
 			case "backend":
-				p.parseBackendBlock(b, dest, filePath)
+				p.parseBackendBlock(b, dest)
 			}
 		}
 	}
@@ -194,38 +209,33 @@ func (p *Parser) collectFromFile(filePath string, dest *core.TerraformConfig) er
 	return nil
 }
 
-// parseRequiredProvidersBlock fills providers map with any versions found
-func (p *Parser) parseRequiredProvidersBlock(body hcl.Body, providers map[string]string, filePath string) {
+// parseRequiredProvidersBlock records every provider requirement found. The
+// entries are read expression by expression because configuration_aliases
+// holds references, which cannot be evaluated without a scope.
+func (p *Parser) parseRequiredProvidersBlock(body hcl.Body, dest *core.TerraformConfig) {
 	attrs, diags := body.JustAttributes()
 	if diags.HasErrors() {
-		fmt.Fprintf(os.Stderr, "Warning: Error getting attributes from required_providers block in '%s': %v\n", filePath, diags.Error())
+		dest.Diagnostics = append(dest.Diagnostics, hclDiagnostics(diags)...)
 		return
 	}
 
 	for name, attr := range attrs {
-		val, diags := attr.Expr.Value(nil)
-		if diags.HasErrors() {
-			fmt.Fprintf(os.Stderr, "Warning: Error evaluating attribute '%s' in required_providers block in '%s': %v\n", name, filePath, diags.Error())
+		requirement, err := parseProviderRequirement(name, attr.Expr)
+		if err != nil {
+			summary := fmt.Sprintf("Invalid required_providers entry %q", name)
+			dest.Diagnostics = append(dest.Diagnostics, errorDiagnostics(err, summary, attr.Range.Filename, &attr.Range)...)
 			continue
 		}
 
-		version := ""
-		if val.Type().IsObjectType() {
-			if v, ok := val.AsValueMap()["version"]; ok && v.Type().IsPrimitiveType() {
-				version = v.AsString()
-			}
-		} else if val.Type().IsPrimitiveType() {
-			// Handle simple string version constraints
-			version = val.AsString()
-		}
-
 		// overwrite or set
-		providers[name] = version
+		dest.RequiredProviders[name] = requirement.Version
+		dest.Providers[name] = requirement
 	}
 }
 
-// parseBackendBlock extracts backend type and simple config attributes
-func (p *Parser) parseBackendBlock(block *hcl.Block, dest *core.TerraformConfig, filePath string) {
+// parseBackendBlock extracts the backend type and its settings, including
+// collection values and nested blocks
+func (p *Parser) parseBackendBlock(block *hcl.Block, dest *core.TerraformConfig) {
 	if len(block.Labels) == 0 {
 		return // malformed backend block
 	}
@@ -236,31 +246,14 @@ func (p *Parser) parseBackendBlock(block *hcl.Block, dest *core.TerraformConfig,
 		return
 	}
 
-	backendInfo := &core.Backend{
-		Type:   backendType,
-		Config: map[string]string{},
-	}
-
-	attrs, diags := block.Body.JustAttributes()
+	values, diags := backendBodyValues(block.Body)
 	if diags.HasErrors() {
-		fmt.Fprintf(os.Stderr, "Warning: Error reading backend attributes in '%s': %v\n", filePath, diags.Error())
+		dest.Diagnostics = append(dest.Diagnostics, hclDiagnostics(diags)...)
 	}
 
-	for key, attr := range attrs {
-		val, _ := attr.Expr.Value(nil)
-		if !val.IsKnown() {
-			continue
-		}
-
-		switch val.Type() {
-		case cty.String:
-			backendInfo.Config[key] = val.AsString()
-		case cty.Bool:
-			backendInfo.Config[key] = strconv.FormatBool(val.True())
-		case cty.Number:
-			backendInfo.Config[key] = val.AsBigFloat().Text('f', -1)
-		}
+	dest.Backend = &core.Backend{
+		Type:          backendType,
+		Config:        values,
+		SensitiveKeys: sensitiveBackendKeys(values, ""),
 	}
-
-	dest.Backend = backendInfo
 }
@@ -20,6 +20,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu/terraform-ops/internal/core"
 )
 
 func TestNewParser(t *testing.T) {
@@ -96,7 +99,7 @@ func TestParseConfigFiles_WithBackend(t *testing.T) {
 	assert.Equal(t, map[string]string{"aws": "~> 5.0"}, config.RequiredProviders)
 	assert.NotNil(t, config.Backend)
 	assert.Equal(t, "s3", config.Backend.Type)
-	assert.Equal(t, map[string]string{
+	assert.Equal(t, map[string]interface{}{
 		"bucket":  "terraform-state-prod",
 		"key":     "terraform/state.tfstate",
 		"region":  "us-west-2",
@@ -202,6 +205,87 @@ func TestParseConfigFiles_InvalidHCL(t *testing.T) {
 	config := configs[0]
 	assert.Empty(t, config.RequiredProviders)
 	assert.Nil(t, config.Backend)
+
+	// The skipped file is reported as a diagnostic with its source position
+	assert.NotEmpty(t, config.Diagnostics)
+	diag := config.Diagnostics[0]
+	assert.Equal(t, core.DiagnosticError, diag.Severity)
+	assert.NotEmpty(t, diag.Summary)
+	assert.Equal(t, filepath.Join(tmpDir, "main.tf"), diag.File)
+	if assert.NotNil(t, diag.Range) {
+		assert.Equal(t, 17, diag.Range.StartLine)
+	}
+}
+
+func TestParseConfigFiles_DiagnosticFilesAreAbsolute(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"envs/prod/main.tf": "provider \"aws\" {}\n\nlocals {\n  name = (\n}\n",
+	})
+	if resolved, err := filepath.EvalSymlinks(root); err != nil || resolved != root {
+		t.Skip("temporary directory is behind a symlink")
+	}
+	t.Chdir(root)
+	want := filepath.Join(root, "envs", "prod", "main.tf")
+
+	parser := NewParser()
+	direct, err := parser.ParseConfigFiles([]string{"envs/prod"})
+	require.NoError(t, err)
+	workspaces, _, err := parser.FindWorkspaces(".", core.WorkspaceSearchOptions{})
+	require.NoError(t, err)
+	discovered, err := parser.ParseConfigFiles(workspaces)
+	require.NoError(t, err)
+
+	for _, configs := range [][]core.TerraformConfig{direct, discovered} {
+		require.Len(t, configs, 1)
+		require.NotEmpty(t, configs[0].Diagnostics)
+		for _, diag := range configs[0].Diagnostics {
+			assert.Equal(t, want, diag.File)
+			if assert.NotNil(t, diag.Range) {
+				assert.Equal(t, want, diag.Range.File)
+			}
+		}
+	}
+}
+
+func TestParseConfigFiles_DiagnosticsForInvalidEntries(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainTf := `terraform {
+  required_providers {
+    aws = {
+      source  = var.aws_source
+      version = "~> 5.0"
+    }
+    google = "~> 6.0"
+  }
+}`
+	lockFile := `provider "registry.terraform.io/hashicorp/google" {
+  version = "6.1.0"
+  hashes  = "h1:abc="
+}
+`
+	assert.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(mainTf), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(tmpDir, LockFileName), []byte(lockFile), 0644))
+
+	configs, err := NewParser().ParseConfigFiles([]string{tmpDir})
+	assert.NoError(t, err)
+	config := configs[0]
+
+	// The valid provider is still reported
+	assert.Equal(t, map[string]string{"google": "~> 6.0"}, config.RequiredProviders)
+	assert.Nil(t, config.LockFile)
+	assert.Len(t, config.Diagnostics, 2)
+
+	providerDiag := config.Diagnostics[0]
+	assert.Equal(t, filepath.Join(tmpDir, "main.tf"), providerDiag.File)
+	if assert.NotNil(t, providerDiag.Range) {
+		assert.Equal(t, 4, providerDiag.Range.StartLine)
+	}
+
+	lockDiag := config.Diagnostics[1]
+	assert.Equal(t, "Invalid provider \"registry.terraform.io/hashicorp/google\"", lockDiag.Summary)
+	assert.Equal(t, filepath.Join(tmpDir, LockFileName), lockDiag.File)
+	assert.Nil(t, lockDiag.Range)
 }
 
 func TestParseConfigFiles_DirectoryNotFound(t *testing.T) {
@@ -313,7 +397,7 @@ func TestParseConfigFiles_ComplexBackend(t *testing.T) {
 	assert.Equal(t, map[string]string{"google": ">= 4.83.0,< 5.0.0"}, config.RequiredProviders)
 	assert.NotNil(t, config.Backend)
 	assert.Equal(t, "gcs", config.Backend.Type)
-	assert.Equal(t, map[string]string{
+	assert.Equal(t, map[string]interface{}{
 		"bucket":                      "terraform-state-prod",
 		"prefix":                      "terraform/state",
 		"impersonate_service_account": "test-service-account@terraform-ops-test.iam.gserviceaccount.com",
@@ -354,3 +438,112 @@ func TestFindTerraformFiles(t *testing.T) {
 		assert.True(t, filepath.Ext(file) == ".tf")
 	}
 }
+
+func TestParseConfigFiles_ProviderSourcesAndLockFile(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	mainTf := `terraform {
+  required_providers {
+    aws = {
+      source                = "hashicorp/aws"
+      version               = "~> 5.0"
+      configuration_aliases = [aws.east, aws.west]
+    }
+    google = {
+      source  = "hashicorp/google"
+      version = ">= 5.0"
+    }
+    random = ">= 3.0"
+    corp = {
+      source = "tf.example.com/Corp/Internal"
+    }
+    terraform = {
+      source = "terraform.io/builtin/terraform"
+    }
+  }
+}`
+	lockFile := `provider "registry.terraform.io/hashicorp/aws" {
+  version     = "5.31.0"
+  constraints = "~> 5.0"
+  hashes = [
+    "h1:abc=",
+    "zh:def",
+  ]
+}
+
+provider "registry.opentofu.org/hashicorp/google" {
+  version = "4.84.0"
+  hashes  = []
+}
+`
+	assert.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(mainTf), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(tmpDir, LockFileName), []byte(lockFile), 0644))
+
+	configs, err := NewParser().ParseConfigFiles([]string{tmpDir})
+	assert.NoError(t, err)
+	assert.Len(t, configs, 1)
+	config := configs[0]
+
+	assert.Equal(t, map[string]string{
+		"aws":       "~> 5.0",
+		"google":    ">= 5.0",
+		"random":    ">= 3.0",
+		"corp":      "",
+		"terraform": "",
+	}, config.RequiredProviders)
+	assert.Equal(t, core.ProviderRequirement{
+		Source:               "hashicorp/aws",
+		Address:              "registry.terraform.io/hashicorp/aws",
+		Version:              "~> 5.0",
+		ConfigurationAliases: []string{"aws.east", "aws.west"},
+	}, config.Providers["aws"])
+	assert.Equal(t, "registry.terraform.io/hashicorp/random", config.Providers["random"].Address)
+	assert.Equal(t, "tf.example.com/corp/internal", config.Providers["corp"].Address)
+
+	assert.NotNil(t, config.LockFile)
+	assert.Equal(t, filepath.Join(tmpDir, LockFileName), config.LockFile.Path)
+	assert.Equal(t, core.LockedProvider{
+		Version:     "5.31.0",
+		Constraints: "~> 5.0",
+		Hashes:      []string{"h1:abc=", "zh:def"},
+	}, config.LockFile.Providers["registry.terraform.io/hashicorp/aws"])
+
+	assert.Equal(t, []core.LockMismatch{
+		{
+			Provider: "corp",
+			Address:  "tf.example.com/corp/internal",
+			Kind:     core.LockMismatchMissing,
+			Message:  "provider corp (tf.example.com/corp/internal) is not in .terraform.lock.hcl; run terraform init",
+		},
+		{
+			Provider:      "google",
+			Address:       "registry.terraform.io/hashicorp/google",
+			Kind:          core.LockMismatchVersion,
+			Constraint:    ">= 5.0",
+			LockedVersion: "4.84.0",
+			Message:       `provider google is locked to 4.84.0, which does not satisfy ">= 5.0"`,
+		},
+		{
+			Provider:   "random",
+			Address:    "registry.terraform.io/hashicorp/random",
+			Kind:       core.LockMismatchMissing,
+			Constraint: ">= 3.0",
+			Message:    "provider random (registry.terraform.io/hashicorp/random) is not in .terraform.lock.hcl; run terraform init",
+		},
+	}, config.LockMismatches)
+}
+
+func TestParseConfigFiles_WithoutLockFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	mainTf := `terraform {
+  required_providers {
+    aws = "~> 5.0"
+  }
+}`
+	assert.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(mainTf), 0644))
+
+	configs, err := NewParser().ParseConfigFiles([]string{tmpDir})
+	assert.NoError(t, err)
+	assert.Nil(t, configs[0].LockFile)
+	assert.Empty(t, configs[0].LockMismatches)
+}
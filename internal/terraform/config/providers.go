@@ -0,0 +1,154 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/yu/terraform-ops/internal/core"
+)
+
+const (
+	// defaultProviderHost is the registry Terraform assumes for source
+	// addresses without a hostname.
+	defaultProviderHost = "registry.terraform.io"
+	// openTofuProviderHost is the registry OpenTofu records for the same
+	// source addresses in its lock files.
+	openTofuProviderHost = "registry.opentofu.org"
+	builtinProviderHost  = "terraform.io"
+)
+
+// parseProviderRequirement reads one required_providers entry: either an
+// object with source, version and configuration_aliases, or the legacy
+// version string.
+func parseProviderRequirement(name string, expr hcl.Expression) (core.ProviderRequirement, error) {
+	requirement := core.ProviderRequirement{}
+	pairs, diags := hcl.ExprMap(expr)
+	if diags.HasErrors() {
+		val, diags := expr.Value(nil)
+		if diags.HasErrors() {
+			return requirement, diags
+		}
+		if val.Type() == cty.String && val.IsKnown() && !val.IsNull() {
+			requirement.Version = val.AsString()
+		}
+		requirement.Address = providerAddress(name, "")
+		return requirement, nil
+	}
+
+	for _, pair := range pairs {
+		key, diags := pair.Key.Value(nil)
+		if diags.HasErrors() || key.Type() != cty.String {
+			continue
+		}
+		switch key.AsString() {
+		case "source":
+			source, err := stringValue(pair.Value)
+			if err != nil {
+				return requirement, fmt.Errorf("source: %w", err)
+			}
+			requirement.Source = source
+		case "version":
+			version, err := stringValue(pair.Value)
+			if err != nil {
+				return requirement, fmt.Errorf("version: %w", err)
+			}
+			requirement.Version = version
+		case "configuration_aliases":
+			aliases, err := configurationAliases(pair.Value)
+			if err != nil {
+				return requirement, fmt.Errorf("configuration_aliases: %w", err)
+			}
+			requirement.ConfigurationAliases = aliases
+		}
+	}
+	requirement.Address = providerAddress(name, requirement.Source)
+	return requirement, nil
+}
+
+func stringValue(expr hcl.Expression) (string, error) {
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return "", diags
+	}
+	if val.Type() != cty.String || !val.IsKnown() || val.IsNull() {
+		return "", fmt.Errorf("expected a string")
+	}
+	return val.AsString(), nil
+}
+
+// configurationAliases renders the provider references of a
+// configuration_aliases list, for example aws.east.
+func configurationAliases(expr hcl.Expression) ([]string, error) {
+	items, diags := hcl.ExprList(expr)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	aliases := make([]string, 0, len(items))
+	for _, item := range items {
+		traversal, diags := hcl.AbsTraversalForExpr(item)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		parts := []string{traversal.RootName()}
+		for _, step := range traversal[1:] {
+			if attr, ok := step.(hcl.TraverseAttr); ok {
+				parts = append(parts, attr.Name)
+			}
+		}
+		aliases = append(aliases, strings.Join(parts, "."))
+	}
+	return aliases, nil
+}
+
+// providerAddress returns the fully qualified address of a provider source.
+// A missing source implies hashicorp/<local name>, and a missing hostname
+// implies the public Terraform registry.
+func providerAddress(localName, source string) string {
+	if source == "" {
+		source = "hashicorp/" + localName
+	}
+	parts := strings.Split(strings.ToLower(source), "/")
+	switch len(parts) {
+	case 1:
+		return defaultProviderHost + "/hashicorp/" + parts[0]
+	case 2:
+		return defaultProviderHost + "/" + parts[0] + "/" + parts[1]
+	default:
+		return strings.Join(parts, "/")
+	}
+}
+
+// lockAddresses lists the lock file keys a requirement may be recorded under.
+// Sources without a hostname are recorded against the OpenTofu registry when
+// the workspace was initialized with tofu.
+func lockAddresses(requirement core.ProviderRequirement) []string {
+	addresses := []string{requirement.Address}
+	if strings.Count(requirement.Source, "/") < 2 {
+		suffix := strings.TrimPrefix(requirement.Address, defaultProviderHost+"/")
+		addresses = append(addresses, openTofuProviderHost+"/"+suffix)
+	}
+	return addresses
+}
+
+// isBuiltinProvider reports whether the provider ships with Terraform itself,
+// such as terraform.io/builtin/terraform, and is therefore never locked.
+func isBuiltinProvider(address string) bool {
+	return strings.HasPrefix(address, builtinProviderHost+"/builtin/")
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+
+	"github.com/yu/terraform-ops/internal/core"
+	"github.com/yu/terraform-ops/internal/glob"
+)
+
+var workspaceSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "terraform"},
+		{Type: "provider", LabelNames: []string{"name"}},
+		{Type: "module", LabelNames: []string{"name"}},
+	},
+}
+
+var workspaceTerraformSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "backend", LabelNames: []string{"type"}},
+		{Type: "cloud"},
+	},
+}
+
+// directoryRole is what a directory's .tf files say about it.
+type directoryRole struct {
+	rootModule    bool
+	moduleSources []string
+}
+
+// FindWorkspaces walks root and returns the absolute paths of root modules:
+// directories whose .tf files declare a backend, a cloud block, or a provider
+// block. Hidden directories such as .terraform/ are skipped, and directories
+// used as the local source of a module call are modules rather than
+// workspaces even when they configure a provider. The result is sorted.
+//
+// Files that fail to parse are classified from the blocks that did parse. The
+// returned diagnostics describe such files outside the root modules found;
+// ParseConfigFiles reports the ones inside a root module.
+func (p *Parser) FindWorkspaces(root string, opts core.WorkspaceSearchOptions) ([]string, []core.Diagnostic, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, nil, &core.ConfigParseError{Path: root, Message: "failed to get absolute path", Cause: err}
+	}
+	if info, err := os.Stat(absRoot); err != nil || !info.IsDir() {
+		return nil, nil, &core.ConfigParseError{Path: root, Message: "path does not exist or is not a directory", Cause: err}
+	}
+
+	var candidates []string
+	var dirs []string
+	moduleDirs := make(map[string]struct{})
+	dirDiagnostics := make(map[string][]core.Diagnostic)
+	err = filepath.WalkDir(absRoot, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if path != absRoot && strings.HasPrefix(entry.Name(), ".") {
+			return filepath.SkipDir
+		}
+		role, diags, err := p.classifyDirectory(path)
+		if err != nil {
+			return err
+		}
+		if len(diags) > 0 {
+			dirs = append(dirs, path)
+			dirDiagnostics[path] = diags
+		}
+		if role.rootModule {
+			candidates = append(candidates, path)
+		}
+		for _, source := range role.moduleSources {
+			moduleDirs[filepath.Join(path, source)] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, &core.ConfigParseError{Path: root, Message: "failed to walk directory", Cause: err}
+	}
+
+	var workspaces []string
+	rootModules := make(map[string]struct{})
+	for _, dir := range candidates {
+		if _, ok := moduleDirs[dir]; ok {
+			continue
+		}
+		rootModules[dir] = struct{}{}
+		if !matchesWorkspaceFilters(opts, workspaceRelPath(absRoot, dir)) {
+			continue
+		}
+		workspaces = append(workspaces, dir)
+	}
+	sort.Strings(workspaces)
+
+	var diagnostics []core.Diagnostic
+	for _, dir := range dirs {
+		if _, ok := rootModules[dir]; !ok {
+			diagnostics = append(diagnostics, dirDiagnostics[dir]...)
+		}
+	}
+	return workspaces, diagnostics, nil
+}
+
+// classifyDirectory reads the .tf files directly in dir. A file that fails to
+// parse is classified from the blocks HCL recovered, so one broken file does
+// not hide a workspace, and its problems are returned as diagnostics.
+func (p *Parser) classifyDirectory(dir string) (directoryRole, []core.Diagnostic, error) {
+	var role directoryRole
+	var diagnostics []core.Diagnostic
+	files, err := p.findTerraformFiles(dir)
+	if err != nil {
+		return role, nil, err
+	}
+	for _, filePath := range files {
+		src, err := os.ReadFile(filePath)
+		if err != nil {
+			diagnostics = append(diagnostics, errorDiagnostics(err, "Failed to read file", filePath, nil)...)
+			continue
+		}
+		file, diags := hclparse.NewParser().ParseHCL(src, filePath)
+		if diags.HasErrors() {
+			diagnostics = append(diagnostics, hclDiagnostics(diags)...)
+		}
+		if file == nil || file.Body == nil {
+			continue
+		}
+		content, _, _ := file.Body.PartialContent(workspaceSchema)
+		if content == nil {
+			continue
+		}
+		for _, block := range content.Blocks {
+			switch block.Type {
+			case "provider":
+				role.rootModule = true
+			case "terraform":
+				inner, _, _ := block.Body.PartialContent(workspaceTerraformSchema)
+				if inner != nil && len(inner.Blocks) > 0 {
+					role.rootModule = true
+				}
+			case "module":
+				if source, ok := localModuleSource(block); ok {
+					role.moduleSources = append(role.moduleSources, source)
+				}
+			}
+		}
+	}
+	return role, diagnostics, nil
+}
+
+// workspaceRelPath is dir relative to root with forward slashes, "." for root.
+func workspaceRelPath(root, dir string) string {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return filepath.ToSlash(dir)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// matchesWorkspaceFilters applies the include and exclude globs. '*' also
+// matches '/', so "envs/*" selects every workspace below envs.
+func matchesWorkspaceFilters(opts core.WorkspaceSearchOptions, rel string) bool {
+	if len(opts.Include) > 0 && !glob.MatchAny(opts.Include, rel) {
+		return false
+	}
+	return !glob.MatchAny(opts.Exclude, rel)
+}
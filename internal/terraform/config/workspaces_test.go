@@ -0,0 +1,111 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu/terraform-ops/internal/core"
+)
+
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func TestFindWorkspaces(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"envs/prod/main.tf": `terraform {
+  backend "s3" {
+    bucket = "state"
+  }
+}
+module "network" {
+  source = "../../modules/network"
+}`,
+		"envs/staging/main.tf": `provider "aws" {}`,
+		"envs/sandbox/main.tf": `terraform {
+  cloud {}
+}`,
+		"envs/draft/broken.tf": `provider "aws" {`,
+		"modules/network/vpc.tf": `provider "aws" {
+  region = "us-east-1"
+}`,
+		"modules/util/main.tf":                   `variable "name" {}`,
+		"envs/prod/.terraform/modules/x/main.tf": `provider "aws" {}`,
+		"docs/README.md":                         "not terraform",
+	})
+
+	parser := NewParser()
+	workspaces, diags, err := parser.FindWorkspaces(root, core.WorkspaceSearchOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(root, "envs", "draft"),
+		filepath.Join(root, "envs", "prod"),
+		filepath.Join(root, "envs", "sandbox"),
+		filepath.Join(root, "envs", "staging"),
+	}, workspaces)
+	assert.Empty(t, diags, "broken files inside a workspace are reported by ParseConfigFiles")
+
+	workspaces, _, err = parser.FindWorkspaces(root, core.WorkspaceSearchOptions{
+		Include: []string{"envs/*"},
+		Exclude: []string{"*/sandbox"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(root, "envs", "draft"),
+		filepath.Join(root, "envs", "prod"),
+		filepath.Join(root, "envs", "staging"),
+	}, workspaces)
+}
+
+func TestFindWorkspacesClassifiesBrokenFiles(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"envs/prod/providers.tf": `provider "aws" {
+  region = "us-east-1"
+}
+
+locals {
+  name = (
+}`,
+		"envs/prod/main.tf":       `variable "name" {}`,
+		"modules/network/main.tf": `variable "cidr" {`,
+	})
+
+	workspaces, diags, err := NewParser().FindWorkspaces(root, core.WorkspaceSearchOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(root, "envs", "prod")}, workspaces)
+	require.NotEmpty(t, diags)
+	for _, diag := range diags {
+		assert.Equal(t, core.DiagnosticError, diag.Severity)
+		assert.Equal(t, filepath.Join(root, "modules", "network", "main.tf"), diag.File)
+	}
+}
+
+func TestFindWorkspacesMissingRoot(t *testing.T) {
+	_, _, err := NewParser().FindWorkspaces(filepath.Join(t.TempDir(), "missing"), core.WorkspaceSearchOptions{})
+	assert.Error(t, err)
+}
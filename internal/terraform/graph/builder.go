@@ -15,10 +15,12 @@
 package graph
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/yu/terraform-ops/internal/core"
+	"github.com/yu/terraform-ops/internal/glob"
 	"github.com/yu/terraform-ops/internal/ir"
 )
 
@@ -45,21 +47,49 @@ func (b *Builder) BuildGraph(changeSet *ir.ChangeSet, opts core.GraphOptions) (*
 		outputs["output."+output.Name] = output
 	}
 
+	focus, neighborhood, err := focusNeighborhood(changeSet.Graph, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	graphData := &core.GraphData{}
 	included := make(map[ir.NodeID]string, len(changeSet.Graph.Nodes))
+	modules := make(map[string]*core.GraphNode)
 	for _, node := range changeSet.Graph.Nodes {
+		if neighborhood != nil {
+			if _, ok := neighborhood[node.ID]; !ok {
+				continue
+			}
+		}
 		view, ok := projectNode(node, resources, outputs, opts)
 		if !ok {
 			continue
 		}
+		_, view.Focus = focus[node.ID]
+		if module := collapsedModule(view.Module, opts.CollapseModules); module != "" {
+			summary, ok := modules[module]
+			if !ok {
+				summary = newModuleNode(module)
+				modules[module] = summary
+			}
+			countAction(summary.Summary, view.Actions)
+			summary.Focus = summary.Focus || view.Focus
+			included[node.ID] = summary.ID
+			continue
+		}
 		graphData.Nodes = append(graphData.Nodes, view)
 		included[node.ID] = view.ID
 	}
+	for _, summary := range modules {
+		summary.Actions = destructiveActions(*summary.Summary)
+		graphData.Nodes = append(graphData.Nodes, *summary)
+	}
 
 	// Multiple pieces of normalized evidence can connect the same pair of
-	// resources. Diagram renderers need one visual edge, while ChangeSet retains
-	// every evidence kind/confidence separately.
-	edgeSet := make(map[string]core.GraphEdge)
+	// resources, or of collapsed modules. Diagram renderers need one visual
+	// edge, so the edge keeps the distinct evidence kinds and the strongest
+	// confidence among them. Edges inside a collapsed module are dropped.
+	edgeSet := make(map[string]*core.GraphEdge)
 	for _, edge := range changeSet.Graph.Edges {
 		from, fromOK := included[edge.From]
 		to, toOK := included[edge.To]
@@ -67,10 +97,20 @@ func (b *Builder) BuildGraph(changeSet *ir.ChangeSet, opts core.GraphOptions) (*
 			continue
 		}
 		key := from + "\x00" + to
-		edgeSet[key] = core.GraphEdge{From: from, To: to}
+		view, ok := edgeSet[key]
+		if !ok {
+			view = &core.GraphEdge{From: from, To: to, Confidence: edge.Confidence}
+			edgeSet[key] = view
+		} else if confidenceRank(edge.Confidence) > confidenceRank(view.Confidence) {
+			view.Confidence = edge.Confidence
+		}
+		if edge.Kind != "" && !containsEdgeKind(view.Kinds, edge.Kind) {
+			view.Kinds = append(view.Kinds, edge.Kind)
+		}
 	}
 	for _, edge := range edgeSet {
-		graphData.Edges = append(graphData.Edges, edge)
+		sort.Slice(edge.Kinds, func(i, j int) bool { return edge.Kinds[i] < edge.Kinds[j] })
+		graphData.Edges = append(graphData.Edges, *edge)
 	}
 
 	sort.Slice(graphData.Nodes, func(i, j int) bool { return graphData.Nodes[i].Address < graphData.Nodes[j].Address })
@@ -83,6 +123,107 @@ func (b *Builder) BuildGraph(changeSet *ir.ChangeSet, opts core.GraphOptions) (*
 	return graphData, nil
 }
 
+// focusNeighborhood resolves --focus patterns against node addresses and
+// returns the matching nodes and their neighborhood. Both are nil when no
+// focus is requested, meaning every node is rendered.
+func focusNeighborhood(graph ir.DependencyGraph, opts core.GraphOptions) (map[ir.NodeID]struct{}, map[ir.NodeID]struct{}, error) {
+	if len(opts.Focus) == 0 {
+		return nil, nil, nil
+	}
+	focus := make(map[ir.NodeID]struct{})
+	var seeds []ir.NodeID
+	for _, node := range graph.Nodes {
+		if glob.MatchAny(opts.Focus, string(node.Address)) {
+			focus[node.ID] = struct{}{}
+			seeds = append(seeds, node.ID)
+		}
+	}
+	if len(seeds) == 0 {
+		return nil, nil, &core.ValidationError{
+			Field:   "focus",
+			Message: fmt.Sprintf("no graph node matches %s", strings.Join(opts.Focus, ", ")),
+		}
+	}
+
+	upstream, downstream := opts.Upstream, opts.Downstream
+	if !upstream && !downstream {
+		upstream, downstream = true, true
+	}
+	neighborhood := make(map[ir.NodeID]struct{})
+	for _, id := range graph.Neighborhood(seeds, upstream, downstream, opts.Depth) {
+		neighborhood[id] = struct{}{}
+	}
+	return focus, neighborhood, nil
+}
+
+// collapsedModule returns the module at depth whose summary node replaces a
+// node declared in module, or "" when the node is rendered on its own.
+func collapsedModule(module string, depth int) string {
+	if depth <= 0 {
+		return ""
+	}
+	path := ir.Address(module).ModulePath()
+	if len(path) < depth {
+		return ""
+	}
+	return string(path[depth-1])
+}
+
+// newModuleNode creates the summary node of a collapsed module. It belongs to
+// the parent module so module grouping still nests it.
+func newModuleNode(module string) *core.GraphNode {
+	path := ir.Address(module).ModulePath()
+	parent := ""
+	if len(path) > 1 {
+		parent = string(path[len(path)-2])
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(module, parent), ".")
+	return &core.GraphNode{
+		ID:      sanitizeID(module),
+		Address: module,
+		Type:    string(core.NodeTypeModule),
+		Name:    strings.TrimPrefix(name, "module."),
+		Module:  parent,
+		Summary: &core.ModuleSummary{},
+	}
+}
+
+// countAction adds one collapsed node to a module summary. Data source reads
+// change nothing and count as no-op, as they are colored in the diagrams.
+func countAction(summary *core.ModuleSummary, actions []string) {
+	summary.Nodes++
+	action := ir.NormalizeAction(actions)
+	switch {
+	case action.IsReplace():
+		summary.Replace++
+	case action.Semantic == ir.ActionCreate:
+		summary.Create++
+	case action.Semantic == ir.ActionUpdate:
+		summary.Update++
+	case action.Semantic == ir.ActionDelete:
+		summary.Delete++
+	default:
+		summary.NoOp++
+	}
+}
+
+// destructiveActions returns the raw actions of the most destructive change in
+// a collapsed module, so the module is colored like its riskiest node.
+func destructiveActions(summary core.ModuleSummary) []string {
+	switch {
+	case summary.Delete > 0:
+		return []string{"delete"}
+	case summary.Replace > 0:
+		return []string{"delete", "create"}
+	case summary.Update > 0:
+		return []string{"update"}
+	case summary.Create > 0:
+		return []string{"create"}
+	default:
+		return []string{"no-op"}
+	}
+}
+
 func projectNode(
 	node ir.Node,
 	resources map[string]ir.ResourceChange,
@@ -128,7 +269,10 @@ func projectNode(
 		view.Type = resource.Type
 		view.Name = resource.Name
 		view.Module = module
-		view.Provider = extractProviderFromType(resource.Type)
+		view.Provider = resource.ProviderLabel()
+		if view.Provider == "" {
+			view.Provider = extractProviderFromType(resource.Type)
+		}
 		view.Actions = append([]string(nil), resource.Action.Raw...)
 		view.Sensitive = len(resource.SensitivePaths) > 0
 	case ir.NodeKindOutput:
@@ -156,6 +300,29 @@ func projectNode(
 	return view, true
 }
 
+// confidenceRank orders evidence confidences from unknown to exact.
+func confidenceRank(confidence ir.EvidenceConfidence) int {
+	switch confidence {
+	case ir.ConfidenceExact:
+		return 3
+	case ir.ConfidenceStrong:
+		return 2
+	case ir.ConfidenceHeuristic:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func containsEdgeKind(kinds []ir.EdgeKind, kind ir.EdgeKind) bool {
+	for _, existing := range kinds {
+		if existing == kind {
+			return true
+		}
+	}
+	return false
+}
+
 func sanitizeID(id string) string {
 	replacements := map[string]string{
 		".": "_",
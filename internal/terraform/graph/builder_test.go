@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/yu/terraform-ops/internal/core"
+	"github.com/yu/terraform-ops/internal/glob"
 	"github.com/yu/terraform-ops/internal/ir"
 )
 
@@ -34,7 +35,8 @@ func TestBuildGraphProjectsNormalizedNodesAndEdges(t *testing.T) {
 			},
 			{
 				Address: "data.aws_ami.latest", Mode: ir.ResourceModeData, Type: "aws_ami", Name: "latest",
-				Action: ir.NormalizeAction([]string{"read"}),
+				Action:       ir.NormalizeAction([]string{"read"}),
+				ProviderName: "registry.terraform.io/hashicorp/aws", ProviderAlias: "us_east_1",
 			},
 			{
 				Address: "module.child.aws_instance.worker", ModuleAddress: &module, Mode: ir.ResourceModeManaged,
@@ -72,10 +74,21 @@ func TestBuildGraphProjectsNormalizedNodesAndEdges(t *testing.T) {
 		byAddress[node.Address] = node
 	}
 	assert.Equal(t, "aws", byAddress["aws_instance.web"].Provider)
+	assert.Equal(t, "aws.us_east_1", byAddress["data.aws_ami.latest"].Provider)
 	assert.Equal(t, []string{"update"}, byAddress["aws_instance.web"].Actions)
 	assert.Equal(t, string(core.NodeTypeVariable), byAddress["var.region"].Type)
 	assert.Equal(t, string(core.NodeTypeOutput), byAddress["output.id"].Type)
 	assert.Equal(t, "module.child", byAddress["module.child.aws_instance.worker"].Module)
+
+	// The merged edge keeps every evidence kind and the strongest confidence.
+	var merged core.GraphEdge
+	for _, edge := range got.Edges {
+		if edge.From == "aws_instance_web" && edge.To == "module_child_aws_instance_worker" {
+			merged = edge
+		}
+	}
+	assert.Equal(t, []ir.EdgeKind{ir.EdgeExpressionRef, ir.EdgeModuleInput}, merged.Kinds)
+	assert.Equal(t, ir.ConfidenceExact, merged.Confidence)
 }
 
 func TestBuildGraphFiltersNormalizedNodeKinds(t *testing.T) {
@@ -116,3 +129,218 @@ func TestBuildGraphRejectsNilChangeSet(t *testing.T) {
 func TestSanitizeID(t *testing.T) {
 	assert.Equal(t, "module_child_aws_instance_web_0_", sanitizeID("module.child.aws-instance.web[0]"))
 }
+
+// testResource returns a managed resource change for the builder tests.
+func testResource(address, typ, name string, moduleAddress *ir.Address, actions ...string) ir.ResourceChange {
+	return ir.ResourceChange{
+		Address: ir.Address(address), ModuleAddress: moduleAddress, Mode: ir.ResourceModeManaged,
+		Type: typ, Name: name, Action: ir.NormalizeAction(actions),
+	}
+}
+
+// testNode returns the dependency graph node of a managed resource.
+func testNode(address string) ir.Node {
+	return ir.Node{ID: ir.NodeID(address), Address: ir.Address(address), Kind: ir.NodeKindResource}
+}
+
+// testEdge returns a dependency graph edge between two resource nodes.
+func testEdge(from, to string, kind ir.EdgeKind, confidence ir.EvidenceConfidence) ir.Edge {
+	return ir.Edge{From: ir.NodeID(from), To: ir.NodeID(to), Kind: kind, Confidence: confidence}
+}
+
+func graphAddresses(graphData *core.GraphData) []string {
+	addresses := make([]string, 0, len(graphData.Nodes))
+	for _, node := range graphData.Nodes {
+		addresses = append(addresses, node.Address)
+	}
+	return addresses
+}
+
+func TestBuildGraphFocus(t *testing.T) {
+	module := ir.Address("module.db")
+	// vpc -> subnet -> db -> app -> dns, and an unrelated bucket.
+	changeSet := &ir.ChangeSet{
+		Resources: []ir.ResourceChange{
+			testResource("aws_vpc.main", "aws_vpc", "main", nil, "update"),
+			testResource("aws_subnet.db", "aws_subnet", "db", nil, "update"),
+			testResource("module.db.aws_db_instance.main", "aws_db_instance", "main", &module, "update"),
+			testResource("aws_instance.app", "aws_instance", "app", nil, "update"),
+			testResource("aws_route53_record.app", "aws_route53_record", "app", nil, "update"),
+			testResource("aws_s3_bucket.logs", "aws_s3_bucket", "logs", nil, "update"),
+		},
+		Graph: ir.DependencyGraph{
+			Nodes: []ir.Node{
+				testNode("aws_vpc.main"), testNode("aws_subnet.db"), testNode("module.db.aws_db_instance.main"),
+				testNode("aws_instance.app"), testNode("aws_route53_record.app"), testNode("aws_s3_bucket.logs"),
+			},
+			Edges: []ir.Edge{
+				testEdge("aws_vpc.main", "aws_subnet.db", ir.EdgeExpressionRef, ir.ConfidenceExact),
+				testEdge("aws_subnet.db", "module.db.aws_db_instance.main", ir.EdgeExpressionRef, ir.ConfidenceExact),
+				testEdge("module.db.aws_db_instance.main", "aws_instance.app", ir.EdgeExpressionRef, ir.ConfidenceExact),
+				testEdge("aws_instance.app", "aws_route53_record.app", ir.EdgeExpressionRef, ir.ConfidenceExact),
+			},
+		},
+	}
+	focus := []string{"module.db.aws_db_instance.main"}
+	tests := []struct {
+		name string
+		opts core.GraphOptions
+		want []string
+	}{
+		{
+			name: "both directions unlimited",
+			opts: core.GraphOptions{Focus: focus},
+			want: []string{"aws_instance.app", "aws_route53_record.app", "aws_subnet.db", "aws_vpc.main", "module.db.aws_db_instance.main"},
+		},
+		{
+			name: "downstream",
+			opts: core.GraphOptions{Focus: focus, Downstream: true},
+			want: []string{"aws_instance.app", "aws_route53_record.app", "module.db.aws_db_instance.main"},
+		},
+		{
+			name: "upstream with depth",
+			opts: core.GraphOptions{Focus: focus, Upstream: true, Depth: 1},
+			want: []string{"aws_subnet.db", "module.db.aws_db_instance.main"},
+		},
+		{
+			name: "glob",
+			opts: core.GraphOptions{Focus: []string{"aws_s3_*"}},
+			want: []string{"aws_s3_bucket.logs"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBuilder().BuildGraph(changeSet, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, graphAddresses(got))
+			for _, node := range got.Nodes {
+				assert.Equal(t, glob.MatchAny(tt.opts.Focus, node.Address), node.Focus, node.Address)
+			}
+		})
+	}
+}
+
+func TestBuildGraphFocusKeepsEdgesWithinNeighborhood(t *testing.T) {
+	// subnet -> db -> app -> dns
+	changeSet := &ir.ChangeSet{
+		Resources: []ir.ResourceChange{
+			testResource("aws_subnet.db", "aws_subnet", "db", nil, "update"),
+			testResource("aws_db_instance.main", "aws_db_instance", "main", nil, "update"),
+			testResource("aws_instance.app", "aws_instance", "app", nil, "update"),
+			testResource("aws_route53_record.app", "aws_route53_record", "app", nil, "update"),
+		},
+		Graph: ir.DependencyGraph{
+			Nodes: []ir.Node{
+				testNode("aws_subnet.db"), testNode("aws_db_instance.main"),
+				testNode("aws_instance.app"), testNode("aws_route53_record.app"),
+			},
+			Edges: []ir.Edge{
+				testEdge("aws_subnet.db", "aws_db_instance.main", ir.EdgeExpressionRef, ir.ConfidenceExact),
+				testEdge("aws_db_instance.main", "aws_instance.app", ir.EdgeExpressionRef, ir.ConfidenceExact),
+				testEdge("aws_instance.app", "aws_route53_record.app", ir.EdgeExpressionRef, ir.ConfidenceExact),
+			},
+		},
+	}
+	got, err := NewBuilder().BuildGraph(changeSet, core.GraphOptions{
+		Focus: []string{"aws_instance.app"}, Depth: 1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"aws_db_instance.main", "aws_instance.app", "aws_route53_record.app"}, graphAddresses(got))
+	assert.Len(t, got.Edges, 2)
+}
+
+func TestBuildGraphFocusWithoutMatches(t *testing.T) {
+	changeSet := &ir.ChangeSet{
+		Resources: []ir.ResourceChange{testResource("aws_s3_bucket.logs", "aws_s3_bucket", "logs", nil, "update")},
+		Graph:     ir.DependencyGraph{Nodes: []ir.Node{testNode("aws_s3_bucket.logs")}},
+	}
+	_, err := NewBuilder().BuildGraph(changeSet, core.GraphOptions{Focus: []string{"aws_lambda_function.*"}})
+	var validation *core.ValidationError
+	require.ErrorAs(t, err, &validation)
+	assert.Equal(t, "focus", validation.Field)
+}
+
+func TestBuildGraphCollapseModules(t *testing.T) {
+	network := ir.Address("module.network")
+	subnets := ir.Address("module.network.module.subnets")
+	app := ir.Address(`module.app["blue"]`)
+	changeSet := &ir.ChangeSet{
+		Resources: []ir.ResourceChange{
+			testResource("aws_iam_role.deploy", "aws_iam_role", "deploy", nil, "update"),
+			testResource("module.network.aws_vpc.main", "aws_vpc", "main", &network, "create"),
+			testResource("module.network.aws_route_table.main", "aws_route_table", "main", &network, "update"),
+			testResource("module.network.module.subnets.aws_subnet.a", "aws_subnet", "a", &subnets, "delete"),
+			testResource(`module.app["blue"].aws_instance.web`, "aws_instance", "web", &app, "delete", "create"),
+			testResource(`module.app["blue"].aws_eip.web`, "aws_eip", "web", &app, "no-op"),
+		},
+		Graph: ir.DependencyGraph{
+			Nodes: []ir.Node{
+				testNode("aws_iam_role.deploy"),
+				testNode("module.network.aws_vpc.main"),
+				testNode("module.network.aws_route_table.main"),
+				testNode("module.network.module.subnets.aws_subnet.a"),
+				testNode(`module.app["blue"].aws_instance.web`),
+				testNode(`module.app["blue"].aws_eip.web`),
+			},
+			Edges: []ir.Edge{
+				testEdge("module.network.aws_vpc.main", "module.network.aws_route_table.main", ir.EdgeExpressionRef, ir.ConfidenceExact),
+				testEdge("module.network.aws_vpc.main", "module.network.module.subnets.aws_subnet.a", ir.EdgeModuleInput, ir.ConfidenceStrong),
+				testEdge("module.network.module.subnets.aws_subnet.a", `module.app["blue"].aws_instance.web`, ir.EdgeModuleOutput, ir.ConfidenceStrong),
+				testEdge("module.network.aws_vpc.main", `module.app["blue"].aws_instance.web`, ir.EdgeConservative, ir.ConfidenceHeuristic),
+				testEdge("aws_iam_role.deploy", `module.app["blue"].aws_instance.web`, ir.EdgeExpressionRef, ir.ConfidenceExact),
+			},
+		},
+	}
+
+	t.Run("top level", func(t *testing.T) {
+		got, err := NewBuilder().BuildGraph(changeSet, core.GraphOptions{CollapseModules: 1})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"aws_iam_role.deploy", `module.app["blue"]`, "module.network"}, graphAddresses(got))
+		app, network := got.Nodes[1], got.Nodes[2]
+
+		assert.Equal(t, string(core.NodeTypeModule), network.Type)
+		assert.Equal(t, "network", network.Name)
+		assert.Equal(t, "", network.Module)
+		assert.Equal(t, &core.ModuleSummary{Nodes: 3, Create: 1, Update: 1, Delete: 1}, network.Summary)
+		assert.Equal(t, []string{"delete"}, network.Actions)
+
+		assert.Equal(t, `app["blue"]`, app.Name)
+		assert.Equal(t, &core.ModuleSummary{Nodes: 2, Replace: 1, NoOp: 1}, app.Summary)
+		assert.Equal(t, []string{"delete", "create"}, app.Actions)
+
+		assert.Equal(t, []core.GraphEdge{
+			{From: "aws_iam_role_deploy", To: app.ID, Kinds: []ir.EdgeKind{ir.EdgeExpressionRef}, Confidence: ir.ConfidenceExact},
+			{From: network.ID, To: app.ID, Kinds: []ir.EdgeKind{ir.EdgeConservative, ir.EdgeModuleOutput}, Confidence: ir.ConfidenceStrong},
+		}, got.Edges)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		got, err := NewBuilder().BuildGraph(changeSet, core.GraphOptions{CollapseModules: 2})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"aws_iam_role.deploy",
+			`module.app["blue"].aws_eip.web`,
+			`module.app["blue"].aws_instance.web`,
+			"module.network.aws_route_table.main",
+			"module.network.aws_vpc.main",
+			"module.network.module.subnets",
+		}, graphAddresses(got))
+		subnets := got.Nodes[5]
+		assert.Equal(t, "subnets", subnets.Name)
+		assert.Equal(t, "module.network", subnets.Module)
+		assert.Equal(t, &core.ModuleSummary{Nodes: 1, Delete: 1}, subnets.Summary)
+		assert.Len(t, got.Edges, 5)
+	})
+
+	t.Run("marks focus", func(t *testing.T) {
+		got, err := NewBuilder().BuildGraph(changeSet, core.GraphOptions{
+			CollapseModules: 1, Focus: []string{"module.network.module.subnets.*"}, Downstream: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{`module.app["blue"]`, "module.network"}, graphAddresses(got))
+		assert.False(t, got.Nodes[0].Focus)
+		assert.True(t, got.Nodes[1].Focus)
+	})
+}
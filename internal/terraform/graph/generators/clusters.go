@@ -0,0 +1,222 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yu/terraform-ops/internal/core"
+	"github.com/yu/terraform-ops/internal/ir"
+)
+
+// rootModuleLabel names the cluster of nodes that belong to no module.
+const rootModuleLabel = "root"
+
+// noProviderLabel names the provider cluster of outputs and variables.
+const noProviderLabel = "(no provider)"
+
+// actionClusterOrder lists action clusters from the least to the most
+// destructive so the layout reads the same in every format.
+var actionClusterOrder = []core.ActionType{
+	core.ActionCreate,
+	core.ActionUpdate,
+	core.ActionReplace,
+	core.ActionDelete,
+	core.ActionNoOp,
+}
+
+// cluster is a group of nodes rendered as a subgraph. Only module clusters
+// have children: module.a.module.b is nested inside module.a.
+type cluster struct {
+	id       string
+	label    string
+	nodes    []core.GraphNode
+	children []*cluster
+}
+
+// buildClusters groups nodes by the grouping strategy, defaulting to module.
+// The clusters and their children come out in a deterministic order, and
+// nodes keep their input order within a cluster.
+func buildClusters(nodes []core.GraphNode, strategy core.GroupingStrategy) []*cluster {
+	var clusters []*cluster
+	switch strategy {
+	case core.GroupByAction:
+		clusters = actionClusters(nodes)
+	case core.GroupByResourceType:
+		clusters = flatClusters(nodes, "type_", func(node core.GraphNode) string { return node.Type })
+	case core.GroupByProvider:
+		clusters = flatClusters(nodes, "provider_", func(node core.GraphNode) string {
+			if node.Provider == "" {
+				return noProviderLabel
+			}
+			return node.Provider
+		})
+	default:
+		clusters = moduleClusters(nodes)
+	}
+	assignUniqueIDs(clusters, map[string]int{})
+	return clusters
+}
+
+func actionClusters(nodes []core.GraphNode) []*cluster {
+	byAction := make(map[core.ActionType]*cluster)
+	for _, node := range nodes {
+		action := getActionType(node.Actions)
+		c, ok := byAction[action]
+		if !ok {
+			c = &cluster{id: clusterID("action_" + string(action)), label: string(action)}
+			byAction[action] = c
+		}
+		c.nodes = append(c.nodes, node)
+	}
+	clusters := make([]*cluster, 0, len(byAction))
+	for _, action := range actionClusterOrder {
+		if c, ok := byAction[action]; ok {
+			clusters = append(clusters, c)
+		}
+	}
+	return clusters
+}
+
+func flatClusters(nodes []core.GraphNode, idPrefix string, key func(core.GraphNode) string) []*cluster {
+	byKey := make(map[string]*cluster)
+	for _, node := range nodes {
+		label := key(node)
+		c, ok := byKey[label]
+		if !ok {
+			c = &cluster{id: clusterID(idPrefix + label), label: label}
+			byKey[label] = c
+		}
+		c.nodes = append(c.nodes, node)
+	}
+	clusters := make([]*cluster, 0, len(byKey))
+	for _, c := range byKey {
+		clusters = append(clusters, c)
+	}
+	sortClusters(clusters)
+	return clusters
+}
+
+// moduleClusters builds the module tree. Root module nodes form the "root"
+// cluster; every module call gets a cluster inside its parent module's, even
+// when only its child modules contain nodes.
+func moduleClusters(nodes []core.GraphNode) []*cluster {
+	var root *cluster
+	var top []*cluster
+	byModule := make(map[string]*cluster)
+
+	var moduleCluster func(path []string) *cluster
+	moduleCluster = func(path []string) *cluster {
+		address := path[len(path)-1]
+		if c, ok := byModule[address]; ok {
+			return c
+		}
+		c := &cluster{id: clusterID(address), label: address}
+		byModule[address] = c
+		if len(path) == 1 {
+			top = append(top, c)
+		} else {
+			parent := moduleCluster(path[:len(path)-1])
+			parent.children = append(parent.children, c)
+		}
+		return c
+	}
+
+	for _, node := range nodes {
+		path := modulePath(node.Module)
+		if len(path) == 0 {
+			if root == nil {
+				root = &cluster{id: rootModuleLabel, label: rootModuleLabel}
+			}
+			root.nodes = append(root.nodes, node)
+			continue
+		}
+		c := moduleCluster(path)
+		c.nodes = append(c.nodes, node)
+	}
+
+	sortClusters(top)
+	if root != nil {
+		top = append([]*cluster{root}, top...)
+	}
+	return top
+}
+
+// modulePath returns the addresses of a module and its ancestors, outermost
+// first. The "root" label used for root module nodes has an empty path.
+func modulePath(module string) []string {
+	if module == rootModuleLabel {
+		return nil
+	}
+	var path []string
+	for _, address := range ir.Address(module).ModulePath() {
+		path = append(path, string(address))
+	}
+	return path
+}
+
+func sortClusters(clusters []*cluster) {
+	sort.SliceStable(clusters, func(i, j int) bool { return clusters[i].label < clusters[j].label })
+	for _, c := range clusters {
+		sortClusters(c.children)
+	}
+}
+
+// clusterID turns a label into an identifier that is valid in DOT, Mermaid
+// and PlantUML.
+func clusterID(label string) string {
+	var b strings.Builder
+	for _, r := range label {
+		if r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// assignUniqueIDs suffixes identifiers that collide after sanitizing, such as
+// module.a-b and module.a_b.
+func assignUniqueIDs(clusters []*cluster, seen map[string]int) {
+	for _, c := range clusters {
+		seen[c.id]++
+		if n := seen[c.id]; n > 1 {
+			c.id = fmt.Sprintf("%s_%d", c.id, n)
+		}
+		assignUniqueIDs(c.children, seen)
+	}
+}
+
+// clusterWriter renders clusters for one output format. indent is the
+// indentation of the line being written and grows with each nesting level.
+type clusterWriter struct {
+	open  func(c *cluster, indent string)
+	node  func(node core.GraphNode, indent string)
+	close func(c *cluster, indent string)
+}
+
+func (w clusterWriter) write(clusters []*cluster, indent, step string) {
+	for _, c := range clusters {
+		w.open(c, indent)
+		for _, node := range c.nodes {
+			w.node(node, indent+step)
+		}
+		w.write(c.children, indent+step, step)
+		w.close(c, indent)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yu/terraform-ops/internal/core"
+)
+
+func clusterTestNodes() []core.GraphNode {
+	return []core.GraphNode{
+		{ID: "module_b_null_resource_x", Address: "module.b.null_resource.x", Type: "null_resource", Module: "module.b", Provider: "registry.terraform.io/hashicorp/null", Actions: []string{"delete"}},
+		{ID: "module_a_module_c_aws_instance_y", Address: "module.a.module.c.aws_instance.y", Type: "aws_instance", Module: "module.a.module.c", Provider: "registry.terraform.io/hashicorp/aws", Actions: []string{"create"}},
+		{ID: "aws_instance_web", Address: "aws_instance.web", Type: "aws_instance", Provider: "registry.terraform.io/hashicorp/aws", Actions: []string{"update"}},
+		{ID: "output_ip", Address: "output.ip", Type: "output", Actions: []string{"create"}},
+		{ID: "aws_instance_old", Address: "aws_instance.old", Type: "aws_instance", Provider: "registry.terraform.io/hashicorp/aws", Actions: []string{"delete", "create"}},
+	}
+}
+
+func clusterLabels(clusters []*cluster) []string {
+	labels := make([]string, 0, len(clusters))
+	for _, c := range clusters {
+		labels = append(labels, c.label)
+	}
+	return labels
+}
+
+func TestBuildClusters_ModuleNesting(t *testing.T) {
+	clusters := buildClusters(clusterTestNodes(), "")
+
+	require.Equal(t, []string{"root", "module.a", "module.b"}, clusterLabels(clusters))
+	assert.Len(t, clusters[0].nodes, 3)
+
+	moduleA := clusters[1]
+	assert.Empty(t, moduleA.nodes)
+	require.Len(t, moduleA.children, 1)
+	assert.Equal(t, "module.a.module.c", moduleA.children[0].label)
+	assert.Equal(t, "module_a_module_c", moduleA.children[0].id)
+	assert.Equal(t, "module.a.module.c.aws_instance.y", moduleA.children[0].nodes[0].Address)
+}
+
+func TestBuildClusters_Action(t *testing.T) {
+	clusters := buildClusters(clusterTestNodes(), core.GroupByAction)
+
+	assert.Equal(t, []string{"create", "update", "replace", "delete"}, clusterLabels(clusters))
+	assert.Equal(t, "action_create", clusters[0].id)
+	assert.Len(t, clusters[0].nodes, 2)
+}
+
+func TestBuildClusters_ResourceTypeAndProvider(t *testing.T) {
+	byType := buildClusters(clusterTestNodes(), core.GroupByResourceType)
+	assert.Equal(t, []string{"aws_instance", "null_resource", "output"}, clusterLabels(byType))
+	assert.Equal(t, "type_aws_instance", byType[0].id)
+
+	byProvider := buildClusters(clusterTestNodes(), core.GroupByProvider)
+	assert.Equal(t, []string{
+		"(no provider)",
+		"registry.terraform.io/hashicorp/aws",
+		"registry.terraform.io/hashicorp/null",
+	}, clusterLabels(byProvider))
+	assert.Equal(t, "provider_registry_terraform_io_hashicorp_aws", byProvider[1].id)
+}
+
+func TestModulePath(t *testing.T) {
+	assert.Nil(t, modulePath(""))
+	assert.Equal(t, []string{"module.a"}, modulePath("module.a"))
+	assert.Equal(t, []string{
+		`module.a["x.module.y"]`,
+		`module.a["x.module.y"].module.b[0]`,
+	}, modulePath(`module.a["x.module.y"].module.b[0]`))
+}
+
+func TestBuildClusters_UniqueIDs(t *testing.T) {
+	clusters := buildClusters([]core.GraphNode{
+		{ID: "a", Module: "module.a-b"},
+		{ID: "b", Module: "module.a_b"},
+	}, core.GroupByModule)
+
+	require.Len(t, clusters, 2)
+	assert.NotEqual(t, clusters[0].id, clusters[1].id)
+}
+
+func TestGenerators_NestedModuleClusters(t *testing.T) {
+	graphData := &core.GraphData{Nodes: clusterTestNodes()}
+	opts := core.GraphOptions{GroupBy: core.GroupByModule}
+
+	dot, err := NewGraphvizGenerator().Generate(graphData, opts)
+	require.NoError(t, err)
+	outer := strings.Index(dot, "  subgraph cluster_module_a {")
+	inner := strings.Index(dot, "    subgraph cluster_module_a_module_c {")
+	require.True(t, outer >= 0 && inner > outer, dot)
+	assert.Less(t, strings.Index(dot, "cluster_root"), outer)
+	assert.Less(t, inner, strings.Index(dot, "subgraph cluster_module_b {"))
+
+	mermaid, err := NewMermaidGenerator().Generate(graphData, opts)
+	require.NoError(t, err)
+	assert.Contains(t, mermaid, "    subgraph module_a_module_c[\"module.a.module.c\"]\n")
+
+	plantuml, err := NewPlantUMLGenerator().Generate(graphData, opts)
+	require.NoError(t, err)
+	assert.Contains(t, plantuml, "  package \"module.a.module.c\" {\n")
+}
+
+func TestGenerators_Deterministic(t *testing.T) {
+	graphData := &core.GraphData{Nodes: clusterTestNodes()}
+	for _, strategy := range []core.GroupingStrategy{core.GroupByModule, core.GroupByAction, core.GroupByResourceType, core.GroupByProvider} {
+		opts := core.GraphOptions{GroupBy: strategy}
+		first, err := NewGraphvizGenerator().Generate(graphData, opts)
+		require.NoError(t, err)
+		for i := 0; i < 10; i++ {
+			again, err := NewGraphvizGenerator().Generate(graphData, opts)
+			require.NoError(t, err)
+			assert.Equal(t, first, again, "grouping %s", strategy)
+		}
+	}
+}
+
+func TestGenerators_CollapsedModuleNodes(t *testing.T) {
+	graphData := &core.GraphData{Nodes: []core.GraphNode{{
+		ID: "module_app", Address: `module.app["blue"]`, Type: string(core.NodeTypeModule), Name: "app",
+		Actions: []string{"delete", "create"},
+		Summary: &core.ModuleSummary{Nodes: 4, Create: 2, Update: 1, Replace: 1},
+	}}}
+	opts := core.GraphOptions{GroupBy: core.GroupByModule}
+
+	dot, err := NewGraphvizGenerator().Generate(graphData, opts)
+	require.NoError(t, err)
+	assert.Contains(t, dot, `module_app [label="module.app[\"blue\"]\n[2 create, 1 update, 0 delete, 1 replace]", fillcolor=orange, shape=folder];`)
+
+	mermaid, err := NewMermaidGenerator().Generate(graphData, opts)
+	require.NoError(t, err)
+	assert.Contains(t, mermaid, `module_app[["module.app[#quot;blue#quot;] [2 create, 1 update, 0 delete, 1 replace]"]]`)
+	assert.Contains(t, mermaid, "class module_app replace\n")
+
+	plantuml, err := NewPlantUMLGenerator().Generate(graphData, opts)
+	require.NoError(t, err)
+	assert.Contains(t, plantuml, `folder "module.app['blue']\n[2 create, 1 update, 0 delete, 1 replace]" as module_app #REPLACE_COLOR`)
+}
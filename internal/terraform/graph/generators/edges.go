@@ -0,0 +1,75 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"strings"
+
+	"github.com/yu/terraform-ops/internal/core"
+	"github.com/yu/terraform-ops/internal/ir"
+)
+
+// edgeStyle is how strongly an edge's dependency evidence is drawn.
+type edgeStyle string
+
+const (
+	// edgeBold marks an explicit depends_on.
+	edgeBold edgeStyle = "bold"
+	// edgeSolid marks expression, variable, module and output references.
+	edgeSolid edgeStyle = "solid"
+	// edgeDashed marks edges backed only by heuristic evidence, such as
+	// conservative propagation through a module boundary.
+	edgeDashed edgeStyle = "dashed"
+)
+
+// legendEntries describes each edge style in the order the legends list them.
+var legendEntries = []struct {
+	style       edgeStyle
+	description string
+}{
+	{edgeBold, "explicit depends_on"},
+	{edgeSolid, "reference"},
+	{edgeDashed, "conservative (heuristic)"},
+}
+
+// styleOf picks the style of the strongest evidence behind an edge. Edges
+// built without evidence, such as hand-written fixtures, are solid.
+func styleOf(edge core.GraphEdge) edgeStyle {
+	conservative := len(edge.Kinds) > 0
+	for _, kind := range edge.Kinds {
+		if kind == ir.EdgeExplicitDependsOn {
+			return edgeBold
+		}
+		if kind != ir.EdgeConservative {
+			conservative = false
+		}
+	}
+	if conservative || edge.Confidence == ir.ConfidenceHeuristic {
+		return edgeDashed
+	}
+	return edgeSolid
+}
+
+// edgeLabel lists the evidence kinds of an edge when --edge-labels is set.
+func edgeLabel(edge core.GraphEdge, opts core.GraphOptions) string {
+	if !opts.EdgeLabels || len(edge.Kinds) == 0 {
+		return ""
+	}
+	kinds := make([]string, 0, len(edge.Kinds))
+	for _, kind := range edge.Kinds {
+		kinds = append(kinds, string(kind))
+	}
+	return strings.Join(kinds, ", ")
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yu/terraform-ops/internal/core"
+	"github.com/yu/terraform-ops/internal/ir"
+)
+
+func edgeTestGraph() *core.GraphData {
+	return &core.GraphData{
+		Nodes: []core.GraphNode{
+			{ID: "a", Address: "aws_vpc.a", Type: "aws_vpc", Actions: []string{"create"}},
+			{ID: "b", Address: "aws_subnet.b", Type: "aws_subnet", Actions: []string{"create"}},
+			{ID: "c", Address: "module.m.aws_instance.c", Type: "aws_instance", Module: "module.m", Actions: []string{"create"}},
+		},
+		Edges: []core.GraphEdge{
+			{From: "a", To: "b", Kinds: []ir.EdgeKind{ir.EdgeExplicitDependsOn, ir.EdgeExpressionRef}, Confidence: ir.ConfidenceExact},
+			{From: "b", To: "c", Kinds: []ir.EdgeKind{ir.EdgeConservative}, Confidence: ir.ConfidenceHeuristic},
+			{From: "a", To: "c", Kinds: []ir.EdgeKind{ir.EdgeModuleInput}, Confidence: ir.ConfidenceExact},
+		},
+	}
+}
+
+func TestStyleOf(t *testing.T) {
+	edges := edgeTestGraph().Edges
+	assert.Equal(t, edgeBold, styleOf(edges[0]))
+	assert.Equal(t, edgeDashed, styleOf(edges[1]))
+	assert.Equal(t, edgeSolid, styleOf(edges[2]))
+	assert.Equal(t, edgeSolid, styleOf(core.GraphEdge{From: "x", To: "y"}))
+	assert.Equal(t, edgeSolid, styleOf(core.GraphEdge{Kinds: []ir.EdgeKind{ir.EdgeConservative, ir.EdgeExpressionRef}, Confidence: ir.ConfidenceStrong}))
+}
+
+func TestGraphvizGenerate_EdgeEvidence(t *testing.T) {
+	output, err := NewGraphvizGenerator().Generate(edgeTestGraph(), core.GraphOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, output, "  a -> b [style=bold];\n")
+	assert.Contains(t, output, "  b -> c [style=dashed];\n")
+	assert.Contains(t, output, "  a -> c;\n")
+	assert.Contains(t, output, "subgraph cluster_legend {")
+	assert.Contains(t, output, `_legend_dashed_from -> _legend_dashed_to [label="conservative (heuristic)", style=dashed];`)
+
+	labeled, err := NewGraphvizGenerator().Generate(edgeTestGraph(), core.GraphOptions{EdgeLabels: true})
+	require.NoError(t, err)
+	assert.Contains(t, labeled, `  a -> b [style=bold, label="explicit_depends_on, expression_reference"];`)
+	assert.Contains(t, labeled, `  a -> c [label="module_input"];`)
+}
+
+func TestMermaidGenerate_EdgeEvidence(t *testing.T) {
+	output, err := NewMermaidGenerator().Generate(edgeTestGraph(), core.GraphOptions{EdgeLabels: true})
+	require.NoError(t, err)
+	assert.Contains(t, output, "  a ==>|explicit_depends_on, expression_reference| b\n")
+	assert.Contains(t, output, "  b -.->|conservative_module_propagation| c\n")
+	assert.Contains(t, output, "  a -->|module_input| c\n")
+	assert.Contains(t, output, "subgraph _legend[\"Legend\"]")
+}
+
+func TestPlantUMLGenerate_EdgeEvidence(t *testing.T) {
+	output, err := NewPlantUMLGenerator().Generate(edgeTestGraph(), core.GraphOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, output, "a -[bold]-> b\n")
+	assert.Contains(t, output, "b -[dashed]-> c\n")
+	assert.Contains(t, output, "a --> c\n")
+	assert.Contains(t, output, "legend right\n")
+	assert.NotContains(t, output, " : ")
+}
+
+func TestGenerators_NoLegendWithoutEdges(t *testing.T) {
+	graphData := &core.GraphData{Nodes: edgeTestGraph().Nodes}
+	for _, generator := range []core.GraphGenerator{NewGraphvizGenerator(), NewMermaidGenerator(), NewPlantUMLGenerator()} {
+		output, err := generator.Generate(graphData, core.GraphOptions{})
+		require.NoError(t, err)
+		assert.NotContains(t, output, "egend")
+	}
+}
+
+func TestGenerators_MarkFocusNodes(t *testing.T) {
+	graphData := edgeTestGraph()
+	graphData.Nodes[1].Focus = true
+
+	dot, err := NewGraphvizGenerator().Generate(graphData, core.GraphOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, dot, `b [label="aws_subnet.b\n[create]", fillcolor=lightgreen, shape=house, penwidth=3, color=blue];`)
+	assert.NotContains(t, dot, `a [label="aws_vpc.a\n[create]", fillcolor=lightgreen, shape=house, penwidth=3`)
+
+	mermaid, err := NewMermaidGenerator().Generate(graphData, core.GraphOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, mermaid, "style b stroke:#1f6feb,stroke-width:4px\n")
+	assert.NotContains(t, mermaid, "style a ")
+
+	plantuml, err := NewPlantUMLGenerator().Generate(graphData, core.GraphOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, plantuml, "as b #CREATE_COLOR;line:blue;line.bold\n")
+}
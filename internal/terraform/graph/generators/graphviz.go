@@ -38,49 +38,81 @@ func (g *GraphvizGenerator) Generate(graphData *core.GraphData, opts core.GraphO
 	builder.WriteString("  node [shape=box, style=filled, fontname=\"Arial\"];\n")
 	builder.WriteString("  edge [fontname=\"Arial\"];\n\n")
 
-	// Group nodes by module
-	moduleGroups := groupNodesByModule(graphData.Nodes)
-
-	for moduleName, nodes := range moduleGroups {
-		if moduleName == "" {
-			moduleName = "Root Module"
-		}
-
-		builder.WriteString(fmt.Sprintf("  subgraph cluster_%s {\n", sanitizeID(moduleName)))
-		builder.WriteString(fmt.Sprintf("    label=\"%s\";\n", moduleName))
-		builder.WriteString("    style=filled;\n")
-		builder.WriteString("    color=lightgrey;\n\n")
-
-		for _, node := range nodes {
+	clusterWriter{
+		open: func(c *cluster, indent string) {
+			builder.WriteString(fmt.Sprintf("%ssubgraph cluster_%s {\n", indent, c.id))
+			builder.WriteString(fmt.Sprintf("%s  label=\"%s\";\n", indent, escapeDOTLabel(c.label)))
+			builder.WriteString(fmt.Sprintf("%s  style=filled;\n", indent))
+			builder.WriteString(fmt.Sprintf("%s  color=lightgrey;\n\n", indent))
+		},
+		node: func(node core.GraphNode, indent string) {
 			actionType := getActionType(node.Actions)
 
 			// Use action color for resources, node type color for others
 			var color string
-			if isResourceType(node.Type) {
+			if colorsByAction(node) {
 				color = getActionColor(actionType)
 			} else {
 				color = getNodeTypeColor(node.Type)
 			}
 
 			shape := getNodeShape(node.Type, node.Type)
-			label := fmt.Sprintf("%s\\n[%s]", node.Address, actionType)
+			label := fmt.Sprintf("%s\\n[%s]", escapeDOTLabel(node.Address), nodeActionLabel(node))
 
-			builder.WriteString(fmt.Sprintf("    %s [label=\"%s\", fillcolor=%s, shape=%s];\n",
-				node.ID, label, color, shape))
-		}
+			// Focus nodes get a heavy blue border
+			focus := ""
+			if node.Focus {
+				focus = ", penwidth=3, color=blue"
+			}
 
-		builder.WriteString("  }\n\n")
-	}
+			builder.WriteString(fmt.Sprintf("%s%s [label=\"%s\", fillcolor=%s, shape=%s%s];\n",
+				indent, node.ID, label, color, shape, focus))
+		},
+		close: func(c *cluster, indent string) {
+			builder.WriteString(indent + "}\n\n")
+		},
+	}.write(buildClusters(graphData.Nodes, opts.GroupBy), "  ", "  ")
 
 	// Add edges
 	for _, edge := range graphData.Edges {
-		builder.WriteString(fmt.Sprintf("  %s -> %s;\n", edge.From, edge.To))
+		var attrs []string
+		if style := styleOf(edge); style != edgeSolid {
+			attrs = append(attrs, "style="+string(style))
+		}
+		if label := edgeLabel(edge, opts); label != "" {
+			attrs = append(attrs, fmt.Sprintf("label=\"%s\"", label))
+		}
+		if len(attrs) > 0 {
+			builder.WriteString(fmt.Sprintf("  %s -> %s [%s];\n", edge.From, edge.To, strings.Join(attrs, ", ")))
+		} else {
+			builder.WriteString(fmt.Sprintf("  %s -> %s;\n", edge.From, edge.To))
+		}
+	}
+
+	// Add a legend of the edge styles
+	if len(graphData.Edges) > 0 {
+		builder.WriteString("\n  subgraph cluster_legend {\n")
+		builder.WriteString("    label=\"Legend\";\n")
+		builder.WriteString("    style=dashed;\n")
+		builder.WriteString("    color=grey;\n")
+		builder.WriteString("    node [shape=point, width=0.05];\n")
+		for _, entry := range legendEntries {
+			builder.WriteString(fmt.Sprintf("    _legend_%s_from -> _legend_%s_to [label=\"%s\", style=%s];\n",
+				entry.style, entry.style, entry.description, entry.style))
+		}
+		builder.WriteString("  }\n")
 	}
 
 	builder.WriteString("}\n")
 	return builder.String(), nil
 }
 
+// escapeDOTLabel escapes the quotes in module instance keys such as
+// module.app["blue"].
+func escapeDOTLabel(label string) string {
+	return strings.ReplaceAll(label, `"`, `\"`)
+}
+
 // Helper functions
 func getActionColor(actionType core.ActionType) string {
 	switch actionType {
@@ -59,27 +59,20 @@ func (g *MermaidGenerator) Generate(graphData *core.GraphData, opts core.GraphOp
 	// Collect used CSS classes
 	usedClasses := make(map[string]bool)
 
-	// Group nodes by module
-	moduleGroups := groupNodesByModule(graphData.Nodes)
-
-	for moduleName, nodes := range moduleGroups {
-		if moduleName == "" {
-			moduleName = "root"
-		}
-
-		// Define subgraph
-		builder.WriteString(fmt.Sprintf("  subgraph %s[\"%s\"]\n",
-			sanitizeID(moduleName), moduleName))
-
-		for _, node := range nodes {
+	clusterWriter{
+		open: func(c *cluster, indent string) {
+			builder.WriteString(fmt.Sprintf("%ssubgraph %s[\"%s\"]\n",
+				indent, c.id, strings.ReplaceAll(c.label, `"`, "#quot;")))
+		},
+		node: func(node core.GraphNode, indent string) {
 			actionType := getActionType(node.Actions)
 
 			// Use simple single-line labels to avoid parsing issues
-			label := fmt.Sprintf("%s [%s]", node.Address, actionType)
+			label := fmt.Sprintf("%s [%s]", strings.ReplaceAll(node.Address, `"`, "#quot;"), nodeActionLabel(node))
 
 			// Get color based on action type for resources, or node type for others
 			var color string
-			if isResourceType(node.Type) {
+			if colorsByAction(node) {
 				color = getMermaidActionColor(actionType)
 			} else {
 				color = getMermaidNodeTypeColor(node.Type)
@@ -93,15 +86,30 @@ func (g *MermaidGenerator) Generate(graphData *core.GraphData, opts core.GraphOp
 			mermaidShape := getMermaidShape(shape)
 
 			// Define nodes with proper Mermaid syntax using resource type-specific shapes
-			builder.WriteString(fmt.Sprintf("    %s"+mermaidShape+"\n", node.ID, label))
-		}
-
-		builder.WriteString("  end\n\n")
-	}
+			builder.WriteString(fmt.Sprintf("%s%s"+mermaidShape+"\n", indent, node.ID, label))
+		},
+		close: func(c *cluster, indent string) {
+			builder.WriteString(indent + "end\n\n")
+		},
+	}.write(buildClusters(graphData.Nodes, opts.GroupBy), "  ", "  ")
 
 	// Add edges
 	for _, edge := range graphData.Edges {
-		builder.WriteString(fmt.Sprintf("  %s --> %s\n", edge.From, edge.To))
+		arrow := getMermaidArrow(styleOf(edge))
+		if label := edgeLabel(edge, opts); label != "" {
+			arrow += "|" + label + "|"
+		}
+		builder.WriteString(fmt.Sprintf("  %s %s %s\n", edge.From, arrow, edge.To))
+	}
+
+	// Add a legend of the edge styles
+	if len(graphData.Edges) > 0 {
+		builder.WriteString("\n  subgraph _legend[\"Legend\"]\n")
+		for _, entry := range legendEntries {
+			builder.WriteString(fmt.Sprintf("    _legend_%s_from[\" \"] %s|\"%s\"| _legend_%s_to[\" \"]\n",
+				entry.style, getMermaidArrow(entry.style), entry.description, entry.style))
+		}
+		builder.WriteString("  end\n")
 	}
 
 	// Add CSS class definitions
@@ -139,19 +147,37 @@ func (g *MermaidGenerator) Generate(graphData *core.GraphData, opts core.GraphOp
 		for _, node := range graphData.Nodes {
 			actionType := getActionType(node.Actions)
 			var color string
-			if isResourceType(node.Type) {
+			if colorsByAction(node) {
 				color = getMermaidActionColor(actionType)
 			} else {
 				color = getMermaidNodeTypeColor(node.Type)
 			}
 			builder.WriteString(fmt.Sprintf("class %s %s\n", node.ID, color))
 		}
+
+		// Focus nodes get a heavy blue border
+		for _, node := range graphData.Nodes {
+			if node.Focus {
+				builder.WriteString(fmt.Sprintf("style %s stroke:#1f6feb,stroke-width:4px\n", node.ID))
+			}
+		}
 	}
 
 	return builder.String(), nil
 }
 
 // Helper functions
+func getMermaidArrow(style edgeStyle) string {
+	switch style {
+	case edgeBold:
+		return "==>" // Thick link
+	case edgeDashed:
+		return "-.->" // Dotted link
+	default:
+		return "-->"
+	}
+}
+
 func getMermaidActionColor(actionType core.ActionType) string {
 	switch actionType {
 	case core.ActionCreate:
@@ -206,6 +232,8 @@ func getMermaidShape(shape string) string {
 		return "{{\"%s\"}}" // Hexagon
 	case "octagon":
 		return "{{{\"%s\"}}}" // Octagon (using triple braces as approximation)
+	case "folder":
+		return "[[\"%s\"]]" // Subroutine
 	default:
 		return "[\"%s\"]" // Default rectangle
 	}
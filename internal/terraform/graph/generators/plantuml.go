@@ -50,62 +50,80 @@ func (g *PlantUMLGenerator) Generate(graphData *core.GraphData, opts core.GraphO
 	builder.WriteString("!define VARIABLE_COLOR #fff3cd\n")
 	builder.WriteString("!define LOCAL_COLOR #f8d7da\n\n")
 
-	// Group nodes by module
-	moduleGroups := groupNodesByModule(graphData.Nodes)
-
-	for moduleName, nodes := range moduleGroups {
-		if moduleName == "" {
-			moduleName = "Root Module"
-		}
-
-		builder.WriteString(fmt.Sprintf("package \"%s\" {\n", moduleName))
-
-		for _, node := range nodes {
+	clusterWriter{
+		open: func(c *cluster, indent string) {
+			builder.WriteString(fmt.Sprintf("%spackage \"%s\" {\n", indent, strings.ReplaceAll(c.label, `"`, "'")))
+		},
+		node: func(node core.GraphNode, indent string) {
 			actionType := getActionType(node.Actions)
-			label := fmt.Sprintf("%s\\n[%s]", node.Address, actionType)
+			label := fmt.Sprintf("%s\\n[%s]", node.Address, nodeActionLabel(node))
 
 			// Get color based on action type for resources, or node type for others
 			var color string
-			if isResourceType(node.Type) {
+			if colorsByAction(node) {
 				color = getPlantUMLActionColor(actionType)
 			} else {
 				color = getPlantUMLNodeTypeColor(node.Type)
 			}
 
+			// Focus nodes get a heavy blue border
+			if node.Focus {
+				color += ";line:blue;line.bold"
+			}
+
 			// Get shape for the node
 			shape := getNodeShape(node.Type, node.Type)
 
 			// Use different notation for each node type in PlantUML based on shape
 			switch shape {
 			case "box":
-				builder.WriteString(fmt.Sprintf("  [%s] as %s #%s\n", label, node.ID, color)) // Rectangle
+				builder.WriteString(fmt.Sprintf("%s[%s] as %s #%s\n", indent, label, node.ID, color)) // Rectangle
 			case "house":
-				builder.WriteString(fmt.Sprintf("  [%s] as %s #%s\n", label, node.ID, color)) // House (using rectangle as approximation)
+				builder.WriteString(fmt.Sprintf("%s[%s] as %s #%s\n", indent, label, node.ID, color)) // House (using rectangle as approximation)
 			case "diamond":
-				builder.WriteString(fmt.Sprintf("  <%s> as %s #%s\n", label, node.ID, color)) // Rhombus/Diamond
+				builder.WriteString(fmt.Sprintf("%s<%s> as %s #%s\n", indent, label, node.ID, color)) // Rhombus/Diamond
 			case "invhouse":
-				builder.WriteString(fmt.Sprintf("  [%s] as %s #%s\n", label, node.ID, color)) // Inverted house (using rectangle as approximation)
+				builder.WriteString(fmt.Sprintf("%s[%s] as %s #%s\n", indent, label, node.ID, color)) // Inverted house (using rectangle as approximation)
 			case "ellipse":
-				builder.WriteString(fmt.Sprintf("  (%s) as %s #%s\n", label, node.ID, color)) // Circle
+				builder.WriteString(fmt.Sprintf("%s(%s) as %s #%s\n", indent, label, node.ID, color)) // Circle
 			case "cylinder":
-				builder.WriteString(fmt.Sprintf("  [%s] as %s #%s\n", label, node.ID, color)) // Cylinder (using rectangle as approximation)
+				builder.WriteString(fmt.Sprintf("%s[%s] as %s #%s\n", indent, label, node.ID, color)) // Cylinder (using rectangle as approximation)
 			case "parallelogram":
-				builder.WriteString(fmt.Sprintf("  \"%s\" as %s #%s\n", label, node.ID, color)) // Parallelogram
+				builder.WriteString(fmt.Sprintf("%s\"%s\" as %s #%s\n", indent, label, node.ID, color)) // Parallelogram
 			case "hexagon":
-				builder.WriteString(fmt.Sprintf("  {%s} as %s #%s\n", label, node.ID, color)) // Hexagon
+				builder.WriteString(fmt.Sprintf("%s{%s} as %s #%s\n", indent, label, node.ID, color)) // Hexagon
 			case "octagon":
-				builder.WriteString(fmt.Sprintf("  [%s] as %s #%s\n", label, node.ID, color)) // Octagon (using rectangle as approximation)
+				builder.WriteString(fmt.Sprintf("%s[%s] as %s #%s\n", indent, label, node.ID, color)) // Octagon (using rectangle as approximation)
+			case "folder":
+				builder.WriteString(fmt.Sprintf("%sfolder \"%s\" as %s #%s\n", indent, strings.ReplaceAll(label, `"`, "'"), node.ID, color)) // Folder
 			default:
-				builder.WriteString(fmt.Sprintf("  [%s] as %s #%s\n", label, node.ID, color)) // Default rectangle
+				builder.WriteString(fmt.Sprintf("%s[%s] as %s #%s\n", indent, label, node.ID, color)) // Default rectangle
 			}
-		}
-
-		builder.WriteString("}\n\n")
-	}
+		},
+		close: func(c *cluster, indent string) {
+			builder.WriteString(indent + "}\n\n")
+		},
+	}.write(buildClusters(graphData.Nodes, opts.GroupBy), "", "  ")
 
 	// Add edges
 	for _, edge := range graphData.Edges {
-		builder.WriteString(fmt.Sprintf("%s --> %s\n", edge.From, edge.To))
+		arrow := getPlantUMLArrow(styleOf(edge))
+		if label := edgeLabel(edge, opts); label != "" {
+			builder.WriteString(fmt.Sprintf("%s %s %s : %s\n", edge.From, arrow, edge.To, label))
+		} else {
+			builder.WriteString(fmt.Sprintf("%s %s %s\n", edge.From, arrow, edge.To))
+		}
+	}
+
+	// Add a legend of the edge styles
+	if len(graphData.Edges) > 0 {
+		builder.WriteString("\nlegend right\n")
+		builder.WriteString("  Edges\n")
+		builder.WriteString("  ==\n")
+		for _, entry := range legendEntries {
+			builder.WriteString(fmt.Sprintf("  %s: %s\n", entry.style, entry.description))
+		}
+		builder.WriteString("endlegend\n")
 	}
 
 	builder.WriteString("@enduml\n")
@@ -113,6 +131,17 @@ func (g *PlantUMLGenerator) Generate(graphData *core.GraphData, opts core.GraphO
 }
 
 // Helper functions
+func getPlantUMLArrow(style edgeStyle) string {
+	switch style {
+	case edgeBold:
+		return "-[bold]->"
+	case edgeDashed:
+		return "-[dashed]->"
+	default:
+		return "-->"
+	}
+}
+
 func getPlantUMLActionColor(actionType core.ActionType) string {
 	switch actionType {
 	case core.ActionCreate:
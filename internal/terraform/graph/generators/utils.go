@@ -50,19 +50,6 @@ func getActionType(actions []string) core.ActionType {
 	}
 }
 
-// groupNodesByModule groups nodes by their module
-func groupNodesByModule(nodes []core.GraphNode) map[string][]core.GraphNode {
-	groups := make(map[string][]core.GraphNode)
-	for _, node := range nodes {
-		module := node.Module
-		if module == "" {
-			module = "root"
-		}
-		groups[module] = append(groups[module], node)
-	}
-	return groups
-}
-
 // getNodeShape determines the shape for a node based on its type
 func getNodeShape(nodeType string, resourceType string) string {
 	switch nodeType {
@@ -76,6 +63,8 @@ func getNodeShape(nodeType string, resourceType string) string {
 		return "cylinder" // Cylinder for input variables
 	case string(core.NodeTypeLocal):
 		return "octagon" // Octagon for computed locals
+	case string(core.NodeTypeModule):
+		return "folder" // Folder for collapsed modules
 	default:
 		// Check if this looks like a resource type (has underscore, like "aws_instance")
 		if strings.Contains(nodeType, "_") {
@@ -85,24 +74,20 @@ func getNodeShape(nodeType string, resourceType string) string {
 	}
 }
 
-// sanitizeID sanitizes an ID for use in graph formats
-func sanitizeID(id string) string {
-	// Replace special characters that might cause issues in graph formats
-	replacements := map[string]string{
-		".": "_",
-		"-": "_",
-		"[": "_",
-		"]": "_",
-		"(": "_",
-		")": "_",
-		" ": "_",
-	}
+// colorsByAction reports whether a node is colored by its planned action
+// rather than by its node type. Collapsed modules take their most destructive
+// action.
+func colorsByAction(node core.GraphNode) bool {
+	return node.Summary != nil || isResourceType(node.Type)
+}
 
-	result := id
-	for old, new := range replacements {
-		result = strings.ReplaceAll(result, old, new)
+// nodeActionLabel returns what a node label shows after the address: the
+// action type, or the action counts of a collapsed module.
+func nodeActionLabel(node core.GraphNode) string {
+	if node.Summary != nil {
+		return node.Summary.String()
 	}
-	return result
+	return string(getActionType(node.Actions))
 }
 
 // isResourceType checks if a string represents a Terraform resource type
@@ -0,0 +1,259 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formatters
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yu/terraform-ops/internal/core"
+)
+
+// rootModuleName is the group of resources declared in the root module. It
+// matches the key the summarizer uses in Statistics.ModuleBreakdown.
+const rootModuleName = "root"
+
+// actionCounts counts the resources of a group by planned action.
+type actionCounts struct {
+	Create  int `json:"create"`
+	Update  int `json:"update"`
+	Replace int `json:"replace"`
+	Delete  int `json:"delete"`
+	NoOp    int `json:"no_op"`
+}
+
+// Total returns the number of counted resources.
+func (c actionCounts) Total() int {
+	return c.Create + c.Update + c.Replace + c.Delete + c.NoOp
+}
+
+// String lists the non-zero counts in plan order, for example
+// "2 create, 1 replace".
+func (c actionCounts) String() string {
+	var parts []string
+	for _, entry := range []struct {
+		action string
+		count  int
+	}{
+		{"create", c.Create},
+		{"update", c.Update},
+		{"replace", c.Replace},
+		{"delete", c.Delete},
+		{"no-op", c.NoOp},
+	} {
+		if entry.count > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", entry.count, entry.action))
+		}
+	}
+	if len(parts) == 0 {
+		return "no changes"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (c *actionCounts) add(action core.ActionType) {
+	switch action {
+	case core.ActionCreate:
+		c.Create++
+	case core.ActionUpdate:
+		c.Update++
+	case core.ActionReplace:
+		c.Replace++
+	case core.ActionDelete:
+		c.Delete++
+	default:
+		c.NoOp++
+	}
+}
+
+// plannedResource is a resource together with the action bucket the
+// summarizer placed it in, which is authoritative over its raw action list.
+type plannedResource struct {
+	core.ResourceSummary
+	action core.ActionType
+}
+
+// resourceGroup is one group of resources under a grouping strategy.
+type resourceGroup struct {
+	name      string
+	counts    actionCounts
+	resources []plannedResource
+}
+
+// plannedResources flattens the action buckets in plan order: create, update,
+// replace, delete, no-op.
+func plannedResources(changes core.Changes) []plannedResource {
+	var resources []plannedResource
+	for _, bucket := range []struct {
+		action    core.ActionType
+		resources []core.ResourceSummary
+	}{
+		{core.ActionCreate, changes.Create},
+		{core.ActionUpdate, changes.Update},
+		{core.ActionReplace, changes.Replace},
+		{core.ActionDelete, changes.Delete},
+		{core.ActionNoOp, changes.NoOp},
+	} {
+		for _, resource := range bucket.resources {
+			resources = append(resources, plannedResource{ResourceSummary: resource, action: bucket.action})
+		}
+	}
+	return resources
+}
+
+// isActionGrouping reports whether resources are grouped by action, the
+// default for summarize-plan.
+func isActionGrouping(groupBy core.SummaryGrouping) bool {
+	return groupBy == "" || groupBy == core.GroupByAction
+}
+
+// groupResources groups the planned resources by the grouping strategy.
+// Action groups follow plan order; module groups start with the root module;
+// other groups are sorted by name. Resources within a group are sorted by
+// address.
+func groupResources(changes core.Changes, groupBy core.SummaryGrouping) []resourceGroup {
+	var groups []resourceGroup
+	index := make(map[string]int)
+	for _, resource := range plannedResources(changes) {
+		name := groupName(resource, groupBy)
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, resourceGroup{name: name})
+		}
+		groups[i].counts.add(resource.action)
+		groups[i].resources = append(groups[i].resources, resource)
+	}
+
+	if !isActionGrouping(groupBy) {
+		sort.SliceStable(groups, func(i, j int) bool {
+			if groupBy == core.GroupByModule && (groups[i].name == rootModuleName) != (groups[j].name == rootModuleName) {
+				return groups[i].name == rootModuleName
+			}
+			return groups[i].name < groups[j].name
+		})
+	}
+	for _, group := range groups {
+		sort.SliceStable(group.resources, func(i, j int) bool {
+			return group.resources[i].Address < group.resources[j].Address
+		})
+	}
+	return groups
+}
+
+func groupName(resource plannedResource, groupBy core.SummaryGrouping) string {
+	switch groupBy {
+	case core.GroupByModule:
+		if resource.ModuleAddress == "" {
+			return rootModuleName
+		}
+		return resource.ModuleAddress
+	case core.GroupByProvider:
+		return resource.Provider
+	case core.GroupByResourceType:
+		return resource.Type
+	default:
+		return string(resource.action)
+	}
+}
+
+// groupTitle is the display name of a group; the root module reads as
+// "Root Module" like the module breakdown.
+func groupTitle(name string, groupBy core.SummaryGrouping) string {
+	if groupBy == core.GroupByModule && name == rootModuleName {
+		return "Root Module"
+	}
+	return name
+}
+
+// groupHeading is the column or heading label for the grouping strategy.
+func groupHeading(groupBy core.SummaryGrouping) string {
+	switch groupBy {
+	case core.GroupByModule:
+		return "Module"
+	case core.GroupByProvider:
+		return "Provider"
+	case core.GroupByResourceType:
+		return "Resource Type"
+	default:
+		return "Action"
+	}
+}
+
+// groupIcon matches the icons of the statistics breakdowns.
+func groupIcon(groupBy core.SummaryGrouping) string {
+	switch groupBy {
+	case core.GroupByModule:
+		return "📦"
+	case core.GroupByProvider:
+		return "🏢"
+	default:
+		return "🧩"
+	}
+}
+
+// actionSymbol is the terraform plan symbol for an action bucket.
+func actionSymbol(action core.ActionType) string {
+	switch action {
+	case core.ActionCreate:
+		return "+"
+	case core.ActionUpdate:
+		return "~"
+	case core.ActionReplace:
+		return "-/+"
+	case core.ActionDelete:
+		return "-"
+	default:
+		return "="
+	}
+}
+
+// showSensitive reports whether a sensitive marker should be rendered.
+func showSensitive(sensitive bool, opts core.SummaryOptions) bool {
+	return sensitive && !opts.NoSensitive
+}
+
+// totalCounts counts every planned resource by action.
+func totalCounts(changes core.Changes) actionCounts {
+	var counts actionCounts
+	for _, resource := range plannedResources(changes) {
+		counts.add(resource.action)
+	}
+	return counts
+}
+
+// planStatus describes whether the plan can be applied.
+func planStatus(info core.PlanInfo) string {
+	status := "✅ Applicable"
+	if !info.Applicable {
+		status = "❌ Not Applicable"
+	}
+	if info.Errored {
+		status = "💥 Errored"
+	}
+	return status
+}
+
+// changedKeys returns the sorted attribute names of a resource's key changes.
+func changedKeys(resource core.ResourceSummary) []string {
+	keys := make([]string, 0, len(resource.KeyChanges))
+	for key := range resource.KeyChanges {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formatters
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yu/terraform-ops/internal/core"
+)
+
+func groupedTestSummary() *core.PlanSummary {
+	return &core.PlanSummary{
+		PlanInfo: core.PlanInfo{FormatVersion: "1.2", Applicable: true, Complete: true},
+		Statistics: core.Statistics{
+			TotalChanges:    4,
+			ActionBreakdown: map[string]int{"create": 2, "update": 1, "delete": 2},
+		},
+		Changes: core.Changes{
+			Create: []core.ResourceSummary{
+				{Address: "module.app.aws_instance.web", ModuleAddress: "module.app", Type: "aws_instance", Name: "web", Provider: "aws", Actions: []string{"create"}},
+				{Address: "google_storage_bucket.logs", Type: "google_storage_bucket", Name: "logs", Provider: "google", Actions: []string{"create"}},
+			},
+			Update: []core.ResourceSummary{
+				{Address: "aws_db_instance.main", Type: "aws_db_instance", Name: "main", Provider: "aws", Actions: []string{"update"}, Sensitive: true,
+					KeyChanges: map[string]interface{}{"password": map[string]interface{}{"from": "(sensitive)", "to": "(sensitive)"}}},
+			},
+			Replace: []core.ResourceSummary{
+				{Address: "module.app.aws_instance.api", ModuleAddress: "module.app", Type: "aws_instance", Name: "api", Provider: "aws", Actions: []string{"delete", "create"}},
+			},
+		},
+		Outputs: []core.OutputSummary{
+			{Name: "db_password", Actions: []string{"create"}, Sensitive: true},
+		},
+	}
+}
+
+func TestGroupResources(t *testing.T) {
+	summary := groupedTestSummary()
+
+	byModule := groupResources(summary.Changes, core.GroupByModule)
+	require.Len(t, byModule, 2)
+	assert.Equal(t, "root", byModule[0].name)
+	assert.Equal(t, "module.app", byModule[1].name)
+	assert.Equal(t, actionCounts{Create: 1, Replace: 1}, byModule[1].counts)
+	assert.Equal(t, "module.app.aws_instance.api", byModule[1].resources[0].Address)
+	assert.Equal(t, core.ActionReplace, byModule[1].resources[0].action)
+
+	byProvider := groupResources(summary.Changes, core.GroupByProvider)
+	require.Len(t, byProvider, 2)
+	assert.Equal(t, "aws", byProvider[0].name)
+	assert.Equal(t, 3, byProvider[0].counts.Total())
+	assert.Equal(t, "1 create, 1 update, 1 replace", byProvider[0].counts.String())
+
+	byType := groupResources(summary.Changes, core.GroupByResourceType)
+	assert.Equal(t, "aws_db_instance", byType[0].name)
+
+	byAction := groupResources(summary.Changes, core.GroupByAction)
+	require.Len(t, byAction, 3)
+	assert.Equal(t, []string{"create", "update", "replace"}, []string{byAction[0].name, byAction[1].name, byAction[2].name})
+}
+
+func TestFormatters_GroupByModule(t *testing.T) {
+	summary := groupedTestSummary()
+	opts := core.SummaryOptions{GroupBy: core.GroupByModule}
+
+	text, err := NewTextFormatter(false).Format(summary, opts)
+	require.NoError(t, err)
+	assert.Contains(t, text, "📦 Root Module (2: 1 create, 1 update)")
+	assert.Contains(t, text, "📦 module.app (2: 1 create, 1 replace)")
+	assert.Contains(t, text, "  -/+ module.app.aws_instance.api")
+	assert.Less(t, strings.Index(text, "Root Module (2"), strings.Index(text, "module.app (2"))
+	assert.NotContains(t, text, "➕ Create (")
+
+	markdown, err := NewMarkdownFormatter().Format(summary, opts)
+	require.NoError(t, err)
+	assert.Contains(t, markdown, "### 📦 module.app (2)\n\n_1 create, 1 replace_")
+	assert.Contains(t, markdown, "- 🔄 **module.app.aws_instance.api**")
+
+	table, err := NewTableFormatter().Format(summary, opts)
+	require.NoError(t, err)
+	assert.Contains(t, table, "### module.app (2: 1 create, 1 replace)")
+	assert.Contains(t, table, "| replace | module.app.aws_instance.api | aws_instance | aws | module.app | No |")
+
+	plan, err := NewPlanFormatter(false).Format(summary, opts)
+	require.NoError(t, err)
+	assert.Contains(t, plan, "  # Module: module.app (1 create, 1 replace)")
+	assert.Contains(t, plan, "Plan: 2 to add, 1 to change, 2 to destroy.")
+
+	out, err := NewJSONFormatter().Format(summary, opts)
+	require.NoError(t, err)
+	var parsed struct {
+		GroupBy string `json:"group_by"`
+		Groups  []struct {
+			Name      string       `json:"name"`
+			Total     int          `json:"total"`
+			Actions   actionCounts `json:"actions"`
+			Resources []string     `json:"resources"`
+		} `json:"groups"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &parsed))
+	assert.Equal(t, "module", parsed.GroupBy)
+	require.Len(t, parsed.Groups, 2)
+	assert.Equal(t, "module.app", parsed.Groups[1].Name)
+	assert.Equal(t, 2, parsed.Groups[1].Total)
+	assert.Equal(t, actionCounts{Create: 1, Replace: 1}, parsed.Groups[1].Actions)
+	assert.Equal(t, []string{"module.app.aws_instance.api", "module.app.aws_instance.web"}, parsed.Groups[1].Resources)
+}
+
+func TestFormatters_ActionGroupingKeepsJSONShape(t *testing.T) {
+	out, err := NewJSONFormatter().Format(groupedTestSummary(), core.SummaryOptions{GroupBy: core.GroupByAction})
+	require.NoError(t, err)
+	assert.NotContains(t, out, "group_by")
+	assert.NotContains(t, out, "groups")
+	assert.Contains(t, out, `"sensitive": true`)
+}
+
+func TestFormatters_NoSensitive(t *testing.T) {
+	summary := groupedTestSummary()
+	opts := core.SummaryOptions{NoSensitive: true}
+
+	text, err := NewTextFormatter(false).Format(summary, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, text, "🔒")
+	assert.Contains(t, text, "db_password")
+
+	markdown, err := NewMarkdownFormatter().Format(summary, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, markdown, "🔒")
+
+	table, err := NewTableFormatter().Format(summary, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, table, "Sensitive")
+	assert.Contains(t, table, "| aws_db_instance.main | aws_db_instance | aws | root |\n")
+	assert.Contains(t, table, "| db_password | create | N/A |\n")
+
+	plan, err := NewPlanFormatter(false).Format(summary, core.SummaryOptions{NoSensitive: true, Format: core.FormatText})
+	require.NoError(t, err)
+	assert.NotContains(t, plan, "# (sensitive value)")
+
+	out, err := NewJSONFormatter().Format(summary, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, out, `"sensitive"`)
+}
+
+func TestFormatters_Compact(t *testing.T) {
+	summary := groupedTestSummary()
+	opts := core.SummaryOptions{Compact: true, GroupBy: core.GroupByProvider, ShowDetails: true}
+
+	text, err := NewTextFormatter(false).Format(summary, opts)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"Terraform Plan Summary: ✅ Applicable, 4 changes (2 create, 1 update, 1 replace)",
+		"aws (1 create, 1 update, 1 replace):",
+		"  ~ aws_db_instance.main 🔒 [password]",
+		"  -/+ module.app.aws_instance.api",
+		"  + module.app.aws_instance.web",
+		"google (1 create):",
+		"  + google_storage_bucket.logs",
+		"outputs (1):",
+		"  db_password 🔒",
+		"",
+	}, "\n"), text)
+
+	markdown, err := NewMarkdownFormatter().Format(summary, opts)
+	require.NoError(t, err)
+	assert.Contains(t, markdown, "| Provider | Action | Resource |\n")
+	assert.Contains(t, markdown, "| aws | 🔄 update | `aws_db_instance.main` 🔒 (password) |\n")
+	assert.NotContains(t, markdown, "## 📊 Statistics")
+
+	table, err := NewTableFormatter().Format(summary, opts)
+	require.NoError(t, err)
+	assert.Contains(t, table, "## Resource Changes (4: 2 create, 1 update, 1 replace)")
+	assert.Contains(t, table, "| Action | Address | Provider | Sensitive |\n")
+	assert.Equal(t, 1, strings.Count(table, "| Action |"))
+	assert.NotContains(t, table, "## Statistics")
+
+	plan, err := NewPlanFormatter(false).Format(summary, core.SummaryOptions{Compact: true, Format: core.FormatPlan})
+	require.NoError(t, err)
+	assert.Contains(t, plan, "  # aws_db_instance.main will be updated in-place\n  # google_storage_bucket.logs will be created\n")
+	assert.NotContains(t, plan, "resource \"")
+	assert.NotContains(t, plan, "Resource\nactions are indicated")
+
+	out, err := NewJSONFormatter().Format(summary, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(out, "\n"))
+	assert.True(t, json.Valid([]byte(out)))
+}
@@ -29,16 +29,131 @@ func NewJSONFormatter() *JSONFormatter {
 	return &JSONFormatter{}
 }
 
-// Format formats a plan summary as JSON
+// jsonSummary mirrors core.PlanSummary field for field so the default output
+// is unchanged, and adds the groups of a non-action grouping. Sensitive flags
+// are pointers so --no-sensitive can omit them instead of reporting false.
+type jsonSummary struct {
+	PlanInfo   core.PlanInfo        `json:"plan_info"`
+	Statistics core.Statistics      `json:"statistics"`
+	Changes    jsonChanges          `json:"changes"`
+	Outputs    []jsonOutput         `json:"outputs,omitempty"`
+	GroupBy    core.SummaryGrouping `json:"group_by,omitempty"`
+	Groups     []jsonGroup          `json:"groups,omitempty"`
+}
+
+type jsonChanges struct {
+	Create  []jsonResource `json:"create,omitempty"`
+	Update  []jsonResource `json:"update,omitempty"`
+	Delete  []jsonResource `json:"delete,omitempty"`
+	Replace []jsonResource `json:"replace,omitempty"`
+	NoOp    []jsonResource `json:"no_op,omitempty"`
+}
+
+type jsonResource struct {
+	Address        string                 `json:"address"`
+	ModuleAddress  string                 `json:"module_address"`
+	Type           string                 `json:"type"`
+	Name           string                 `json:"name"`
+	Provider       string                 `json:"provider"`
+	ProviderConfig string                 `json:"provider_config,omitempty"`
+	Actions        []string               `json:"actions"`
+	Sensitive      *bool                  `json:"sensitive,omitempty"`
+	KeyChanges     map[string]interface{} `json:"key_changes,omitempty"`
+}
+
+type jsonOutput struct {
+	Name      string      `json:"name"`
+	Actions   []string    `json:"actions"`
+	Sensitive *bool       `json:"sensitive,omitempty"`
+	Value     interface{} `json:"value,omitempty"`
+}
+
+// jsonGroup lists the addresses of a group; the resources themselves stay in
+// changes.
+type jsonGroup struct {
+	Name      string       `json:"name"`
+	Total     int          `json:"total"`
+	Actions   actionCounts `json:"actions"`
+	Resources []string     `json:"resources"`
+}
+
+// Format formats a plan summary as JSON. The compact layout is a single line.
 func (f *JSONFormatter) Format(summary *core.PlanSummary, opts core.SummaryOptions) (string, error) {
 	var buf bytes.Buffer
 	encoder := json.NewEncoder(&buf)
 	encoder.SetEscapeHTML(false)
-	encoder.SetIndent("", "  ")
+	if !opts.Compact {
+		encoder.SetIndent("", "  ")
+	}
 
-	if err := encoder.Encode(summary); err != nil {
+	if err := encoder.Encode(newJSONSummary(summary, opts)); err != nil {
 		return "", err
 	}
 
 	return buf.String(), nil
 }
+
+func newJSONSummary(summary *core.PlanSummary, opts core.SummaryOptions) jsonSummary {
+	sensitive := func(value bool) *bool {
+		if opts.NoSensitive {
+			return nil
+		}
+		return &value
+	}
+	resources := func(items []core.ResourceSummary) []jsonResource {
+		if items == nil {
+			return nil
+		}
+		out := make([]jsonResource, 0, len(items))
+		for _, item := range items {
+			out = append(out, jsonResource{
+				Address:        item.Address,
+				ModuleAddress:  item.ModuleAddress,
+				Type:           item.Type,
+				Name:           item.Name,
+				Provider:       item.Provider,
+				ProviderConfig: item.ProviderConfig,
+				Actions:        item.Actions,
+				Sensitive:      sensitive(item.Sensitive),
+				KeyChanges:     item.KeyChanges,
+			})
+		}
+		return out
+	}
+
+	out := jsonSummary{
+		PlanInfo:   summary.PlanInfo,
+		Statistics: summary.Statistics,
+		Changes: jsonChanges{
+			Create:  resources(summary.Changes.Create),
+			Update:  resources(summary.Changes.Update),
+			Delete:  resources(summary.Changes.Delete),
+			Replace: resources(summary.Changes.Replace),
+			NoOp:    resources(summary.Changes.NoOp),
+		},
+	}
+	for _, output := range summary.Outputs {
+		out.Outputs = append(out.Outputs, jsonOutput{
+			Name:      output.Name,
+			Actions:   output.Actions,
+			Sensitive: sensitive(output.Sensitive),
+			Value:     output.Value,
+		})
+	}
+	if !isActionGrouping(opts.GroupBy) {
+		out.GroupBy = opts.GroupBy
+		for _, group := range groupResources(summary.Changes, opts.GroupBy) {
+			addresses := make([]string, 0, len(group.resources))
+			for _, resource := range group.resources {
+				addresses = append(addresses, resource.Address)
+			}
+			out.Groups = append(out.Groups, jsonGroup{
+				Name:      group.name,
+				Total:     group.counts.Total(),
+				Actions:   group.counts,
+				Resources: addresses,
+			})
+		}
+	}
+	return out
+}
@@ -33,6 +33,11 @@ func NewMarkdownFormatter() *MarkdownFormatter {
 func (f *MarkdownFormatter) Format(summary *core.PlanSummary, opts core.SummaryOptions) (string, error) {
 	var builder strings.Builder
 
+	if opts.Compact {
+		f.writeCompact(&builder, summary, opts)
+		return builder.String(), nil
+	}
+
 	// Header
 	f.writeHeader(&builder, summary.PlanInfo)
 
@@ -44,7 +49,7 @@ func (f *MarkdownFormatter) Format(summary *core.PlanSummary, opts core.SummaryO
 
 	// Output Changes
 	if len(summary.Outputs) > 0 {
-		f.writeOutputChanges(&builder, summary.Outputs)
+		f.writeOutputChanges(&builder, summary.Outputs, opts)
 	}
 
 	return builder.String(), nil
@@ -54,15 +59,7 @@ func (f *MarkdownFormatter) Format(summary *core.PlanSummary, opts core.SummaryO
 func (f *MarkdownFormatter) writeHeader(builder *strings.Builder, info core.PlanInfo) {
 	builder.WriteString("# Terraform Plan Summary\n\n")
 
-	status := "✅ Applicable"
-	if !info.Applicable {
-		status = "❌ Not Applicable"
-	}
-	if info.Errored {
-		status = "💥 Errored"
-	}
-
-	fmt.Fprintf(builder, "**Plan Status:** %s  \n", status)
+	fmt.Fprintf(builder, "**Plan Status:** %s  \n", planStatus(info))
 	fmt.Fprintf(builder, "**Format Version:** %s  \n", info.FormatVersion)
 	fmt.Fprintf(builder, "**Complete:** %t  \n\n", info.Complete)
 }
@@ -109,6 +106,13 @@ func (f *MarkdownFormatter) writeStatistics(builder *strings.Builder, stats core
 func (f *MarkdownFormatter) writeResourceChanges(builder *strings.Builder, changes core.Changes, opts core.SummaryOptions) {
 	builder.WriteString("## 🔄 Resource Changes\n\n")
 
+	if !isActionGrouping(opts.GroupBy) {
+		for _, group := range groupResources(changes, opts.GroupBy) {
+			f.writeGroup(builder, group, opts)
+		}
+		return
+	}
+
 	// Create
 	if len(changes.Create) > 0 {
 		f.writeActionGroup(builder, "➕ Create", changes.Create, opts)
@@ -140,19 +144,31 @@ func (f *MarkdownFormatter) writeActionGroup(builder *strings.Builder, title str
 	fmt.Fprintf(builder, "### %s (%d)\n\n", title, len(resources))
 
 	for _, resource := range resources {
-		f.writeResource(builder, resource, opts)
+		f.writeResource(builder, "", resource, opts)
+	}
+	builder.WriteString("\n")
+}
+
+// writeGroup writes a module, provider or resource type group with its action
+// counts. Each resource is prefixed with its action icon.
+func (f *MarkdownFormatter) writeGroup(builder *strings.Builder, group resourceGroup, opts core.SummaryOptions) {
+	fmt.Fprintf(builder, "### %s %s (%d)\n\n", groupIcon(opts.GroupBy), groupTitle(group.name, opts.GroupBy), group.counts.Total())
+	fmt.Fprintf(builder, "_%s_\n\n", group.counts)
+
+	for _, resource := range group.resources {
+		f.writeResource(builder, f.getActionIcon(string(resource.action))+" ", resource.ResourceSummary, opts)
 	}
 	builder.WriteString("\n")
 }
 
 // writeResource writes a single resource
-func (f *MarkdownFormatter) writeResource(builder *strings.Builder, resource core.ResourceSummary, opts core.SummaryOptions) {
+func (f *MarkdownFormatter) writeResource(builder *strings.Builder, prefix string, resource core.ResourceSummary, opts core.SummaryOptions) {
 	// Resource address
 	address := resource.Address
-	fmt.Fprintf(builder, "- **%s**\n", address)
+	fmt.Fprintf(builder, "- %s**%s**\n", prefix, address)
 
 	// Sensitive indicator
-	if resource.Sensitive {
+	if showSensitive(resource.Sensitive, opts) {
 		builder.WriteString("  - 🔒 Contains sensitive values\n")
 	}
 
@@ -170,13 +186,15 @@ func (f *MarkdownFormatter) writeResource(builder *strings.Builder, resource cor
 }
 
 // writeOutputChanges writes the output changes section
-func (f *MarkdownFormatter) writeOutputChanges(builder *strings.Builder, outputs []core.OutputSummary) {
+func (f *MarkdownFormatter) writeOutputChanges(builder *strings.Builder, outputs []core.OutputSummary, opts core.SummaryOptions) {
 	builder.WriteString("## 📤 Output Changes\n\n")
 
 	for _, output := range outputs {
 		fmt.Fprintf(builder, "- **%s**\n", output.Name)
 		if output.Sensitive {
-			builder.WriteString("  - 🔒 Sensitive value\n")
+			if !opts.NoSensitive {
+				builder.WriteString("  - 🔒 Sensitive value\n")
+			}
 		} else if output.Value != nil {
 			fmt.Fprintf(builder, "  - **Value:** `%v`\n", output.Value)
 		}
@@ -184,6 +202,60 @@ func (f *MarkdownFormatter) writeOutputChanges(builder *strings.Builder, outputs
 	builder.WriteString("\n")
 }
 
+// writeCompact writes a status line and a single resource table, which suits
+// pull request comments with many changes.
+func (f *MarkdownFormatter) writeCompact(builder *strings.Builder, summary *core.PlanSummary, opts core.SummaryOptions) {
+	builder.WriteString("### Terraform Plan Summary\n\n")
+	fmt.Fprintf(builder, "%s · **%d changes** (%s)\n\n", planStatus(summary.PlanInfo), summary.Statistics.TotalChanges, totalCounts(summary.Changes))
+
+	groups := groupResources(summary.Changes, opts.GroupBy)
+	if len(groups) > 0 {
+		grouped := !isActionGrouping(opts.GroupBy)
+		if grouped {
+			fmt.Fprintf(builder, "| %s | Action | Resource |\n", groupHeading(opts.GroupBy))
+			builder.WriteString("|---|---|---|\n")
+		} else {
+			builder.WriteString("| Action | Resource |\n")
+			builder.WriteString("|---|---|\n")
+		}
+		for _, group := range groups {
+			for _, resource := range group.resources {
+				cell := "`" + escapeMarkdownCell(resource.Address) + "`"
+				if showSensitive(resource.Sensitive, opts) {
+					cell += " 🔒"
+				}
+				if opts.ShowDetails && len(resource.KeyChanges) > 0 {
+					cell += " (" + escapeMarkdownCell(strings.Join(changedKeys(resource.ResourceSummary), ", ")) + ")"
+				}
+				action := f.getActionIcon(string(resource.action)) + " " + string(resource.action)
+				if grouped {
+					fmt.Fprintf(builder, "| %s | %s | %s |\n", escapeMarkdownCell(groupTitle(group.name, opts.GroupBy)), action, cell)
+				} else {
+					fmt.Fprintf(builder, "| %s | %s |\n", action, cell)
+				}
+			}
+		}
+		builder.WriteString("\n")
+	}
+
+	if len(summary.Outputs) > 0 {
+		names := make([]string, 0, len(summary.Outputs))
+		for _, output := range summary.Outputs {
+			name := "`" + output.Name + "`"
+			if showSensitive(output.Sensitive, opts) {
+				name += " 🔒"
+			}
+			names = append(names, name)
+		}
+		fmt.Fprintf(builder, "**Outputs:** %s\n", strings.Join(names, ", "))
+	}
+}
+
+// escapeMarkdownCell keeps pipes and newlines from breaking a table row.
+func escapeMarkdownCell(value string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(value, "|", "\\|"), "\n", " ")
+}
+
 // getActionIcon returns an icon for the given action
 func (f *MarkdownFormatter) getActionIcon(action string) string {
 	switch action {
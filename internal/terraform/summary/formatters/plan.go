@@ -38,19 +38,26 @@ func NewPlanFormatter(useColor bool) *PlanFormatter {
 func (f *PlanFormatter) Format(summary *core.PlanSummary, opts core.SummaryOptions) (string, error) {
 	var builder strings.Builder
 
-	// Header
-	f.writeHeader(&builder, summary.PlanInfo, summary.Statistics)
+	// Header; the compact layout drops the symbol legend
+	if !opts.Compact {
+		f.writeHeader(&builder, summary.PlanInfo, summary.Statistics)
+	}
 
-	// Collect all resources in the order they would appear in terraform plan
-	resources := f.collectAllResources(summary.Changes)
+	// Write resource changes in terraform plan style, sorted by address for
+	// consistent output or by group and then address when grouping
+	if isActionGrouping(opts.GroupBy) {
+		// Collect all resources in the order they would appear in terraform plan
+		resources := f.collectAllResources(summary.Changes)
 
-	// Sort resources by address for consistent output
-	sort.Slice(resources, func(i, j int) bool {
-		return resources[i].Address < resources[j].Address
-	})
+		// Sort resources by address for consistent output
+		sort.Slice(resources, func(i, j int) bool {
+			return resources[i].Address < resources[j].Address
+		})
 
-	// Write resource changes in terraform plan style
-	f.writeResourceChanges(&builder, resources, opts)
+		f.writeResourceChanges(&builder, resources, opts)
+	} else {
+		f.writeGroupedResourceChanges(&builder, summary.Changes, opts)
+	}
 
 	// Write plan summary
 	f.writePlanSummary(&builder, summary.Statistics)
@@ -61,7 +68,9 @@ func (f *PlanFormatter) Format(summary *core.PlanSummary, opts core.SummaryOptio
 	}
 
 	// Write footer
-	f.writeFooter(&builder)
+	if !opts.Compact {
+		f.writeFooter(&builder)
+	}
 
 	return builder.String(), nil
 }
@@ -100,10 +109,37 @@ func (f *PlanFormatter) writeResourceChanges(builder *strings.Builder, resources
 
 	for _, resource := range resources {
 		f.writeResourceChange(builder, resource, opts)
+	}
+	if opts.Compact {
 		builder.WriteString("\n")
 	}
 }
 
+// writeGroupedResourceChanges writes resource changes under a comment line per
+// module, provider or resource type that carries the group's action counts.
+func (f *PlanFormatter) writeGroupedResourceChanges(builder *strings.Builder, changes core.Changes, opts core.SummaryOptions) {
+	groups := groupResources(changes, opts.GroupBy)
+	if len(groups) == 0 {
+		f.writeResourceChanges(builder, nil, opts)
+		return
+	}
+
+	builder.WriteString("Terraform will perform the following actions:\n\n")
+
+	for _, group := range groups {
+		fmt.Fprintf(builder, "  # %s: %s (%s)\n", groupHeading(opts.GroupBy), groupTitle(group.name, opts.GroupBy), group.counts)
+		if !opts.Compact {
+			builder.WriteString("\n")
+		}
+		for _, resource := range group.resources {
+			f.writeResourceChange(builder, resource.ResourceSummary, opts)
+		}
+		if opts.Compact {
+			builder.WriteString("\n")
+		}
+	}
+}
+
 // writeResourceChange writes a single resource change in terraform plan style
 func (f *PlanFormatter) writeResourceChange(builder *strings.Builder, resource core.ResourceSummary, opts core.SummaryOptions) {
 	// Determine the action symbol and description
@@ -119,6 +155,11 @@ func (f *PlanFormatter) writeResourceChange(builder *strings.Builder, resource c
 	// Write the comment line with action description
 	fmt.Fprintf(builder, "  # %s %s\n", address, actionDescription)
 
+	// The compact layout stops at the comment line
+	if opts.Compact {
+		return
+	}
+
 	// Write the resource block header
 	if f.useColor && actionColor != "" {
 		fmt.Fprintf(builder, "%s resource \"%s\" \"%s\" {\n", f.colorize(actionSymbol, actionColor), resource.Type, resource.Name)
@@ -131,17 +172,17 @@ func (f *PlanFormatter) writeResourceChange(builder *strings.Builder, resource c
 	if opts.ShowDetails || opts.Format == core.FormatPlan {
 		if len(resource.KeyChanges) > 0 {
 			f.writeResourceDetails(builder, resource, opts)
-		} else if resource.Sensitive {
+		} else if showSensitive(resource.Sensitive, opts) {
 			builder.WriteString("      # (sensitive value)\n")
 		}
 	} else {
 		// Write minimal info for non-detailed view
-		if resource.Sensitive {
+		if showSensitive(resource.Sensitive, opts) {
 			builder.WriteString("      # (sensitive value)\n")
 		}
 	}
 
-	builder.WriteString("    }\n")
+	builder.WriteString("    }\n\n")
 }
 
 // getActionDescription returns a human-readable description of the action
@@ -33,15 +33,20 @@ func NewTableFormatter() *TableFormatter {
 func (f *TableFormatter) Format(summary *core.PlanSummary, opts core.SummaryOptions) (string, error) {
 	var builder strings.Builder
 
-	// Statistics table
-	f.writeStatisticsTable(&builder, summary.Statistics)
-
-	// Resource changes table
-	f.writeResourceChangesTable(&builder, summary.Changes, opts)
+	if opts.Compact {
+		// A single resource table replaces the breakdowns and per-group tables
+		f.writeCompactResourceTable(&builder, summary.Changes, opts)
+	} else {
+		// Statistics table
+		f.writeStatisticsTable(&builder, summary.Statistics)
+
+		// Resource changes table
+		f.writeResourceChangesTable(&builder, summary.Changes, opts)
+	}
 
 	// Output changes table
 	if len(summary.Outputs) > 0 {
-		f.writeOutputChangesTable(&builder, summary.Outputs)
+		f.writeOutputChangesTable(&builder, summary.Outputs, opts)
 	}
 
 	return builder.String(), nil
@@ -93,6 +98,14 @@ func (f *TableFormatter) writeStatisticsTable(builder *strings.Builder, stats co
 func (f *TableFormatter) writeResourceChangesTable(builder *strings.Builder, changes core.Changes, opts core.SummaryOptions) {
 	builder.WriteString("## Resource Changes\n\n")
 
+	if !isActionGrouping(opts.GroupBy) {
+		for _, group := range groupResources(changes, opts.GroupBy) {
+			fmt.Fprintf(builder, "### %s (%d: %s)\n\n", groupTitle(group.name, opts.GroupBy), group.counts.Total(), group.counts)
+			f.writeResourceRows(builder, group.resources, []string{"Action", "Address", "Type", "Provider", "Module"}, opts)
+		}
+		return
+	}
+
 	// Create table
 	if len(changes.Create) > 0 {
 		f.writeActionTable(builder, "Create", changes.Create, opts)
@@ -122,45 +135,108 @@ func (f *TableFormatter) writeResourceChangesTable(builder *strings.Builder, cha
 // writeActionTable writes a table for resources with the same action
 func (f *TableFormatter) writeActionTable(builder *strings.Builder, action string, resources []core.ResourceSummary, opts core.SummaryOptions) {
 	fmt.Fprintf(builder, "### %s (%d)\n\n", action, len(resources))
-	builder.WriteString("| Address | Type | Provider | Module | Sensitive |\n")
-	builder.WriteString("|---------|------|----------|--------|-----------|\n")
+	rows := make([]plannedResource, 0, len(resources))
+	for _, resource := range resources {
+		rows = append(rows, plannedResource{ResourceSummary: resource})
+	}
+	f.writeResourceRows(builder, rows, []string{"Address", "Type", "Provider", "Module"}, opts)
+}
+
+// writeCompactResourceTable writes every resource change as one table with
+// the total action counts in its heading.
+func (f *TableFormatter) writeCompactResourceTable(builder *strings.Builder, changes core.Changes, opts core.SummaryOptions) {
+	counts := totalCounts(changes)
+	fmt.Fprintf(builder, "## Resource Changes (%d: %s)\n\n", counts.Total(), counts)
+	if counts.Total() == 0 {
+		return
+	}
+
+	columns := []string{"Action", "Address"}
+	if !isActionGrouping(opts.GroupBy) {
+		columns = append(columns, groupHeading(opts.GroupBy))
+	}
+	var rows []plannedResource
+	for _, group := range groupResources(changes, opts.GroupBy) {
+		rows = append(rows, group.resources...)
+	}
+	f.writeResourceRows(builder, rows, columns, opts)
+}
+
+// writeResourceRows writes a resource table with the given columns, followed
+// by a Sensitive column unless sensitive markers are suppressed.
+func (f *TableFormatter) writeResourceRows(builder *strings.Builder, resources []plannedResource, columns []string, opts core.SummaryOptions) {
+	if !opts.NoSensitive {
+		columns = append(columns, "Sensitive")
+	}
+	writeTableHeader(builder, columns)
 
 	for _, resource := range resources {
-		address := resource.Address
 		module := resource.ModuleAddress
 		if module == "" {
 			module = "root"
 		}
-		sensitive := "No"
-		if resource.Sensitive {
-			sensitive = "Yes"
+		cells := make([]string, 0, len(columns))
+		for _, column := range columns {
+			switch column {
+			case "Action":
+				cells = append(cells, string(resource.action))
+			case "Address":
+				cells = append(cells, resource.Address)
+			case "Type", "Resource Type":
+				cells = append(cells, resource.Type)
+			case "Provider":
+				cells = append(cells, resource.Provider)
+			case "Module":
+				cells = append(cells, module)
+			case "Sensitive":
+				cells = append(cells, yesNo(resource.Sensitive))
+			}
 		}
-		fmt.Fprintf(builder, "| %s | %s | %s | %s | %s |\n",
-			address, resource.Type, resource.Provider, module, sensitive)
+		builder.WriteString("| " + strings.Join(cells, " | ") + " |\n")
 	}
 	builder.WriteString("\n")
 }
 
+// writeTableHeader writes a header row and a separator sized to each column.
+func writeTableHeader(builder *strings.Builder, columns []string) {
+	separators := make([]string, len(columns))
+	for i, column := range columns {
+		separators[i] = strings.Repeat("-", len(column)+2)
+	}
+	builder.WriteString("| " + strings.Join(columns, " | ") + " |\n")
+	builder.WriteString("|" + strings.Join(separators, "|") + "|\n")
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
+
 // writeOutputChangesTable writes the output changes as a table
-func (f *TableFormatter) writeOutputChangesTable(builder *strings.Builder, outputs []core.OutputSummary) {
+func (f *TableFormatter) writeOutputChangesTable(builder *strings.Builder, outputs []core.OutputSummary, opts core.SummaryOptions) {
 	builder.WriteString("## Output Changes\n\n")
-	builder.WriteString("| Name | Actions | Sensitive | Value |\n")
-	builder.WriteString("|------|---------|-----------|-------|\n")
+	if opts.NoSensitive {
+		writeTableHeader(builder, []string{"Name", "Actions", "Value"})
+	} else {
+		writeTableHeader(builder, []string{"Name", "Actions", "Sensitive", "Value"})
+	}
 
 	for _, output := range outputs {
 		actions := strings.Join(output.Actions, ", ")
-		sensitive := "No"
-		if output.Sensitive {
-			sensitive = "Yes"
-		}
 
 		value := "N/A"
 		if !output.Sensitive && output.Value != nil {
 			value = fmt.Sprintf("%v", output.Value)
 		}
 
-		fmt.Fprintf(builder, "| %s | %s | %s | %s |\n",
-			output.Name, actions, sensitive, value)
+		if opts.NoSensitive {
+			fmt.Fprintf(builder, "| %s | %s | %s |\n", output.Name, actions, value)
+		} else {
+			fmt.Fprintf(builder, "| %s | %s | %s | %s |\n",
+				output.Name, actions, yesNo(output.Sensitive), value)
+		}
 	}
 	builder.WriteString("\n")
 }
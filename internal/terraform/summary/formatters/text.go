@@ -37,6 +37,11 @@ func NewTextFormatter(useColor bool) *TextFormatter {
 func (f *TextFormatter) Format(summary *core.PlanSummary, opts core.SummaryOptions) (string, error) {
 	var builder strings.Builder
 
+	if opts.Compact {
+		f.writeCompact(&builder, summary, opts)
+		return builder.String(), nil
+	}
+
 	// Header
 	f.writeHeader(&builder, summary.PlanInfo)
 
@@ -48,7 +53,7 @@ func (f *TextFormatter) Format(summary *core.PlanSummary, opts core.SummaryOptio
 
 	// Output Changes
 	if len(summary.Outputs) > 0 {
-		f.writeOutputChanges(&builder, summary.Outputs)
+		f.writeOutputChanges(&builder, summary.Outputs, opts)
 	}
 
 	return builder.String(), nil
@@ -59,15 +64,7 @@ func (f *TextFormatter) writeHeader(builder *strings.Builder, info core.PlanInfo
 	builder.WriteString("Terraform Plan Summary\n")
 	builder.WriteString("======================\n\n")
 
-	status := "✅ Applicable"
-	if !info.Applicable {
-		status = "❌ Not Applicable"
-	}
-	if info.Errored {
-		status = "💥 Errored"
-	}
-
-	fmt.Fprintf(builder, "Plan Status: %s\n", status)
+	fmt.Fprintf(builder, "Plan Status: %s\n", planStatus(info))
 	fmt.Fprintf(builder, "Format Version: %s\n", info.FormatVersion)
 	fmt.Fprintf(builder, "Complete: %t\n\n", info.Complete)
 }
@@ -116,6 +113,13 @@ func (f *TextFormatter) writeResourceChanges(builder *strings.Builder, changes c
 	builder.WriteString("🔄 Resource Changes\n")
 	builder.WriteString("-------------------\n\n")
 
+	if !isActionGrouping(opts.GroupBy) {
+		for _, group := range groupResources(changes, opts.GroupBy) {
+			f.writeGroup(builder, group, opts)
+		}
+		return
+	}
+
 	// Create
 	if len(changes.Create) > 0 {
 		f.writeActionGroup(builder, "➕ Create", changes.Create, opts)
@@ -148,19 +152,32 @@ func (f *TextFormatter) writeActionGroup(builder *strings.Builder, title string,
 	builder.WriteString(strings.Repeat("-", len(title)+len(fmt.Sprintf(" (%d)", len(resources)))) + "\n")
 
 	for _, resource := range resources {
-		f.writeResource(builder, resource, opts)
+		f.writeResource(builder, "", resource, opts)
+	}
+	builder.WriteString("\n")
+}
+
+// writeGroup writes a module, provider or resource type group. Each resource
+// is prefixed with its action symbol because the group no longer implies it.
+func (f *TextFormatter) writeGroup(builder *strings.Builder, group resourceGroup, opts core.SummaryOptions) {
+	title := fmt.Sprintf("%s %s (%d: %s)", groupIcon(opts.GroupBy), groupTitle(group.name, opts.GroupBy), group.counts.Total(), group.counts)
+	builder.WriteString(title + "\n")
+	builder.WriteString(strings.Repeat("-", len(title)) + "\n")
+
+	for _, resource := range group.resources {
+		f.writeResource(builder, actionSymbol(resource.action)+" ", resource.ResourceSummary, opts)
 	}
 	builder.WriteString("\n")
 }
 
 // writeResource writes a single resource
-func (f *TextFormatter) writeResource(builder *strings.Builder, resource core.ResourceSummary, opts core.SummaryOptions) {
+func (f *TextFormatter) writeResource(builder *strings.Builder, prefix string, resource core.ResourceSummary, opts core.SummaryOptions) {
 	// Resource address
 	address := resource.Address
-	fmt.Fprintf(builder, "  %s\n", address)
+	fmt.Fprintf(builder, "  %s%s\n", prefix, address)
 
 	// Sensitive indicator
-	if resource.Sensitive {
+	if showSensitive(resource.Sensitive, opts) {
 		builder.WriteString("    🔒 Contains sensitive values\n")
 	}
 
@@ -178,14 +195,16 @@ func (f *TextFormatter) writeResource(builder *strings.Builder, resource core.Re
 }
 
 // writeOutputChanges writes the output changes section
-func (f *TextFormatter) writeOutputChanges(builder *strings.Builder, outputs []core.OutputSummary) {
+func (f *TextFormatter) writeOutputChanges(builder *strings.Builder, outputs []core.OutputSummary, opts core.SummaryOptions) {
 	builder.WriteString("📤 Output Changes\n")
 	builder.WriteString("-----------------\n\n")
 
 	for _, output := range outputs {
 		fmt.Fprintf(builder, "  %s\n", output.Name)
 		if output.Sensitive {
-			builder.WriteString("    🔒 Sensitive value\n")
+			if !opts.NoSensitive {
+				builder.WriteString("    🔒 Sensitive value\n")
+			}
 		} else if output.Value != nil {
 			fmt.Fprintf(builder, "    Value: %v\n", output.Value)
 		}
@@ -193,6 +212,46 @@ func (f *TextFormatter) writeOutputChanges(builder *strings.Builder, outputs []c
 	builder.WriteString("\n")
 }
 
+// writeCompact writes the whole summary with one line per resource and output
+// and without the statistics breakdowns.
+func (f *TextFormatter) writeCompact(builder *strings.Builder, summary *core.PlanSummary, opts core.SummaryOptions) {
+	counts := totalCounts(summary.Changes)
+	fmt.Fprintf(builder, "Terraform Plan Summary: %s, %d changes (%s)\n", planStatus(summary.PlanInfo), summary.Statistics.TotalChanges, counts)
+
+	for _, group := range groupResources(summary.Changes, opts.GroupBy) {
+		if isActionGrouping(opts.GroupBy) {
+			fmt.Fprintf(builder, "%s (%d):\n", group.name, group.counts.Total())
+		} else {
+			fmt.Fprintf(builder, "%s (%s):\n", groupTitle(group.name, opts.GroupBy), group.counts)
+		}
+		for _, resource := range group.resources {
+			line := fmt.Sprintf("  %s %s", actionSymbol(resource.action), resource.Address)
+			if showSensitive(resource.Sensitive, opts) {
+				line += " 🔒"
+			}
+			if opts.ShowDetails && len(resource.KeyChanges) > 0 {
+				line += " [" + strings.Join(changedKeys(resource.ResourceSummary), ", ") + "]"
+			}
+			builder.WriteString(line + "\n")
+		}
+	}
+
+	if len(summary.Outputs) > 0 {
+		fmt.Fprintf(builder, "outputs (%d):\n", len(summary.Outputs))
+		for _, output := range summary.Outputs {
+			line := "  " + output.Name
+			if output.Sensitive {
+				if !opts.NoSensitive {
+					line += " 🔒"
+				}
+			} else if output.Value != nil {
+				line += fmt.Sprintf(" = %v", output.Value)
+			}
+			builder.WriteString(line + "\n")
+		}
+	}
+}
+
 // getActionIcon returns an icon for the given action
 func (f *TextFormatter) getActionIcon(action string) string {
 	switch action {
@@ -62,7 +62,7 @@ func (s *Summarizer) calculateStatistics(changeSet *ir.ChangeSet) core.Statistic
 		for _, action := range change.Action.Raw {
 			stats.ActionBreakdown[action]++
 		}
-		stats.ProviderBreakdown[extractProviderFromType(change.Type)]++
+		stats.ProviderBreakdown[providerLabel(change)]++
 		stats.ResourceBreakdown[change.Type]++
 		module := "root"
 		if change.ModuleAddress != nil && *change.ModuleAddress != "" {
@@ -81,14 +81,15 @@ func (s *Summarizer) groupResourceChanges(changeSet *ir.ChangeSet) core.Changes
 			moduleAddress = string(*change.ModuleAddress)
 		}
 		item := core.ResourceSummary{
-			Address:       string(change.Address),
-			ModuleAddress: moduleAddress,
-			Type:          change.Type,
-			Name:          change.Name,
-			Provider:      extractProviderFromType(change.Type),
-			Actions:       append([]string(nil), change.Action.Raw...),
-			Sensitive:     len(change.SensitivePaths) > 0,
-			KeyChanges:    extractKeyChanges(change),
+			Address:        string(change.Address),
+			ModuleAddress:  moduleAddress,
+			Type:           change.Type,
+			Name:           change.Name,
+			Provider:       providerLabel(change),
+			ProviderConfig: change.ProviderConfig,
+			Actions:        append([]string(nil), change.Action.Raw...),
+			Sensitive:      len(change.SensitivePaths) > 0,
+			KeyChanges:     extractKeyChanges(change),
 		}
 
 		switch change.Action.Semantic {
@@ -170,6 +171,15 @@ func extractKeyChanges(change ir.ResourceChange) map[string]interface{} {
 	return keyChanges
 }
 
+// providerLabel prefers the provider recorded in the plan and only guesses
+// from the resource type prefix for plans that lack provider_name.
+func providerLabel(change ir.ResourceChange) string {
+	if label := change.ProviderLabel(); label != "" {
+		return label
+	}
+	return extractProviderFromType(change.Type)
+}
+
 func extractProviderFromType(resourceType string) string {
 	provider, _, ok := strings.Cut(resourceType, "_")
 	if !ok || provider == "" {
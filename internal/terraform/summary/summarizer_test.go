@@ -121,6 +121,42 @@ func TestExtractKeyChangesDoesNotExposeFullyRedactedValues(t *testing.T) {
 	assert.Nil(t, extractKeyChanges(change))
 }
 
+func TestSummarizePlanUsesRecordedProviderNames(t *testing.T) {
+	changeSet := &ir.ChangeSet{
+		Resources: []ir.ResourceChange{
+			{
+				Address: "google_compute_instance.vm", Type: "google_compute_instance", Name: "vm",
+				Action:       ir.NormalizeAction([]string{"create"}),
+				ProviderName: "registry.terraform.io/hashicorp/google-beta",
+			},
+			{
+				Address: "aws_s3_bucket.replica", Type: "aws_s3_bucket", Name: "replica",
+				Action:         ir.NormalizeAction([]string{"create"}),
+				ProviderName:   "registry.terraform.io/hashicorp/aws",
+				ProviderAlias:  "us_east_1",
+				ProviderConfig: `provider["registry.terraform.io/hashicorp/aws"].us_east_1`,
+			},
+			{
+				Address: "aws_s3_bucket.primary", Type: "aws_s3_bucket", Name: "primary",
+				Action: ir.NormalizeAction([]string{"create"}),
+			},
+		},
+	}
+
+	got, err := NewSummarizer().SummarizePlan(changeSet, core.SummaryOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"google-beta": 1, "aws.us_east_1": 1, "aws": 1}, got.Statistics.ProviderBreakdown)
+	require.Len(t, got.Changes.Create, 3)
+	byAddress := make(map[string]core.ResourceSummary)
+	for _, item := range got.Changes.Create {
+		byAddress[item.Address] = item
+	}
+	assert.Equal(t, "aws.us_east_1", byAddress["aws_s3_bucket.replica"].Provider)
+	assert.Equal(t, `provider["registry.terraform.io/hashicorp/aws"].us_east_1`, byAddress["aws_s3_bucket.replica"].ProviderConfig)
+	// Without provider_name the type prefix is still used.
+	assert.Equal(t, "aws", byAddress["aws_s3_bucket.primary"].Provider)
+}
+
 func TestExtractProviderFromType(t *testing.T) {
 	assert.Equal(t, "aws", extractProviderFromType("aws_instance"))
 	assert.Equal(t, "google", extractProviderFromType("google_compute_instance"))
@@ -0,0 +1,412 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package versionpolicy evaluates Terraform version constraint strings as sets
+// of versions and checks the constraints of configured workspaces against an
+// organisation's version policy.
+package versionpolicy
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version. Missing minor and patch segments are zero,
+// and a prerelease sorts before the release it precedes. Build metadata is
+// ignored.
+type Version struct {
+	Major, Minor, Patch int
+	Prerelease          string
+}
+
+// ParseVersion parses versions such as 1, 1.5, 1.5.7 and 1.6.0-beta1. A
+// leading "v" is accepted.
+func ParseVersion(value string) (Version, error) {
+	version, _, err := parseVersion(value)
+	return version, err
+}
+
+// parseVersion also returns how many numeric segments were written, which
+// the pessimistic operator needs.
+func parseVersion(value string) (Version, int, error) {
+	raw := strings.TrimPrefix(strings.TrimSpace(value), "v")
+	if raw == "" {
+		return Version{}, 0, fmt.Errorf("invalid version %q", value)
+	}
+	if i := strings.IndexByte(raw, '+'); i >= 0 {
+		raw = raw[:i]
+	}
+	var version Version
+	if i := strings.IndexByte(raw, '-'); i >= 0 {
+		version.Prerelease = raw[i+1:]
+		raw = raw[:i]
+		if version.Prerelease == "" {
+			return Version{}, 0, fmt.Errorf("invalid version %q: empty prerelease", value)
+		}
+	}
+	parts := strings.Split(raw, ".")
+	if len(parts) > 3 {
+		return Version{}, 0, fmt.Errorf("invalid version %q: at most three numeric segments", value)
+	}
+	segments := [3]int{}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, 0, fmt.Errorf("invalid version %q", value)
+		}
+		segments[i] = n
+	}
+	version.Major, version.Minor, version.Patch = segments[0], segments[1], segments[2]
+	return version, len(parts), nil
+}
+
+// Compare returns -1, 0 or 1 as v sorts before, equal to or after other.
+func (v Version) Compare(other Version) int {
+	for _, pair := range [][2]int{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// comparePrerelease orders prerelease identifiers as SemVer does: a release
+// sorts after its prereleases, numeric identifiers compare numerically and
+// before alphanumeric ones.
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	left, right := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(left) && i < len(right); i++ {
+		ln, lerr := strconv.Atoi(left[i])
+		rn, rerr := strconv.Atoi(right[i])
+		switch {
+		case lerr == nil && rerr == nil:
+			if ln != rn {
+				return compareInts(ln, rn)
+			}
+		case lerr == nil:
+			return -1
+		case rerr == nil:
+			return 1
+		default:
+			if c := strings.Compare(left[i], right[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareInts(len(left), len(right))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// bound is one end of an interval. An unbounded end has bounded == false.
+type bound struct {
+	version   Version
+	inclusive bool
+	bounded   bool
+}
+
+// interval is a contiguous range of versions.
+type interval struct {
+	lower, upper bound
+}
+
+func (i interval) empty() bool {
+	if !i.lower.bounded || !i.upper.bounded {
+		return false
+	}
+	switch i.lower.version.Compare(i.upper.version) {
+	case 1:
+		return true
+	case 0:
+		return !i.lower.inclusive || !i.upper.inclusive
+	default:
+		return false
+	}
+}
+
+func (i interval) String() string {
+	switch {
+	case !i.lower.bounded && !i.upper.bounded:
+		return "any version"
+	case i.lower.bounded && i.upper.bounded && i.lower.inclusive && i.upper.inclusive && i.lower.version.Compare(i.upper.version) == 0:
+		return "= " + i.lower.version.String()
+	}
+	var parts []string
+	if i.lower.bounded {
+		op := ">"
+		if i.lower.inclusive {
+			op = ">="
+		}
+		parts = append(parts, op+" "+i.lower.version.String())
+	}
+	if i.upper.bounded {
+		op := "<"
+		if i.upper.inclusive {
+			op = "<="
+		}
+		parts = append(parts, op+" "+i.upper.version.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// compareLower orders lower bounds: unbounded first, then by version, with an
+// inclusive bound before an exclusive one on the same version.
+func compareLower(a, b bound) int {
+	switch {
+	case !a.bounded && !b.bounded:
+		return 0
+	case !a.bounded:
+		return -1
+	case !b.bounded:
+		return 1
+	}
+	if c := a.version.Compare(b.version); c != 0 {
+		return c
+	}
+	switch {
+	case a.inclusive == b.inclusive:
+		return 0
+	case a.inclusive:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// compareUpper orders upper bounds: unbounded last, then by version, with an
+// exclusive bound before an inclusive one on the same version.
+func compareUpper(a, b bound) int {
+	switch {
+	case !a.bounded && !b.bounded:
+		return 0
+	case !a.bounded:
+		return 1
+	case !b.bounded:
+		return -1
+	}
+	if c := a.version.Compare(b.version); c != 0 {
+		return c
+	}
+	switch {
+	case a.inclusive == b.inclusive:
+		return 0
+	case a.inclusive:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// Set is a union of disjoint intervals sorted by lower bound.
+type Set []interval
+
+// anyVersion is the set of every version.
+var anyVersion = Set{{}}
+
+// Empty reports whether the set contains no version.
+func (s Set) Empty() bool { return len(s) == 0 }
+
+// String renders the set as constraint clauses, with " or " between
+// disjoint ranges.
+func (s Set) String() string {
+	if s.Empty() {
+		return "no version"
+	}
+	parts := make([]string, 0, len(s))
+	for _, iv := range s {
+		parts = append(parts, iv.String())
+	}
+	return strings.Join(parts, " or ")
+}
+
+// Contains reports whether v is in the set.
+func (s Set) Contains(v Version) bool {
+	point := bound{version: v, inclusive: true, bounded: true}
+	return !s.Intersect(Set{{lower: point, upper: point}}).Empty()
+}
+
+// Intersect returns the versions in both s and other.
+func (s Set) Intersect(other Set) Set {
+	var out Set
+	for _, a := range s {
+		for _, b := range other {
+			iv := interval{lower: a.lower, upper: a.upper}
+			if compareLower(b.lower, iv.lower) > 0 {
+				iv.lower = b.lower
+			}
+			if compareUpper(b.upper, iv.upper) < 0 {
+				iv.upper = b.upper
+			}
+			if !iv.empty() {
+				out = append(out, iv)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return compareLower(out[i].lower, out[j].lower) < 0 })
+	return out
+}
+
+// Complement returns the versions not in s.
+func (s Set) Complement() Set {
+	var out Set
+	lower := bound{}
+	for _, iv := range s {
+		if iv.lower.bounded {
+			gap := interval{lower: lower, upper: bound{version: iv.lower.version, inclusive: !iv.lower.inclusive, bounded: true}}
+			if !gap.empty() {
+				out = append(out, gap)
+			}
+		}
+		if !iv.upper.bounded {
+			return out
+		}
+		lower = bound{version: iv.upper.version, inclusive: !iv.upper.inclusive, bounded: true}
+	}
+	return append(out, interval{lower: lower})
+}
+
+// Minus returns the versions in s that are not in other.
+func (s Set) Minus(other Set) Set {
+	return s.Intersect(other.Complement())
+}
+
+// Constraint is a parsed Terraform version constraint: comma-separated
+// clauses that must all hold. The empty constraint allows every version.
+type Constraint struct {
+	raw string
+	set Set
+}
+
+// ParseConstraint parses constraints such as ">= 1.5.0, < 2.0.0", "~> 5.0"
+// and "!= 1.6.2". Supported operators are =, !=, >, >=, <, <= and ~>; a
+// version without an operator means =.
+func ParseConstraint(value string) (Constraint, error) {
+	set := anyVersion
+	for _, clause := range strings.Split(value, ",") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			if strings.TrimSpace(value) != "" {
+				return Constraint{}, fmt.Errorf("invalid constraint %q: empty clause", value)
+			}
+			continue
+		}
+		clauseSet, err := parseClause(clause)
+		if err != nil {
+			return Constraint{}, fmt.Errorf("invalid constraint %q: %w", value, err)
+		}
+		set = set.Intersect(clauseSet)
+	}
+	return Constraint{raw: strings.TrimSpace(value), set: set}, nil
+}
+
+// MustParseConstraint is ParseConstraint for constraints known to be valid.
+func MustParseConstraint(value string) Constraint {
+	constraint, err := ParseConstraint(value)
+	if err != nil {
+		panic(err)
+	}
+	return constraint
+}
+
+var operators = []string{"~>", ">=", "<=", "!=", "=", ">", "<"}
+
+func parseClause(clause string) (Set, error) {
+	op := "="
+	for _, candidate := range operators {
+		if strings.HasPrefix(clause, candidate) {
+			op = candidate
+			clause = strings.TrimSpace(clause[len(candidate):])
+			break
+		}
+	}
+	version, segments, err := parseVersion(clause)
+	if err != nil {
+		return nil, err
+	}
+	at := func(inclusive bool) bound { return bound{version: version, inclusive: inclusive, bounded: true} }
+	switch op {
+	case "=":
+		return Set{{lower: at(true), upper: at(true)}}, nil
+	case "!=":
+		return Set{{upper: at(false)}, {lower: at(false)}}, nil
+	case ">":
+		return Set{{lower: at(false)}}, nil
+	case ">=":
+		return Set{{lower: at(true)}}, nil
+	case "<":
+		return Set{{upper: at(false)}}, nil
+	case "<=":
+		return Set{{upper: at(true)}}, nil
+	default: // ~>
+		return Set{{lower: at(true), upper: bound{version: pessimisticUpper(version, segments), bounded: true}}}, nil
+	}
+}
+
+// pessimisticUpper is the exclusive upper bound of "~> version": only the
+// rightmost written segment may increase, so ~> 1.2.3 allows < 1.3.0 and
+// ~> 1.2 allows < 2.0.0. A single segment behaves like two.
+func pessimisticUpper(version Version, segments int) Version {
+	if segments == 3 {
+		return Version{Major: version.Major, Minor: version.Minor + 1}
+	}
+	return Version{Major: version.Major + 1}
+}
+
+// String returns the constraint as written.
+func (c Constraint) String() string { return c.raw }
+
+// Set returns the versions the constraint allows.
+func (c Constraint) Set() Set {
+	if c.set == nil && c.raw == "" {
+		return anyVersion
+	}
+	return c.set
+}
+
+// Allows reports whether v satisfies the constraint.
+func (c Constraint) Allows(v Version) bool { return c.Set().Contains(v) }
+
+// Excess returns the versions c allows that other does not. It is empty when
+// every version acceptable to c is acceptable to other.
+func (c Constraint) Excess(other Constraint) Set { return c.Set().Minus(other.Set()) }
@@ -0,0 +1,83 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package versionpolicy
+
+import "testing"
+
+func TestParseConstraintRejectsInvalid(t *testing.T) {
+	for _, raw := range []string{"~>", ">= one", "=> 1.0", "1.2.3.4", ">= 1.0,"} {
+		if _, err := ParseConstraint(raw); err == nil {
+			t.Errorf("ParseConstraint(%q) succeeded, want error", raw)
+		}
+	}
+}
+
+func TestConstraintAllows(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"~> 1.2.3", "1.2.9", true},
+		{"~> 1.2.3", "1.3.0", false},
+		{"~> 1.2", "1.9.0", true},
+		{"~> 1.2", "2.0.0", false},
+		{"~> 1.2", "1.1.9", false},
+		{">= 1.5, < 2.0", "1.5.0", true},
+		{">= 1.5, < 2.0", "2.0.0", false},
+		{"!= 1.6.0", "1.6.0", false},
+		{"!= 1.6.0", "1.6.1", true},
+		{"1.4.2", "1.4.2", true},
+		{"= 1.4.2", "1.4.3", false},
+		{"", "0.1.0", true},
+		{">= 1.1.0", "1.1.0-beta1", false},
+		{"1.1.0-beta1", "1.1.0-beta1", true},
+	}
+	for _, tt := range tests {
+		constraint := MustParseConstraint(tt.constraint)
+		version, err := ParseVersion(tt.version)
+		if err != nil {
+			t.Fatalf("ParseVersion(%q): %v", tt.version, err)
+		}
+		if got := constraint.Allows(version); got != tt.want {
+			t.Errorf("%q allows %s = %v, want %v", tt.constraint, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestConstraintExcess(t *testing.T) {
+	tests := []struct {
+		constraint string
+		policy     string
+		want       string
+	}{
+		{"~> 5.10", ">= 5.0, < 6.0", ""},
+		{"~> 5.10", ">= 5.0, < 5.50", ">= 5.50.0, < 6.0.0"},
+		{">= 4.0", ">= 5.0, < 6.0", ">= 4.0.0, < 5.0.0 or >= 6.0.0"},
+		{"~> 1.6.0", ">= 1.5, != 1.6.2", "= 1.6.2"},
+		{"", "~> 1.6", "< 1.6.0 or >= 2.0.0"},
+		{">= 2.0, < 1.0", ">= 1.0", ""},
+	}
+	for _, tt := range tests {
+		excess := MustParseConstraint(tt.constraint).Excess(MustParseConstraint(tt.policy))
+		got := ""
+		if !excess.Empty() {
+			got = excess.String()
+		}
+		if got != tt.want {
+			t.Errorf("%q excess over %q = %q, want %q", tt.constraint, tt.policy, got, tt.want)
+		}
+	}
+}
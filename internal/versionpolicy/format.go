@@ -0,0 +1,89 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package versionpolicy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Format is an output format of check-versions.
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+)
+
+// ParseFormat validates a --format value.
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(value)) {
+	case FormatText, "":
+		return FormatText, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported version check format %q: use text or json", value)
+	}
+}
+
+// Render renders a policy check report.
+func Render(out Report, format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return renderJSON(out)
+	case FormatText, "":
+		return []byte(renderText(out)), nil
+	default:
+		return nil, fmt.Errorf("unsupported version check format %q", format)
+	}
+}
+
+func renderJSON(out Report) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func renderText(out Report) string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "Terraform Version Policy Check")
+	fmt.Fprintln(&b, "==============================")
+	fmt.Fprintf(&b, "Policy: %s\n", out.Policy)
+	fmt.Fprintf(&b, "Workspaces: %d checked, %d non-compliant, %d violations\n\n",
+		out.Summary.Workspaces, out.Summary.NonCompliant, out.Summary.Violations)
+	if out.Summary.Workspaces == 0 {
+		fmt.Fprintln(&b, "No workspaces found.")
+		return b.String()
+	}
+	for _, workspace := range out.Workspaces {
+		if workspace.Compliant {
+			fmt.Fprintf(&b, "PASS  %s\n", workspace.Path)
+			continue
+		}
+		fmt.Fprintf(&b, "FAIL  %s\n", workspace.Path)
+		for _, violation := range workspace.Violations {
+			fmt.Fprintf(&b, "      [%s] %s\n", violation.Kind, violation.Message)
+		}
+	}
+	return b.String()
+}
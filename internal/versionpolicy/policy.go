@@ -0,0 +1,291 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package versionpolicy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/yu/terraform-ops/internal/core"
+	"github.com/yu/terraform-ops/internal/report"
+)
+
+const SchemaVersion = "1.0"
+
+// Policy is the version policy file accepted by check-versions --policy.
+type Policy struct {
+	Terraform       *TerraformRule          `yaml:"terraform"`
+	Providers       map[string]ProviderRule `yaml:"providers"`
+	BannedProviders []string                `yaml:"banned_providers"`
+}
+
+// TerraformRule lists the Terraform versions a workspace may run with.
+type TerraformRule struct {
+	Allowed string `yaml:"allowed"`
+}
+
+// ProviderRule bounds the versions of one provider. Allowed is a constraint;
+// Minimum and Maximum are inclusive versions. All populated fields apply.
+type ProviderRule struct {
+	Allowed string `yaml:"allowed"`
+	Minimum string `yaml:"minimum"`
+	Maximum string `yaml:"maximum"`
+}
+
+// compiledPolicy holds the policy with every constraint parsed.
+type compiledPolicy struct {
+	terraform *Constraint
+	providers map[string]providerBounds
+	banned    map[string]struct{}
+}
+
+type providerBounds struct {
+	constraint  Constraint
+	description string
+}
+
+// Violation kinds.
+const (
+	KindTerraformVersion  = "terraform_version"
+	KindProviderVersion   = "provider_version"
+	KindBannedProvider    = "banned_provider"
+	KindInvalidConstraint = "invalid_constraint"
+)
+
+// Violation is one way a workspace does not satisfy the policy. Excess lists
+// the versions the workspace accepts that the policy does not.
+type Violation struct {
+	Kind       string `json:"kind"`
+	Subject    string `json:"subject"`
+	Constraint string `json:"constraint"`
+	Policy     string `json:"policy,omitempty"`
+	Excess     string `json:"excess,omitempty"`
+	Message    string `json:"message"`
+}
+
+type WorkspaceResult struct {
+	Path       string      `json:"path"`
+	Compliant  bool        `json:"compliant"`
+	Violations []Violation `json:"violations"`
+}
+
+type Summary struct {
+	Workspaces   int `json:"workspaces"`
+	NonCompliant int `json:"non_compliant"`
+	Violations   int `json:"violations"`
+}
+
+type Report struct {
+	SchemaVersion string              `json:"schema_version"`
+	Tool          report.ToolMetadata `json:"tool"`
+	Policy        string              `json:"policy"`
+	Summary       Summary             `json:"summary"`
+	Workspaces    []WorkspaceResult   `json:"workspaces"`
+}
+
+// LoadPolicy reads a YAML (or JSON) policy file and validates its constraints.
+func LoadPolicy(path string) (Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Policy{}, fmt.Errorf("read version policy: %w", err)
+	}
+	var policy Policy
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&policy); err != nil && !errors.Is(err, io.EOF) {
+		return Policy{}, fmt.Errorf("parse version policy %s: %w", path, err)
+	}
+	if _, err := policy.compile(); err != nil {
+		return Policy{}, fmt.Errorf("invalid version policy %s: %w", path, err)
+	}
+	return policy, nil
+}
+
+func (p Policy) compile() (compiledPolicy, error) {
+	compiled := compiledPolicy{
+		providers: make(map[string]providerBounds, len(p.Providers)),
+		banned:    make(map[string]struct{}, len(p.BannedProviders)),
+	}
+	if p.Terraform != nil && p.Terraform.Allowed != "" {
+		constraint, err := ParseConstraint(p.Terraform.Allowed)
+		if err != nil {
+			return compiledPolicy{}, fmt.Errorf("terraform.allowed: %w", err)
+		}
+		compiled.terraform = &constraint
+	}
+	var errs []error
+	for _, name := range sortedNames(p.Providers) {
+		rule := p.Providers[name]
+		bounds, err := rule.compile()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("providers.%s: %w", name, err))
+			continue
+		}
+		compiled.providers[name] = bounds
+	}
+	for _, name := range p.BannedProviders {
+		compiled.banned[strings.ToLower(name)] = struct{}{}
+	}
+	return compiled, errors.Join(errs...)
+}
+
+func (r ProviderRule) compile() (providerBounds, error) {
+	var clauses []string
+	if r.Allowed != "" {
+		clauses = append(clauses, r.Allowed)
+	}
+	if r.Minimum != "" {
+		if _, err := ParseVersion(r.Minimum); err != nil {
+			return providerBounds{}, fmt.Errorf("minimum: %w", err)
+		}
+		clauses = append(clauses, ">= "+r.Minimum)
+	}
+	if r.Maximum != "" {
+		if _, err := ParseVersion(r.Maximum); err != nil {
+			return providerBounds{}, fmt.Errorf("maximum: %w", err)
+		}
+		clauses = append(clauses, "<= "+r.Maximum)
+	}
+	if len(clauses) == 0 {
+		return providerBounds{}, errors.New("set allowed, minimum or maximum")
+	}
+	description := strings.Join(clauses, ", ")
+	constraint, err := ParseConstraint(description)
+	if err != nil {
+		return providerBounds{}, err
+	}
+	return providerBounds{constraint: constraint, description: description}, nil
+}
+
+// Check evaluates every workspace against the policy. A workspace constraint
+// satisfies a rule when every version it accepts is allowed by the rule, so
+// an unset constraint never satisfies a bounded rule.
+func (p Policy) Check(configs []core.TerraformConfig, policyPath, toolVersion string) (Report, error) {
+	compiled, err := p.compile()
+	if err != nil {
+		return Report{}, err
+	}
+	out := Report{
+		SchemaVersion: SchemaVersion,
+		Tool:          report.ToolMetadata{Name: "terraform-ops", Version: toolVersion},
+		Policy:        policyPath,
+		Workspaces:    make([]WorkspaceResult, 0, len(configs)),
+	}
+	for _, config := range configs {
+		result := WorkspaceResult{Path: config.Path, Violations: compiled.check(config)}
+		if result.Violations == nil {
+			result.Violations = []Violation{}
+		}
+		result.Compliant = len(result.Violations) == 0
+		out.Summary.Workspaces++
+		out.Summary.Violations += len(result.Violations)
+		if !result.Compliant {
+			out.Summary.NonCompliant++
+		}
+		out.Workspaces = append(out.Workspaces, result)
+	}
+	sort.SliceStable(out.Workspaces, func(i, j int) bool { return out.Workspaces[i].Path < out.Workspaces[j].Path })
+	return out, nil
+}
+
+func (p compiledPolicy) check(config core.TerraformConfig) []Violation {
+	var violations []Violation
+	if p.terraform != nil {
+		if violation, ok := checkConstraint(KindTerraformVersion, "terraform", config.RequiredVersion, *p.terraform, p.terraform.String()); ok {
+			violations = append(violations, violation)
+		}
+	}
+	for _, name := range sortedNames(config.RequiredProviders) {
+		declared := config.RequiredProviders[name]
+		if p.isBanned(name, config.Providers[name]) {
+			violations = append(violations, Violation{
+				Kind:       KindBannedProvider,
+				Subject:    name,
+				Constraint: declared,
+				Message:    fmt.Sprintf("provider %s is banned by the policy", name),
+			})
+			continue
+		}
+		bounds, ok := p.providers[name]
+		if !ok {
+			continue
+		}
+		if violation, ok := checkConstraint(KindProviderVersion, name, declared, bounds.constraint, bounds.description); ok {
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+// isBanned matches banned_providers entries against the provider's local
+// name, its source as written, and its fully qualified address.
+func (p compiledPolicy) isBanned(name string, requirement core.ProviderRequirement) bool {
+	for _, candidate := range []string{name, requirement.Source, requirement.Address} {
+		if candidate == "" {
+			continue
+		}
+		if _, ok := p.banned[strings.ToLower(candidate)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checkConstraint returns a violation when declared accepts versions outside
+// policy.
+func checkConstraint(kind, subject, declared string, policy Constraint, description string) (Violation, bool) {
+	constraint, err := ParseConstraint(declared)
+	if err != nil {
+		return Violation{
+			Kind:       KindInvalidConstraint,
+			Subject:    subject,
+			Constraint: declared,
+			Policy:     description,
+			Message:    err.Error(),
+		}, true
+	}
+	excess := constraint.Excess(policy)
+	if excess.Empty() {
+		return Violation{}, false
+	}
+	message := fmt.Sprintf("%s constraint %q also accepts %s, outside the policy %q", subject, declared, excess, description)
+	if declared == "" {
+		message = fmt.Sprintf("%s has no version constraint; the policy requires %q", subject, description)
+	}
+	return Violation{
+		Kind:       kind,
+		Subject:    subject,
+		Constraint: declared,
+		Policy:     description,
+		Excess:     excess.String(),
+		Message:    message,
+	}, true
+}
+
+func sortedNames[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package versionpolicy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yu/terraform-ops/internal/core"
+)
+
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPolicyRejectsInvalidConstraints(t *testing.T) {
+	for name, content := range map[string]string{
+		"terraform": "terraform:\n  allowed: \">= one\"\n",
+		"minimum":   "providers:\n  aws:\n    minimum: \"~> 5.0\"\n",
+		"empty":     "providers:\n  aws: {}\n",
+		"unknown":   "terraform:\n  allow: \">= 1.5\"\n",
+	} {
+		if _, err := LoadPolicy(writePolicy(t, content)); err == nil {
+			t.Errorf("%s: LoadPolicy succeeded, want error", name)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	policy, err := LoadPolicy(writePolicy(t, `
+terraform:
+  allowed: ">= 1.5, < 2.0"
+providers:
+  aws:
+    minimum: "5.0.0"
+    maximum: "5.99.99"
+  google:
+    allowed: "~> 6.0"
+banned_providers:
+  - template
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	configs := []core.TerraformConfig{
+		{
+			Path:              "prod",
+			RequiredVersion:   ">= 1.6, < 2.0",
+			RequiredProviders: map[string]string{"aws": "~> 5.40.0", "random": ">= 3.0"},
+		},
+		{
+			Path:              "legacy",
+			RequiredVersion:   ">= 1.0",
+			RequiredProviders: map[string]string{"aws": ">= 4.0", "google": "", "template": "~> 2.2", "null": "~>"},
+		},
+	}
+	out, err := policy.Check(configs, "policy.yaml", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Summary != (Summary{Workspaces: 2, NonCompliant: 1, Violations: 4}) {
+		t.Fatalf("summary = %+v", out.Summary)
+	}
+	if out.Workspaces[0].Path != "legacy" || out.Workspaces[1].Path != "prod" {
+		t.Fatalf("workspaces are not sorted: %+v", out.Workspaces)
+	}
+	if !out.Workspaces[1].Compliant {
+		t.Fatalf("prod should be compliant: %+v", out.Workspaces[1].Violations)
+	}
+
+	got := map[string]Violation{}
+	for _, violation := range out.Workspaces[0].Violations {
+		got[violation.Subject] = violation
+	}
+	if v := got["terraform"]; v.Kind != KindTerraformVersion || v.Excess != ">= 1.0.0, < 1.5.0 or >= 2.0.0" {
+		t.Errorf("terraform violation = %+v", v)
+	}
+	if v := got["aws"]; v.Kind != KindProviderVersion || v.Excess != ">= 4.0.0, < 5.0.0 or > 5.99.99" {
+		t.Errorf("aws violation = %+v", v)
+	}
+	if v := got["google"]; v.Kind != KindProviderVersion || !strings.Contains(v.Message, "no version constraint") {
+		t.Errorf("google violation = %+v", v)
+	}
+	if v := got["template"]; v.Kind != KindBannedProvider {
+		t.Errorf("template violation = %+v", v)
+	}
+	if _, ok := got["null"]; ok {
+		t.Errorf("providers without a rule should not be checked: %+v", got["null"])
+	}
+}
+
+func TestCheckBansProviderSource(t *testing.T) {
+	policy, err := LoadPolicy(writePolicy(t, "banned_providers:\n  - registry.terraform.io/hashicorp/template\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := policy.Check([]core.TerraformConfig{{
+		Path:              "ws",
+		RequiredProviders: map[string]string{"tpl": "~> 2.2"},
+		Providers: map[string]core.ProviderRequirement{
+			"tpl": {Source: "hashicorp/template", Address: "registry.terraform.io/hashicorp/template", Version: "~> 2.2"},
+		},
+	}}, "policy.yaml", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if violations := out.Workspaces[0].Violations; len(violations) != 1 || violations[0].Kind != KindBannedProvider {
+		t.Fatalf("violations = %+v", violations)
+	}
+}
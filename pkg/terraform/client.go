@@ -1,8 +1,14 @@
 package terraform
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os/exec"
+	"strings"
+	"time"
 )
 
 // Client represents a Terraform client
@@ -46,3 +52,64 @@ func (c *Client) Destroy(ctx context.Context) error {
 	cmd.Dir = c.WorkingDir
 	return cmd.Run()
 }
+
+// ShowJSON runs show -json on a saved plan file and streams the JSON document
+// from stdout. The caller must Close the returned reader; Close waits for the
+// process and reports a failed run together with its stderr. Closing before
+// stdout is exhausted stops the process, so callers can bound how much they read.
+func (c *Client) ShowJSON(ctx context.Context, planFile string) (io.ReadCloser, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	cmd := exec.CommandContext(ctx, c.BinaryPath, "show", "-json", planFile)
+	cmd.Dir = c.WorkingDir
+	// Do not let a lingering child process that still holds stderr open
+	// block Wait indefinitely after the plan has been read.
+	cmd.WaitDelay = showWaitDelay
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return nil, fmt.Errorf("run %s show -json: %w", c.BinaryPath, err)
+	}
+	return &showOutput{stdout: stdout, cmd: cmd, cancel: cancel, stderr: stderr}, nil
+}
+
+const showWaitDelay = 5 * time.Second
+
+type showOutput struct {
+	stdout io.ReadCloser
+	cmd    *exec.Cmd
+	cancel context.CancelFunc
+	stderr *bytes.Buffer
+	eof    bool
+}
+
+func (o *showOutput) Read(p []byte) (int, error) {
+	n, err := o.stdout.Read(p)
+	if errors.Is(err, io.EOF) {
+		o.eof = true
+	}
+	return n, err
+}
+
+func (o *showOutput) Close() error {
+	if !o.eof {
+		// The reader stopped early; the process result no longer matters.
+		_ = o.stdout.Close()
+		o.cancel()
+		_ = o.cmd.Wait()
+		return nil
+	}
+	defer o.cancel()
+	if err := o.cmd.Wait(); err != nil {
+		if message := strings.TrimSpace(o.stderr.String()); message != "" {
+			return fmt.Errorf("%s show -json failed: %w: %s", o.cmd.Path, err, message)
+		}
+		return fmt.Errorf("%s show -json failed: %w", o.cmd.Path, err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 yu-iskw
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// writeStub writes an executable shell script that stands in for the
+// terraform binary.
+func writeStub(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("stub binaries are shell scripts")
+	}
+	path := filepath.Join(t.TempDir(), "terraform")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestShowJSONStreamsStdout(t *testing.T) {
+	stub := writeStub(t, `[ "$1" = "show" ] && [ "$2" = "-json" ] || exit 2
+printf '{"plan":"%s"}' "$3"
+`)
+	output, err := NewClient(stub, t.TempDir()).ShowJSON(context.Background(), "tfplan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := output.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"plan":"tfplan"}` {
+		t.Fatalf("unexpected output %q", data)
+	}
+}
+
+func TestShowJSONReportsFailureWithStderr(t *testing.T) {
+	stub := writeStub(t, `echo "Error: Failed to read the given file as a state or plan file" >&2
+exit 1
+`)
+	output, err := NewClient(stub, "").ShowJSON(context.Background(), "tfplan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(output); err != nil {
+		t.Fatal(err)
+	}
+	err = output.Close()
+	if err == nil || !strings.Contains(err.Error(), "Failed to read the given file") {
+		t.Fatalf("expected show failure with stderr, got %v", err)
+	}
+}
+
+func TestShowJSONCloseStopsUnreadOutput(t *testing.T) {
+	stub := writeStub(t, `yes '{}'
+`)
+	output, err := NewClient(stub, "").ShowJSON(context.Background(), "tfplan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadFull(output, make([]byte, 64)); err != nil {
+		t.Fatal(err)
+	}
+	if err := output.Close(); err != nil {
+		t.Fatalf("closing early should not report an error, got %v", err)
+	}
+}
+
+func TestShowJSONMissingBinary(t *testing.T) {
+	_, err := NewClient(filepath.Join(t.TempDir(), "missing"), "").ShowJSON(context.Background(), "tfplan")
+	if err == nil {
+		t.Fatal("expected an error for a missing binary")
+	}
+}